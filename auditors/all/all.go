@@ -7,9 +7,14 @@ import (
 	"github.com/Shopify/kubeaudit"
 	"github.com/Shopify/kubeaudit/auditors/apparmor"
 	"github.com/Shopify/kubeaudit/auditors/asat"
+	"github.com/Shopify/kubeaudit/auditors/availability"
 	"github.com/Shopify/kubeaudit/auditors/capabilities"
 	"github.com/Shopify/kubeaudit/auditors/deprecatedapis"
+	"github.com/Shopify/kubeaudit/auditors/exposure"
+	"github.com/Shopify/kubeaudit/auditors/gateway"
 	"github.com/Shopify/kubeaudit/auditors/hostns"
+	"github.com/Shopify/kubeaudit/auditors/hostports"
+	"github.com/Shopify/kubeaudit/auditors/hostprocess"
 	"github.com/Shopify/kubeaudit/auditors/image"
 	"github.com/Shopify/kubeaudit/auditors/limits"
 	"github.com/Shopify/kubeaudit/auditors/mounts"
@@ -17,8 +22,15 @@ import (
 	"github.com/Shopify/kubeaudit/auditors/nonroot"
 	"github.com/Shopify/kubeaudit/auditors/privesc"
 	"github.com/Shopify/kubeaudit/auditors/privileged"
+	"github.com/Shopify/kubeaudit/auditors/probes"
+	"github.com/Shopify/kubeaudit/auditors/procmount"
 	"github.com/Shopify/kubeaudit/auditors/rootfs"
 	"github.com/Shopify/kubeaudit/auditors/seccomp"
+	"github.com/Shopify/kubeaudit/auditors/selinux"
+	"github.com/Shopify/kubeaudit/auditors/serviceaccounts"
+	"github.com/Shopify/kubeaudit/auditors/sysctls"
+	"github.com/Shopify/kubeaudit/auditors/volumes"
+	"github.com/Shopify/kubeaudit/auditors/webhooks"
 	"github.com/Shopify/kubeaudit/config"
 )
 
@@ -27,9 +39,14 @@ var ErrUnknownAuditor = errors.New("Unknown auditor")
 var AuditorNames = []string{
 	apparmor.Name,
 	asat.Name,
+	availability.Name,
 	capabilities.Name,
 	deprecatedapis.Name,
+	exposure.Name,
+	gateway.Name,
 	hostns.Name,
+	hostports.Name,
+	hostprocess.Name,
 	image.Name,
 	limits.Name,
 	mounts.Name,
@@ -37,8 +54,15 @@ var AuditorNames = []string{
 	nonroot.Name,
 	privesc.Name,
 	privileged.Name,
+	probes.Name,
+	procmount.Name,
 	rootfs.Name,
 	seccomp.Name,
+	selinux.Name,
+	serviceaccounts.Name,
+	sysctls.Name,
+	volumes.Name,
+	webhooks.Name,
 }
 
 func Auditors(conf config.KubeauditConfig) ([]kubeaudit.Auditable, error) {
@@ -48,7 +72,15 @@ func Auditors(conf config.KubeauditConfig) ([]kubeaudit.Auditable, error) {
 		if err != nil {
 			return nil, err
 		}
-		auditors = append(auditors, auditor)
+
+		if len(conf.GetProfiles()) > 0 {
+			auditor, err = initProfiledAuditor(auditorName, auditor, conf.GetProfiles())
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		auditors = append(auditors, conf.GetOverrides().Auditor(auditor))
 	}
 
 	return auditors, nil
@@ -70,33 +102,57 @@ func getEnabledAuditors(conf config.KubeauditConfig) []string {
 func initAuditor(name string, conf config.KubeauditConfig) (kubeaudit.Auditable, error) {
 	switch name {
 	case apparmor.Name:
-		return apparmor.New(), nil
+		return apparmor.NewWithConfig(conf.GetAuditorConfigs().AppArmor)
 	case asat.Name:
 		return asat.New(), nil
+	case availability.Name:
+		return availability.New(conf.GetAuditorConfigs().Availability)
 	case capabilities.Name:
 		return capabilities.New(conf.GetAuditorConfigs().Capabilities), nil
 	case deprecatedapis.Name:
 		return deprecatedapis.New(conf.GetAuditorConfigs().DeprecatedAPIs)
+	case exposure.Name:
+		return exposure.New(), nil
+	case gateway.Name:
+		return gateway.New(), nil
 	case hostns.Name:
 		return hostns.New(), nil
+	case hostports.Name:
+		return hostports.New(conf.GetAuditorConfigs().HostPorts), nil
+	case hostprocess.Name:
+		return hostprocess.New(), nil
 	case image.Name:
 		return image.New(conf.GetAuditorConfigs().Image), nil
 	case limits.Name:
 		return limits.New(conf.GetAuditorConfigs().Limits)
 	case mounts.Name:
-		return mounts.New(conf.GetAuditorConfigs().Mounts), nil
+		return mounts.New(conf.GetAuditorConfigs().Mounts)
 	case netpols.Name:
 		return netpols.New(), nil
 	case nonroot.Name:
-		return nonroot.New(), nil
+		return nonroot.NewWithConfig(conf.GetAuditorConfigs().NonRoot)
 	case privesc.Name:
 		return privesc.New(), nil
 	case privileged.Name:
 		return privileged.New(), nil
+	case probes.Name:
+		return probes.New(conf.GetAuditorConfigs().Probes)
+	case procmount.Name:
+		return procmount.New(), nil
 	case rootfs.Name:
 		return rootfs.New(), nil
 	case seccomp.Name:
-		return seccomp.New(), nil
+		return seccomp.NewWithConfig(conf.GetAuditorConfigs().Seccomp)
+	case selinux.Name:
+		return selinux.New(conf.GetAuditorConfigs().SELinux), nil
+	case serviceaccounts.Name:
+		return serviceaccounts.New(conf.GetAuditorConfigs().ServiceAccounts), nil
+	case sysctls.Name:
+		return sysctls.New(conf.GetAuditorConfigs().Sysctls), nil
+	case volumes.Name:
+		return volumes.New(conf.GetAuditorConfigs().Volumes)
+	case webhooks.Name:
+		return webhooks.New(conf.GetAuditorConfigs().Webhooks)
 	}
 
 	return nil, fmt.Errorf("unknown auditor %s: %w", name, ErrUnknownAuditor)
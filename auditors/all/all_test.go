@@ -7,23 +7,37 @@ import (
 	"github.com/Shopify/kubeaudit"
 	"github.com/Shopify/kubeaudit/auditors/apparmor"
 	"github.com/Shopify/kubeaudit/auditors/asat"
+	"github.com/Shopify/kubeaudit/auditors/availability"
 	"github.com/Shopify/kubeaudit/auditors/capabilities"
 	"github.com/Shopify/kubeaudit/auditors/deprecatedapis"
+	"github.com/Shopify/kubeaudit/auditors/exposure"
+	"github.com/Shopify/kubeaudit/auditors/gateway"
 	"github.com/Shopify/kubeaudit/auditors/mounts"
 
 	"github.com/Shopify/kubeaudit/auditors/hostns"
+	"github.com/Shopify/kubeaudit/auditors/hostports"
+	"github.com/Shopify/kubeaudit/auditors/hostprocess"
 	"github.com/Shopify/kubeaudit/auditors/image"
 	"github.com/Shopify/kubeaudit/auditors/limits"
 	"github.com/Shopify/kubeaudit/auditors/netpols"
 	"github.com/Shopify/kubeaudit/auditors/nonroot"
 	"github.com/Shopify/kubeaudit/auditors/privesc"
 	"github.com/Shopify/kubeaudit/auditors/privileged"
+	"github.com/Shopify/kubeaudit/auditors/probes"
+	"github.com/Shopify/kubeaudit/auditors/procmount"
 	"github.com/Shopify/kubeaudit/auditors/rootfs"
 	"github.com/Shopify/kubeaudit/auditors/seccomp"
+	"github.com/Shopify/kubeaudit/auditors/selinux"
+	"github.com/Shopify/kubeaudit/auditors/serviceaccounts"
+	"github.com/Shopify/kubeaudit/auditors/sysctls"
+	"github.com/Shopify/kubeaudit/auditors/volumes"
+	"github.com/Shopify/kubeaudit/auditors/webhooks"
 	"github.com/Shopify/kubeaudit/config"
 	"github.com/Shopify/kubeaudit/internal/test"
+	"github.com/Shopify/kubeaudit/pkg/k8s"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 const fixtureDir = "../../internal/test/fixtures/all_resources"
@@ -33,12 +47,16 @@ func TestAuditAll(t *testing.T) {
 		apparmor.AppArmorAnnotationMissing,
 		asat.AutomountServiceAccountTokenTrueAndDefaultSA,
 		capabilities.CapabilityOrSecurityContextMissing,
+		exposure.ExposedWithoutNetworkPolicy,
 		hostns.NamespaceHostNetworkTrue,
 		hostns.NamespaceHostIPCTrue,
 		hostns.NamespaceHostPIDTrue,
 		image.ImageTagMissing,
 		limits.LimitsNotSet,
 		netpols.MissingDefaultDenyIngressAndEgressNetworkPolicy,
+		netpols.IngressAllowedFromAllNamespaces,
+		netpols.IngressAllowedFromInternet,
+		netpols.EgressUnrestricted,
 		nonroot.RunAsNonRootPSCNilCSCNil,
 		privesc.AllowPrivilegeEscalationNil,
 		privileged.PrivilegedNil,
@@ -47,8 +65,13 @@ func TestAuditAll(t *testing.T) {
 	}
 
 	allAuditors, err := Auditors(
-		// Not all the tested resources raise an deprecated API error
-		config.KubeauditConfig{EnabledAuditors: map[string]bool{deprecatedapis.Name: false}})
+		// Not all the tested resources raise an deprecated API error, Jobs and CronJobs don't need probes and only
+		// Deployments and StatefulSets are checked for availability
+		config.KubeauditConfig{EnabledAuditors: map[string]bool{
+			deprecatedapis.Name: false,
+			probes.Name:         false,
+			availability.Name:   false,
+		}})
 	require.NoError(t, err)
 
 	for _, file := range test.GetAllFileNames(t, fixtureDir) {
@@ -102,6 +125,136 @@ func TestAllWithConfig(t *testing.T) {
 	}
 }
 
+func TestAllWithProfiles(t *testing.T) {
+	conf := config.KubeauditConfig{
+		EnabledAuditors: enabledAuditorsToMap([]string{limits.Name}),
+		AuditorConfig: config.AuditorConfig{
+			Limits: limits.Config{CPU: "500m"},
+		},
+		Profiles: []config.Profile{
+			{
+				Name:          "system",
+				Match:         config.ProfileMatch{Namespaces: []string{"kube-*"}, LabelSelector: "tier=system"},
+				AuditorConfig: config.AuditorConfig{Limits: limits.Config{CPU: "2"}},
+			},
+		},
+	}
+	auditors, err := Auditors(conf)
+	require.NoError(t, err)
+
+	report := test.AuditMultiple(t, "fixtures", "profiles.yml", auditors, []string{limits.LimitsCPUExceeded}, "", test.MANIFEST_MODE)
+	for _, result := range report.Results() {
+		assert.Equal(t, "app-deployment", result.GetResource().Object().(*k8s.DeploymentV1).Name)
+		for _, auditResult := range result.GetAuditResults() {
+			assert.NotContains(t, auditResult.Metadata, ProfileMetadataKey)
+		}
+	}
+
+	// The profile's auditor config is used for resources matched by the profile
+	conf.Profiles[0].AuditorConfig.Limits.CPU = "750m"
+	auditors, err = Auditors(conf)
+	require.NoError(t, err)
+
+	report = test.AuditMultiple(t, "fixtures", "profiles.yml", auditors, []string{limits.LimitsCPUExceeded}, "", test.MANIFEST_MODE)
+	require.Len(t, report.Results(), 2)
+	for _, result := range report.Results() {
+		for _, auditResult := range result.GetAuditResults() {
+			if result.GetResource().Object().(*k8s.DeploymentV1).Name == "system-deployment" {
+				assert.Equal(t, "system", auditResult.Metadata[ProfileMetadataKey])
+				assert.Equal(t, "750m", auditResult.Metadata["MaxCPU"])
+			} else {
+				assert.NotContains(t, auditResult.Metadata, ProfileMetadataKey)
+				assert.Equal(t, "500m", auditResult.Metadata["MaxCPU"])
+			}
+		}
+	}
+
+	conf.Profiles[0].Match.LabelSelector = "tier in ("
+	_, err = Auditors(conf)
+	require.Error(t, err)
+}
+
+func TestAllWithCustomResources(t *testing.T) {
+	conf := config.KubeauditConfig{
+		EnabledAuditors: enabledAuditorsToMap([]string{privileged.Name}),
+		CustomResources: []k8s.CustomResourceConfig{
+			{APIVersion: "argoproj.io/v1alpha1", Kind: "Rollout", PodTemplatePaths: []string{"{.spec.template}"}},
+			{APIVersion: "serving.knative.dev/v1", Kind: "Service", PodTemplatePaths: []string{".spec.template"}},
+		},
+	}
+	auditors, err := Auditors(conf)
+	require.NoError(t, err)
+	withCustomResources := kubeaudit.WithCustomResources(conf.GetCustomResources())
+
+	// Custom resources are only audited if they are configured with kubeaudit.WithCustomResources
+	test.AuditMultiple(t, "fixtures", "custom-resources.yml", auditors, []string{}, "", test.MANIFEST_MODE)
+
+	test.AuditMultiple(t, "fixtures", "custom-resources.yml", auditors,
+		[]string{privileged.PrivilegedTrue, privileged.PrivilegedNil}, "", test.MANIFEST_MODE, withCustomResources)
+
+	fixedResources, _ := test.FixSetupMultiple(t, "fixtures", "custom-resources.yml", auditors, withCustomResources)
+	require.Len(t, fixedResources, 2)
+	for _, resource := range fixedResources {
+		customResource, ok := resource.(*k8s.CustomResource)
+		require.True(t, ok)
+		for _, container := range k8s.GetContainers(customResource) {
+			assert.False(t, *container.SecurityContext.Privileged)
+		}
+	}
+
+	// Fields of the pod template which aren't part of PodTemplateSpec are kept
+	knative, err := fixedResources[1].(*k8s.CustomResource).ToUnstructured()
+	require.NoError(t, err)
+	containerConcurrency, _, _ := unstructured.NestedInt64(knative.Object, "spec", "template", "spec", "containerConcurrency")
+	assert.Equal(t, int64(10), containerConcurrency)
+
+	conf.CustomResources[0].PodTemplatePaths = []string{"spec.template"}
+	_, err = kubeaudit.New(auditors, kubeaudit.WithCustomResources(conf.GetCustomResources()))
+	require.Error(t, err)
+}
+
+func TestAllWithCustomResourceTemplates(t *testing.T) {
+	conf := config.KubeauditConfig{
+		EnabledAuditors: enabledAuditorsToMap([]string{privileged.Name}),
+		CustomResources: []k8s.CustomResourceConfig{
+			{APIVersion: "example.com/v1", Kind: "Workflow", PodTemplatePaths: []string{
+				".spec.steps[0].template", ".spec.steps[1].template", ".spec.steps[2].template",
+			}},
+		},
+	}
+	auditors, err := Auditors(conf)
+	require.NoError(t, err)
+	withCustomResources := kubeaudit.WithCustomResources(conf.GetCustomResources())
+
+	// Every pod template found is audited
+	report := test.AuditMultiple(t, "fixtures", "custom-resources-multiple-templates.yml", auditors,
+		[]string{privileged.PrivilegedTrue, privileged.PrivilegedNil}, "", test.MANIFEST_MODE, withCustomResources)
+	podTemplatePaths := map[string]string{}
+	for _, result := range report.Results() {
+		for _, auditResult := range result.GetAuditResults() {
+			podTemplatePaths[auditResult.Rule] = auditResult.Metadata[kubeaudit.PodTemplatePathMetadataKey]
+		}
+	}
+	assert.Equal(t, map[string]string{
+		privileged.PrivilegedTrue: "spec.steps.0.template",
+		privileged.PrivilegedNil:  "spec.steps.1.template",
+	}, podTemplatePaths)
+
+	fixedResources, _ := test.FixSetupMultiple(t, "fixtures", "custom-resources-multiple-templates.yml", auditors, withCustomResources)
+	require.Len(t, fixedResources, 1)
+	workflow, err := fixedResources[0].(*k8s.CustomResource).ToUnstructured()
+	require.NoError(t, err)
+	steps, _, _ := unstructured.NestedSlice(workflow.Object, "spec", "steps")
+	require.Len(t, steps, 2)
+	for _, step := range steps {
+		containers, _, _ := unstructured.NestedSlice(step.(map[string]interface{}), "template", "spec", "containers")
+		require.Len(t, containers, 1)
+		privileged, found, _ := unstructured.NestedBool(containers[0].(map[string]interface{}), "securityContext", "privileged")
+		assert.True(t, found)
+		assert.False(t, privileged)
+	}
+}
+
 func TestGetEnabledAuditors(t *testing.T) {
 	cases := []struct {
 		testName         string
@@ -123,9 +276,14 @@ func TestGetEnabledAuditors(t *testing.T) {
 			},
 			expectedAuditors: []string{
 				asat.Name,
+				availability.Name,
 				capabilities.Name,
 				deprecatedapis.Name,
+				exposure.Name,
+				gateway.Name,
 				hostns.Name,
+				hostports.Name,
+				hostprocess.Name,
 				image.Name,
 				limits.Name,
 				mounts.Name,
@@ -133,7 +291,14 @@ func TestGetEnabledAuditors(t *testing.T) {
 				nonroot.Name,
 				privesc.Name,
 				privileged.Name,
+				probes.Name,
+				procmount.Name,
 				seccomp.Name,
+				selinux.Name,
+				serviceaccounts.Name,
+				sysctls.Name,
+				volumes.Name,
+				webhooks.Name,
 			},
 		},
 		{
@@ -155,9 +320,14 @@ func TestGetEnabledAuditors(t *testing.T) {
 			},
 			expectedAuditors: []string{
 				asat.Name,
+				availability.Name,
 				capabilities.Name,
 				deprecatedapis.Name,
+				exposure.Name,
+				gateway.Name,
 				hostns.Name,
+				hostports.Name,
+				hostprocess.Name,
 				image.Name,
 				limits.Name,
 				mounts.Name,
@@ -165,7 +335,14 @@ func TestGetEnabledAuditors(t *testing.T) {
 				nonroot.Name,
 				privesc.Name,
 				privileged.Name,
+				probes.Name,
+				procmount.Name,
 				seccomp.Name,
+				selinux.Name,
+				serviceaccounts.Name,
+				sysctls.Name,
+				volumes.Name,
+				webhooks.Name,
 			},
 		},
 	}
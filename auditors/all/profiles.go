@@ -0,0 +1,73 @@
+package all
+
+import (
+	"fmt"
+
+	"github.com/Shopify/kubeaudit"
+	"github.com/Shopify/kubeaudit/config"
+	"github.com/Shopify/kubeaudit/pkg/k8s"
+)
+
+// ProfileMetadataKey is the audit result metadata key holding the name of the profile used to audit the resource
+const ProfileMetadataKey = "Profile"
+
+// profiledAuditor implements Auditable. It audits each resource using the auditor initialized with the config of the
+// first profile matching the resource, or the default auditor if no profile matches
+type profiledAuditor struct {
+	auditor  kubeaudit.Auditable
+	profiles []profileAuditor
+}
+
+type profileAuditor struct {
+	profile config.Profile
+	auditor kubeaudit.Auditable
+}
+
+func initProfiledAuditor(name string, auditor kubeaudit.Auditable, profiles []config.Profile) (*profiledAuditor, error) {
+	profiled := &profiledAuditor{auditor: auditor}
+
+	for _, profile := range profiles {
+		if _, err := profile.Match.Selector(); err != nil {
+			return nil, fmt.Errorf("invalid profile %q: %w", profile.Name, err)
+		}
+
+		profileAuditable, err := initAuditor(name, config.KubeauditConfig{AuditorConfig: profile.AuditorConfig})
+		if err != nil {
+			return nil, fmt.Errorf("error initializing auditor %s for profile %q: %w", name, profile.Name, err)
+		}
+
+		profiled.profiles = append(profiled.profiles, profileAuditor{profile: profile, auditor: profileAuditable})
+	}
+
+	return profiled, nil
+}
+
+// Audit runs the auditor configured for the resource and records the name of the matching profile in the metadata
+// of each audit result
+func (a *profiledAuditor) Audit(resource k8s.Resource, resources []k8s.Resource) ([]*kubeaudit.AuditResult, error) {
+	for _, profile := range a.profiles {
+		matched, err := profile.profile.Match.Matches(resource)
+		if err != nil {
+			return nil, fmt.Errorf("error matching profile %q: %w", profile.profile.Name, err)
+		}
+		if !matched {
+			continue
+		}
+
+		auditResults, err := profile.auditor.Audit(resource, resources)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, auditResult := range auditResults {
+			if auditResult.Metadata == nil {
+				auditResult.Metadata = kubeaudit.Metadata{}
+			}
+			auditResult.Metadata[ProfileMetadataKey] = profile.profile.Name
+		}
+
+		return auditResults, nil
+	}
+
+	return a.auditor.Audit(resource, resources)
+}
@@ -2,52 +2,81 @@ package apparmor
 
 import (
 	"fmt"
+	"path"
+	"sort"
 	"strings"
 
 	"github.com/Shopify/kubeaudit"
 	"github.com/Shopify/kubeaudit/pkg/fix"
 	"github.com/Shopify/kubeaudit/pkg/k8s"
 	"github.com/Shopify/kubeaudit/pkg/override"
+	apiv1 "k8s.io/api/core/v1"
 )
 
 const Name = "apparmor"
 
 const (
-	// AppArmorAnnotationMissing occurs when the apparmor annotation is missing
+	// AppArmorAnnotationMissing occurs when there is no AppArmor profile for a container, neither in the pod or
+	// container SecurityContext nor in an apparmor annotation
 	AppArmorAnnotationMissing = "AppArmorAnnotationMissing"
-	// AppArmorDisabled occurs when the apparmor annotation is set to the unconfined value
+	// AppArmorDisabled occurs when the AppArmor profile is set to the unconfined value
 	AppArmorDisabled = "AppArmorDisabled"
-	// AppArmorDisabled occurs when the apparmor annotation is set to a bad value
+	// AppArmorDisabled occurs when the AppArmor profile is set to a bad value
 	AppArmorBadValue = "AppArmorBadValue"
 	// AppArmorInvalidAnnotation occurs when the apparmor annotation key refers to a container which doesn't exist. This will
 	// prevent the manifest from being applied to a cluster with AppArmor enabled.
 	AppArmorInvalidAnnotation = "AppArmorInvalidAnnotation"
+	// AppArmorDeprecatedAnnotations occurs when apparmor annotations are used instead of the AppArmor profile in the
+	// container SecurityContext
+	AppArmorDeprecatedAnnotations = "AppArmorDeprecatedAnnotations"
+	// AppArmorLocalhostProfileNotAllowed occurs when a localhost AppArmor profile is not one of the allowed localhost
+	// profiles
+	AppArmorLocalhostProfileNotAllowed = "AppArmorLocalhostProfileNotAllowed"
 )
 
-// As of Jan 14, 2020 these constants are not in the K8s API package, but once they are they should be replaced
-// https://github.com/kubernetes/kubernetes/blob/master/pkg/security/apparmor/helpers.go#L25
+// The apparmor annotations are deprecated in favour of the appArmorProfile SecurityContext field since Kubernetes 1.30
+// https://kubernetes.io/docs/tutorials/security/apparmor/
 const (
 	// The prefix to an annotation key specifying a container profile.
-	ContainerAnnotationKeyPrefix = "container.apparmor.security.beta.kubernetes.io/"
+	ContainerAnnotationKeyPrefix = apiv1.DeprecatedAppArmorBetaContainerAnnotationKeyPrefix
 
 	// The profile specifying the runtime default.
-	ProfileRuntimeDefault = "runtime/default"
+	ProfileRuntimeDefault = apiv1.DeprecatedAppArmorBetaProfileRuntimeDefault
 	// The profile specifying the unconfined profile.
-	ProfileUnconfined = "unconfined"
+	ProfileUnconfined = apiv1.DeprecatedAppArmorBetaProfileNameUnconfined
 	// The prefix for specifying profiles loaded on the node.
-	ProfileNamePrefix = "localhost/"
+	ProfileNamePrefix = apiv1.DeprecatedAppArmorBetaProfileNamePrefix
 )
 
 const OverrideLabel = "allow-disabled-apparmor"
 
 // AppArmor implements Auditable
-type AppArmor struct{}
+type AppArmor struct {
+	allowedLocalhostProfiles []string
+	useSecurityContext       bool
+}
 
+// New returns an AppArmor auditor which allows every localhost profile and fixes missing profiles with annotations
 func New() *AppArmor {
 	return &AppArmor{}
 }
 
-// Audit checks that AppArmor is enabled for all containers
+// NewWithConfig returns an AppArmor auditor which only allows the localhost profiles of the config, and which fixes
+// missing profiles in the SecurityContext if the config says so
+func NewWithConfig(config Config) (*AppArmor, error) {
+	for _, pattern := range config.GetAllowedLocalhostProfiles() {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("error creating AppArmor auditor: error parsing allowed localhost profile %q: %w", pattern, err)
+		}
+	}
+	return &AppArmor{
+		allowedLocalhostProfiles: config.GetAllowedLocalhostProfiles(),
+		useSecurityContext:       config.GetUseSecurityContext(),
+	}, nil
+}
+
+// Audit checks that AppArmor is enabled for all containers. The AppArmor profile of a container is taken from its
+// SecurityContext, its apparmor annotation or the pod SecurityContext, in that order. Localhost profiles must be allowed
 func (a *AppArmor) Audit(resource k8s.Resource, _ []k8s.Resource) ([]*kubeaudit.AuditResult, error) {
 	var auditResults []*kubeaudit.AuditResult
 	var containerNames []string
@@ -55,7 +84,7 @@ func (a *AppArmor) Audit(resource k8s.Resource, _ []k8s.Resource) ([]*kubeaudit.
 	for _, container := range k8s.GetContainers(resource) {
 		containerName := container.Name
 		containerNames = append(containerNames, containerName)
-		auditResult := auditContainer(container, resource)
+		auditResult := a.auditContainer(container, resource)
 		auditResult = applyDisabledOverride(auditResult, containerName, resource)
 		if auditResult != nil {
 			auditResults = append(auditResults, auditResult)
@@ -64,19 +93,38 @@ func (a *AppArmor) Audit(resource k8s.Resource, _ []k8s.Resource) ([]*kubeaudit.
 
 	auditResults = append(auditResults, auditPodAnnotations(resource, containerNames)...)
 
+	if auditResult := a.auditDeprecatedAnnotations(resource); auditResult != nil {
+		auditResults = append(auditResults, auditResult)
+	}
+
 	return auditResults, nil
 }
 
-func auditContainer(container *k8s.ContainerV1, resource k8s.Resource) *kubeaudit.AuditResult {
+func (a *AppArmor) auditContainer(container *k8s.ContainerV1, resource k8s.Resource) *kubeaudit.AuditResult {
+	if profile := getContainerProfile(container); profile != nil {
+		return a.auditProfile(container, profile, &BySettingAppArmorProfileInContainer{
+			container:   container,
+			profileType: apiv1.AppArmorProfileTypeRuntimeDefault,
+		})
+	}
+
 	annotations := k8s.GetAnnotations(resource)
 	containerAnnotation := getContainerAnnotation(container)
 
 	if isAppArmorAnnotationMissing(containerAnnotation, annotations) {
-		return &kubeaudit.AuditResult{
+		if profile := getPodProfile(resource); profile != nil {
+			// The pod profile is fixed for this container only so the pod profile of overridden containers is kept
+			return a.auditProfile(container, profile, &BySettingAppArmorProfileInContainer{
+				container:   container,
+				profileType: apiv1.AppArmorProfileTypeRuntimeDefault,
+			})
+		}
+
+		auditResult := &kubeaudit.AuditResult{
 			Auditor:  Name,
 			Rule:     AppArmorAnnotationMissing,
 			Severity: kubeaudit.Error,
-			Message:  fmt.Sprintf("AppArmor annotation missing. The annotation '%s' should be added.", containerAnnotation),
+			Message:  fmt.Sprintf("AppArmor profile missing. The annotation '%s' should be added, or the AppArmor profile type should be set to '%s' in the pod or container SecurityContext on Kubernetes 1.30 or later.", containerAnnotation, apiv1.AppArmorProfileTypeRuntimeDefault),
 			Metadata: kubeaudit.Metadata{
 				"Container":         container.Name,
 				"MissingAnnotation": containerAnnotation,
@@ -86,6 +134,12 @@ func auditContainer(container *k8s.ContainerV1, resource k8s.Resource) *kubeaudi
 				Value: ProfileRuntimeDefault,
 			},
 		}
+		if a.useSecurityContext {
+			auditResult.PendingFix = &BySettingAppArmorProfile{
+				profileType: apiv1.AppArmorProfileTypeRuntimeDefault,
+			}
+		}
+		return auditResult
 	}
 
 	if isAppArmorDisabled(containerAnnotation, annotations) {
@@ -95,7 +149,7 @@ func auditContainer(container *k8s.ContainerV1, resource k8s.Resource) *kubeaudi
 		} else {
 			rule = AppArmorBadValue
 		}
-		
+
 		return &kubeaudit.AuditResult{
 			Auditor:  Name,
 			Rule:     rule,
@@ -113,11 +167,69 @@ func auditContainer(container *k8s.ContainerV1, resource k8s.Resource) *kubeaudi
 		}
 	}
 
+	profileName := getProfileName(containerAnnotation, annotations)
+	localhostProfile := strings.TrimPrefix(profileName, ProfileNamePrefix)
+	if strings.HasPrefix(profileName, ProfileNamePrefix) && !a.isLocalhostProfileAllowed(localhostProfile) {
+		return &kubeaudit.AuditResult{
+			Auditor:  Name,
+			Rule:     AppArmorLocalhostProfileNotAllowed,
+			Message:  fmt.Sprintf("AppArmor localhost profile %s is not an allowed localhost profile. The apparmor annotation should be set to an allowed localhost profile or '%s'.", localhostProfile, ProfileRuntimeDefault),
+			Severity: kubeaudit.Error,
+			Metadata: kubeaudit.Metadata{
+				"Container":        container.Name,
+				"Annotation":       containerAnnotation,
+				"LocalhostProfile": localhostProfile,
+			},
+			PendingFix: &fix.BySettingPodAnnotation{
+				Key:   containerAnnotation,
+				Value: ProfileRuntimeDefault,
+			},
+		}
+	}
+
 	return nil
 }
 
+// auditProfile audits an AppArmor profile set in the pod or container SecurityContext
+func (a *AppArmor) auditProfile(container *k8s.ContainerV1, profile *apiv1.AppArmorProfile, pendingFix kubeaudit.PendingFix) *kubeaudit.AuditResult {
+	if isProfileEnabled(profile) {
+		if profile.Type != apiv1.AppArmorProfileTypeLocalhost || a.isLocalhostProfileAllowed(*profile.LocalhostProfile) {
+			return nil
+		}
+
+		return &kubeaudit.AuditResult{
+			Auditor:  Name,
+			Rule:     AppArmorLocalhostProfileNotAllowed,
+			Message:  fmt.Sprintf("AppArmor localhost profile %s is not an allowed localhost profile. The AppArmor profile should be set to an allowed localhost profile or '%s'.", *profile.LocalhostProfile, apiv1.AppArmorProfileTypeRuntimeDefault),
+			Severity: kubeaudit.Error,
+			Metadata: kubeaudit.Metadata{
+				"Container":        container.Name,
+				"LocalhostProfile": *profile.LocalhostProfile,
+			},
+			PendingFix: pendingFix,
+		}
+	}
+
+	rule := AppArmorBadValue
+	if profile.Type == apiv1.AppArmorProfileTypeUnconfined {
+		rule = AppArmorDisabled
+	}
+
+	return &kubeaudit.AuditResult{
+		Auditor:  Name,
+		Rule:     rule,
+		Message:  fmt.Sprintf("AppArmor is disabled. The AppArmor profile type should be set to '%s' or '%s' with a localhost profile.", apiv1.AppArmorProfileTypeRuntimeDefault, apiv1.AppArmorProfileTypeLocalhost),
+		Severity: kubeaudit.Error,
+		Metadata: kubeaudit.Metadata{
+			"Container":           container.Name,
+			"AppArmorProfileType": string(profile.Type),
+		},
+		PendingFix: pendingFix,
+	}
+}
+
 func applyDisabledOverride(auditResult *kubeaudit.AuditResult, containerName string, resource k8s.Resource) *kubeaudit.AuditResult {
-	if auditResult == nil || auditResult.Rule != AppArmorDisabled {
+	if auditResult == nil || auditResult.Rule != AppArmorDisabled && auditResult.Rule != AppArmorLocalhostProfileNotAllowed {
 		return auditResult
 	}
 	return override.ApplyOverride(auditResult, Name, containerName, resource, OverrideLabel)
@@ -149,6 +261,93 @@ func auditPodAnnotations(resource k8s.Resource, containerNames []string) []*kube
 	return auditResults
 }
 
+// auditDeprecatedAnnotations checks for apparmor annotations of containers without an AppArmor profile in their
+// SecurityContext. In local and cluster mode Kubernetes copies the annotations of pods to the container SecurityContext
+// so only the annotations of pod templates are reported. The annotations are only migrated by autofix if the auditor
+// uses the SecurityContext, since older API servers drop the AppArmor profile field
+func (a *AppArmor) auditDeprecatedAnnotations(resource k8s.Resource) *kubeaudit.AuditResult {
+	annotations := k8s.GetAnnotations(resource)
+
+	var deprecatedAnnotations []string
+	for _, container := range k8s.GetContainers(resource) {
+		containerAnnotation := getContainerAnnotation(container)
+		if _, ok := annotations[containerAnnotation]; ok && getContainerProfile(container) == nil {
+			deprecatedAnnotations = append(deprecatedAnnotations, containerAnnotation)
+		}
+	}
+
+	if len(deprecatedAnnotations) == 0 {
+		return nil
+	}
+	sort.Strings(deprecatedAnnotations)
+
+	auditResult := &kubeaudit.AuditResult{
+		Auditor:  Name,
+		Rule:     AppArmorDeprecatedAnnotations,
+		Severity: kubeaudit.Warn,
+		Message:  "AppArmor annotations are deprecated since Kubernetes 1.30. The AppArmor profile should be set in the container SecurityContext.",
+		Metadata: kubeaudit.Metadata{"AnnotationKeys": strings.Join(deprecatedAnnotations, ", ")},
+	}
+	if a.useSecurityContext {
+		auditResult.PendingFix = &ByMigratingAnnotations{keys: deprecatedAnnotations}
+	}
+	return auditResult
+}
+
+func getContainerProfile(container *k8s.ContainerV1) *apiv1.AppArmorProfile {
+	if container.SecurityContext == nil {
+		return nil
+	}
+	return container.SecurityContext.AppArmorProfile
+}
+
+func getPodProfile(resource k8s.Resource) *apiv1.AppArmorProfile {
+	podSpec := k8s.GetPodSpec(resource)
+	if podSpec == nil || podSpec.SecurityContext == nil {
+		return nil
+	}
+	return podSpec.SecurityContext.AppArmorProfile
+}
+
+// isLocalhostProfileAllowed returns true if the localhost profile matches one of the allowed localhost profiles, or if
+// there are no allowed localhost profiles
+func (a *AppArmor) isLocalhostProfileAllowed(localhostProfile string) bool {
+	if len(a.allowedLocalhostProfiles) == 0 {
+		return true
+	}
+	for _, pattern := range a.allowedLocalhostProfiles {
+		if matched, _ := path.Match(pattern, localhostProfile); matched {
+			return true
+		}
+	}
+	return false
+}
+
+func isProfileEnabled(profile *apiv1.AppArmorProfile) bool {
+	switch profile.Type {
+	case apiv1.AppArmorProfileTypeRuntimeDefault:
+		return true
+	case apiv1.AppArmorProfileTypeLocalhost:
+		return profile.LocalhostProfile != nil && *profile.LocalhostProfile != ""
+	}
+	return false
+}
+
+// annotationToProfile converts the value of an apparmor annotation to the equivalent AppArmor profile. It returns
+// false if the value isn't a valid profile
+func annotationToProfile(profileName string) (*apiv1.AppArmorProfile, bool) {
+	switch {
+	case profileName == ProfileRuntimeDefault:
+		return &apiv1.AppArmorProfile{Type: apiv1.AppArmorProfileTypeRuntimeDefault}, true
+	case profileName == ProfileUnconfined:
+		return &apiv1.AppArmorProfile{Type: apiv1.AppArmorProfileTypeUnconfined}, true
+	case strings.HasPrefix(profileName, ProfileNamePrefix) && profileName != ProfileNamePrefix:
+		localhostProfile := strings.TrimPrefix(profileName, ProfileNamePrefix)
+		return &apiv1.AppArmorProfile{Type: apiv1.AppArmorProfileTypeLocalhost, LocalhostProfile: &localhostProfile}, true
+	}
+	return nil, false
+}
+
 func isAppArmorAnnotationMissing(apparmorAnnotation string, annotations map[string]string) bool {
 	_, ok := annotations[apparmorAnnotation]
 	return !ok
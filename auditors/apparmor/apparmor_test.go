@@ -8,6 +8,8 @@ import (
 	"github.com/Shopify/kubeaudit/pkg/k8s"
 	"github.com/Shopify/kubeaudit/pkg/override"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
 )
 
 const fixtureDir = "fixtures"
@@ -18,18 +20,25 @@ func TestAuditAppArmor(t *testing.T) {
 		expectedErrors []string
 		testLocalMode  bool
 	}{
-		{"apparmor-enabled.yml", nil, true},
+		// Kubernetes copies the apparmor annotations of pods to the container SecurityContext, so the deprecated
+		// annotations are only reported in manifest mode
+		{"apparmor-enabled.yml", []string{AppArmorDeprecatedAnnotations}, false},
 		{"apparmor-annotation-missing.yml", []string{AppArmorAnnotationMissing}, true},
-		{"apparmor-annotation-init-container-enabled.yml", nil, true},
-		{"apparmor-annotation-init-container-missing.yml", []string{AppArmorAnnotationMissing}, true},
-		{"apparmor-disabled.yml", []string{AppArmorDisabled}, true},
-		{"apparmor-disabled-overriden.yml", []string{override.GetOverriddenResultName(AppArmorDisabled)}, true},
-		{"apparmor-disabled-overriden-old-label.yml", []string{override.GetOverriddenResultName(AppArmorDisabled)}, true},
-		{"apparmor-disabled-overriden-multiple.yml", []string{AppArmorAnnotationMissing, override.GetOverriddenResultName(AppArmorDisabled)}, true},
+		{"apparmor-annotation-init-container-enabled.yml", []string{AppArmorDeprecatedAnnotations}, false},
+		{"apparmor-annotation-init-container-missing.yml", []string{AppArmorAnnotationMissing, AppArmorDeprecatedAnnotations}, false},
+		{"apparmor-disabled.yml", []string{AppArmorDisabled, AppArmorDeprecatedAnnotations}, false},
+		{"apparmor-disabled-overriden.yml", []string{override.GetOverriddenResultName(AppArmorDisabled), AppArmorDeprecatedAnnotations}, false},
+		{"apparmor-disabled-overriden-old-label.yml", []string{override.GetOverriddenResultName(AppArmorDisabled), AppArmorDeprecatedAnnotations}, false},
+		{"apparmor-disabled-overriden-multiple.yml", []string{AppArmorAnnotationMissing, override.GetOverriddenResultName(AppArmorDisabled), AppArmorDeprecatedAnnotations}, false},
+		{"apparmor-profile-precedence.yml", []string{AppArmorDeprecatedAnnotations}, false},
+		{"apparmor-profile-enabled.yml", nil, true},
+		{"apparmor-profile-disabled.yml", []string{AppArmorDisabled}, true},
+		{"apparmor-profile-pod-disabled-overriden.yml", []string{AppArmorDisabled, override.GetOverriddenResultName(AppArmorDisabled)}, true},
 		// These are invalid manifests so we should only test it in manifest mode as kubernetes will fail to apply it
-		{"apparmor-bad-value.yml", []string{AppArmorBadValue}, false},
-		{"apparmor-bad-value-override.yml", []string{AppArmorBadValue}, false},
-		{"apparmor-invalid-annotation.yml", []string{AppArmorInvalidAnnotation}, false},
+		{"apparmor-bad-value.yml", []string{AppArmorBadValue, AppArmorDeprecatedAnnotations}, false},
+		{"apparmor-bad-value-override.yml", []string{AppArmorBadValue, AppArmorDeprecatedAnnotations}, false},
+		{"apparmor-invalid-annotation.yml", []string{AppArmorInvalidAnnotation, AppArmorDeprecatedAnnotations}, false},
+		{"apparmor-profile-bad-value.yml", []string{AppArmorBadValue}, false},
 	}
 
 	for _, tc := range cases {
@@ -37,36 +46,123 @@ func TestAuditAppArmor(t *testing.T) {
 		tc := tc
 		t.Run(tc.file, func(t *testing.T) {
 			t.Parallel()
-			test.AuditManifest(t, fixtureDir, tc.file, New(), tc.expectedErrors)
+			auditor := New()
+			test.AuditManifest(t, fixtureDir, tc.file, auditor, tc.expectedErrors)
 			if tc.testLocalMode {
-				test.AuditLocal(t, fixtureDir, tc.file, New(), strings.Split(tc.file, ".")[0], tc.expectedErrors)
+				test.AuditLocal(t, fixtureDir, tc.file, auditor, strings.Split(tc.file, ".")[0], tc.expectedErrors)
 			}
 		})
 	}
 }
 
+func TestAuditAppArmorLocalhostProfiles(t *testing.T) {
+	cases := []struct {
+		config             Config
+		expectedErrors     []string
+		expectedContainers []string
+	}{
+		{Config{}, []string{AppArmorDeprecatedAnnotations}, nil},
+		{Config{AllowedLocalhostProfiles: []string{"k8s-*"}}, []string{AppArmorLocalhostProfileNotAllowed, AppArmorDeprecatedAnnotations}, []string{"container2"}},
+		{Config{AllowedLocalhostProfiles: []string{"custom-profile"}}, []string{AppArmorLocalhostProfileNotAllowed, AppArmorDeprecatedAnnotations}, []string{"container"}},
+		{Config{AllowedLocalhostProfiles: []string{"k8s-*", "custom-*"}}, []string{AppArmorDeprecatedAnnotations}, nil},
+	}
+
+	for _, tc := range cases {
+		auditor, err := NewWithConfig(tc.config)
+		require.NoError(t, err)
+		report := test.AuditManifest(t, fixtureDir, "apparmor-localhost.yml", auditor, tc.expectedErrors)
+
+		var containers []string
+		for _, result := range report.Results() {
+			for _, auditResult := range result.GetAuditResults() {
+				if auditResult.Rule == AppArmorLocalhostProfileNotAllowed {
+					containers = append(containers, auditResult.Metadata["Container"])
+				}
+			}
+		}
+		assert.Equal(t, tc.expectedContainers, containers)
+	}
+
+	_, err := NewWithConfig(Config{AllowedLocalhostProfiles: []string{"[k8s"}})
+	assert.NotNil(t, err)
+}
+
 func TestFixAppArmor(t *testing.T) {
+	localhostProfile := "something"
 	cases := []struct {
-		file                    string
-		expectedAnnotationValue string
+		file            string
+		expectedProfile apiv1.AppArmorProfile
 	}{
-		{"apparmor-enabled.yml", "localhost/something"},
-		{"apparmor-annotation-missing.yml", ProfileRuntimeDefault},
-		{"apparmor-disabled.yml", ProfileRuntimeDefault},
-		{"apparmor-invalid-annotation.yml", ProfileRuntimeDefault},
+		{"apparmor-enabled.yml", apiv1.AppArmorProfile{Type: apiv1.AppArmorProfileTypeLocalhost, LocalhostProfile: &localhostProfile}},
+		{"apparmor-annotation-missing.yml", apiv1.AppArmorProfile{Type: apiv1.AppArmorProfileTypeRuntimeDefault}},
+		{"apparmor-disabled.yml", apiv1.AppArmorProfile{Type: apiv1.AppArmorProfileTypeRuntimeDefault}},
+		{"apparmor-invalid-annotation.yml", apiv1.AppArmorProfile{Type: apiv1.AppArmorProfileTypeRuntimeDefault}},
+		{"apparmor-bad-value.yml", apiv1.AppArmorProfile{Type: apiv1.AppArmorProfileTypeRuntimeDefault}},
+		{"apparmor-profile-disabled.yml", apiv1.AppArmorProfile{Type: apiv1.AppArmorProfileTypeRuntimeDefault}},
+		{"apparmor-profile-bad-value.yml", apiv1.AppArmorProfile{Type: apiv1.AppArmorProfileTypeRuntimeDefault}},
+		{"apparmor-profile-precedence.yml", apiv1.AppArmorProfile{Type: apiv1.AppArmorProfileTypeRuntimeDefault}},
 	}
 
 	for _, tc := range cases {
 		t.Run(tc.file, func(t *testing.T) {
-			resources, _ := test.FixSetup(t, fixtureDir, tc.file, New())
+			auditor, err := NewWithConfig(Config{UseSecurityContext: true})
+			require.NoError(t, err)
+			resources, _ := test.FixSetup(t, fixtureDir, tc.file, auditor)
 			for _, resource := range resources {
-				containers := k8s.GetContainers(resource)
-				annotations := k8s.GetAnnotations(resource)
+				// The annotations are migrated to the container SecurityContext
+				for annotation := range k8s.GetAnnotations(resource) {
+					assert.False(t, strings.HasPrefix(annotation, ContainerAnnotationKeyPrefix), annotation)
+				}
 
-				for _, container := range containers {
-					containerAnnotation := getContainerAnnotation(container)
-					assert.Equal(t, tc.expectedAnnotationValue, annotations[containerAnnotation])
+				podProfile := getPodProfile(resource)
+				for _, container := range k8s.GetContainers(resource) {
+					profile := getContainerProfile(container)
+					if profile == nil {
+						profile = podProfile
+					}
+					if assert.NotNil(t, profile, container.Name) {
+						assert.Equal(t, tc.expectedProfile, *profile, container.Name)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestFixAppArmorAnnotations(t *testing.T) {
+	cases := []struct {
+		file                string
+		expectedAnnotations map[string]string
+	}{
+		{"apparmor-annotation-missing.yml", map[string]string{
+			ContainerAnnotationKeyPrefix + "container": ProfileRuntimeDefault,
+		}},
+		{"apparmor-enabled.yml", map[string]string{
+			ContainerAnnotationKeyPrefix + "container": ProfileNamePrefix + "something",
+		}},
+		{"apparmor-disabled.yml", map[string]string{
+			ContainerAnnotationKeyPrefix + "container": ProfileRuntimeDefault,
+		}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.file, func(t *testing.T) {
+			resources, report := test.FixSetup(t, fixtureDir, tc.file, New())
+			for _, result := range report.Results() {
+				for _, auditResult := range result.GetAuditResults() {
+					if auditResult.Rule == AppArmorAnnotationMissing {
+						assert.Equal(t, ContainerAnnotationKeyPrefix+auditResult.Metadata["Container"], auditResult.Metadata["MissingAnnotation"])
+					}
+				}
+			}
+
+			for _, resource := range resources {
+				// Annotations aren't migrated to the SecurityContext unless the auditor is configured to
+				assert.Nil(t, getPodProfile(resource))
+				for _, container := range k8s.GetContainers(resource) {
+					assert.Nil(t, getContainerProfile(container), container.Name)
 				}
+				assert.Equal(t, tc.expectedAnnotations, k8s.GetAnnotations(resource))
 			}
 		})
 	}
@@ -0,0 +1,22 @@
+package apparmor
+
+type Config struct {
+	// AllowedLocalhostProfiles are the approved names of the AppArmor profiles loaded on the nodes. They can be glob
+	// patterns (eg. "k8s-*"). If it is empty, every localhost profile is allowed
+	AllowedLocalhostProfiles []string `yaml:"allowedLocalhostProfiles"`
+	// UseSecurityContext fixes missing AppArmor profiles and deprecated apparmor annotations by setting the AppArmor
+	// profile in the SecurityContext instead of adding annotations. API servers older than Kubernetes 1.30 drop the
+	// SecurityContext field, so it should only be set for newer clusters
+	UseSecurityContext bool `yaml:"useSecurityContext"`
+}
+
+func (config *Config) GetAllowedLocalhostProfiles() []string {
+	if config == nil {
+		return nil
+	}
+	return config.AllowedLocalhostProfiles
+}
+
+func (config *Config) GetUseSecurityContext() bool {
+	return config != nil && config.UseSecurityContext
+}
@@ -0,0 +1,83 @@
+package apparmor
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Shopify/kubeaudit/pkg/k8s"
+	apiv1 "k8s.io/api/core/v1"
+)
+
+type BySettingAppArmorProfile struct {
+	profileType apiv1.AppArmorProfileType
+}
+
+func (pending *BySettingAppArmorProfile) Plan() string {
+	return fmt.Sprintf("Set AppArmorProfile type to '%s' in pod SecurityContext", pending.profileType)
+}
+
+func (pending *BySettingAppArmorProfile) Apply(resource k8s.Resource) []k8s.Resource {
+	podSpec := k8s.GetPodSpec(resource)
+	if podSpec.SecurityContext == nil {
+		podSpec.SecurityContext = &apiv1.PodSecurityContext{}
+	}
+	podSpec.SecurityContext.AppArmorProfile = &apiv1.AppArmorProfile{Type: pending.profileType}
+	return nil
+}
+
+type BySettingAppArmorProfileInContainer struct {
+	container   *k8s.ContainerV1
+	profileType apiv1.AppArmorProfileType
+}
+
+func (pending *BySettingAppArmorProfileInContainer) Plan() string {
+	return fmt.Sprintf("Set AppArmorProfile type to '%s' in SecurityContext for container `%s`", pending.profileType, pending.container.Name)
+}
+
+func (pending *BySettingAppArmorProfileInContainer) Apply(resource k8s.Resource) []k8s.Resource {
+	if pending.container.SecurityContext == nil {
+		pending.container.SecurityContext = &apiv1.SecurityContext{}
+	}
+	pending.container.SecurityContext.AppArmorProfile = &apiv1.AppArmorProfile{Type: pending.profileType}
+	return nil
+}
+
+// ByMigratingAnnotations replaces apparmor annotations with the equivalent AppArmor profiles in the SecurityContext of
+// their containers. Annotations with a bad value are left as they are
+type ByMigratingAnnotations struct {
+	keys []string
+}
+
+func (pending *ByMigratingAnnotations) Plan() string {
+	return fmt.Sprintf("Replace pod-level annotations '%v' with the AppArmorProfile in the SecurityContext of their containers", pending.keys)
+}
+
+func (pending *ByMigratingAnnotations) Apply(resource k8s.Resource) []k8s.Resource {
+	annotations := k8s.GetPodObjectMeta(resource).GetAnnotations()
+	containers := k8s.GetContainers(resource)
+
+	for _, key := range pending.keys {
+		profileName, ok := annotations[key]
+		if !ok {
+			continue
+		}
+		profile, ok := annotationToProfile(profileName)
+		if !ok {
+			continue
+		}
+		for _, container := range containers {
+			if container.Name != strings.TrimPrefix(key, ContainerAnnotationKeyPrefix) {
+				continue
+			}
+			if container.SecurityContext == nil {
+				container.SecurityContext = &apiv1.SecurityContext{}
+			}
+			if container.SecurityContext.AppArmorProfile == nil {
+				container.SecurityContext.AppArmorProfile = profile
+			}
+			delete(annotations, key)
+		}
+	}
+
+	return nil
+}
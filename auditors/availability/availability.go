@@ -0,0 +1,184 @@
+package availability
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+
+	"github.com/Shopify/kubeaudit"
+	"github.com/Shopify/kubeaudit/pkg/k8s"
+	"github.com/Shopify/kubeaudit/pkg/override"
+)
+
+const Name = "availability"
+
+const (
+	// SingleReplica occurs when a Deployment or StatefulSet in a production namespace has a single replica and isn't
+	// scaled by a HorizontalPodAutoscaler
+	SingleReplica = "SingleReplica"
+	// PodDisruptionBudgetMissing occurs when a Deployment or StatefulSet with multiple replicas isn't selected by any
+	// PodDisruptionBudget
+	PodDisruptionBudgetMissing = "PodDisruptionBudgetMissing"
+	// TopologySpreadMissing occurs when a Deployment or StatefulSet with multiple replicas has neither topology spread
+	// constraints nor pod anti-affinity, so all its replicas can be scheduled on the same node
+	TopologySpreadMissing = "TopologySpreadMissing"
+	// PodDisruptionBudgetZeroDisruptions occurs when a PodDisruptionBudget doesn't allow any of the pods it selects to
+	// be disrupted, which blocks node drains
+	PodDisruptionBudgetZeroDisruptions = "PodDisruptionBudgetZeroDisruptions"
+	// PodDisruptionBudgetSelectsNoPods occurs when a PodDisruptionBudget doesn't select the pods of any workload
+	PodDisruptionBudgetSelectsNoPods = "PodDisruptionBudgetSelectsNoPods"
+)
+
+// Override labels of the rules
+const (
+	SingleReplicaOverrideLabel              = "allow-single-replica"
+	PodDisruptionBudgetMissingOverrideLabel = "allow-missing-pod-disruption-budget"
+	TopologySpreadMissingOverrideLabel      = "allow-missing-topology-spread"
+	ZeroDisruptionsOverrideLabel            = "allow-zero-disruptions"
+	EmptyPodDisruptionBudgetOverrideLabel   = "allow-empty-pod-disruption-budget"
+)
+
+const (
+	ReplicasMetadataKey            = "Replicas"
+	PodDisruptionBudgetMetadataKey = "PodDisruptionBudget"
+)
+
+// Availability implements Auditable
+type Availability struct {
+	productionNamespaces []string
+}
+
+func New(config Config) (*Availability, error) {
+	for _, pattern := range config.GetProductionNamespaces() {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid production namespace pattern %q: %w", pattern, err)
+		}
+	}
+
+	return &Availability{
+		productionNamespaces: config.GetProductionNamespaces(),
+	}, nil
+}
+
+// Audit checks that Deployments and StatefulSets can survive the loss of a pod or node, and that PodDisruptionBudgets
+// select pods and allow them to be disrupted
+func (a *Availability) Audit(resource k8s.Resource, resources []k8s.Resource) ([]*kubeaudit.AuditResult, error) {
+	var auditResults []*kubeaudit.AuditResult
+
+	switch kubeType := resource.(type) {
+	case *k8s.DeploymentV1:
+		auditResults = a.auditWorkload(resource, kubeType.Spec.Replicas, resources)
+	case *k8s.StatefulSetV1:
+		auditResults = a.auditWorkload(resource, kubeType.Spec.Replicas, resources)
+	case *k8s.PodDisruptionBudgetV1:
+		auditResults = auditPodDisruptionBudget(kubeType, resources)
+	}
+
+	return auditResults, nil
+}
+
+func (a *Availability) auditWorkload(resource k8s.Resource, replicasPtr *int32, resources []k8s.Resource) []*kubeaudit.AuditResult {
+	// Workloads which are scaled down on purpose are not running, so they can't be unavailable
+	replicas := getReplicas(replicasPtr)
+	if replicas == 0 {
+		return nil
+	}
+
+	// The replicas of autoscaled workloads are managed by their HorizontalPodAutoscaler, so the replicas of the
+	// workload spec don't reflect how many pods are running
+	if isAutoscaled(resource, resources) {
+		return nil
+	}
+
+	replicasResults := []*kubeaudit.AuditResult{a.auditReplicas(resource, replicas)}
+	auditResults := override.ApplyOverrides(replicasResults, Name, "", resource, SingleReplicaOverrideLabel)
+	if replicas == 1 {
+		return auditResults
+	}
+
+	pdbResults := []*kubeaudit.AuditResult{auditPodDisruptionBudgetMissing(resource, replicas, resources)}
+	auditResults = append(auditResults, override.ApplyOverrides(pdbResults, Name, "", resource, PodDisruptionBudgetMissingOverrideLabel)...)
+	topologySpreadResults := []*kubeaudit.AuditResult{auditTopologySpread(resource, replicas)}
+	auditResults = append(auditResults, override.ApplyOverrides(topologySpreadResults, Name, "", resource, TopologySpreadMissingOverrideLabel)...)
+
+	return auditResults
+}
+
+func (a *Availability) auditReplicas(resource k8s.Resource, replicas int32) *kubeaudit.AuditResult {
+	namespace := k8s.GetObjectMeta(resource).GetNamespace()
+	if replicas != 1 || !a.isProductionNamespace(namespace) {
+		return nil
+	}
+
+	return &kubeaudit.AuditResult{
+		Auditor:  Name,
+		Rule:     SingleReplica,
+		Severity: kubeaudit.Warn,
+		Message:  fmt.Sprintf("%s has a single replica, so it is unavailable whenever its pod is restarted or evicted. It should have at least 2 replicas.", getKind(resource)),
+		Metadata: kubeaudit.Metadata{
+			ReplicasMetadataKey: "1",
+		},
+	}
+}
+
+func auditTopologySpread(resource k8s.Resource, replicas int32) *kubeaudit.AuditResult {
+	podSpec := k8s.GetPodSpec(resource)
+	if len(podSpec.TopologySpreadConstraints) > 0 ||
+		(podSpec.Affinity != nil && podSpec.Affinity.PodAntiAffinity != nil) {
+		return nil
+	}
+
+	return &kubeaudit.AuditResult{
+		Auditor:  Name,
+		Rule:     TopologySpreadMissing,
+		Severity: kubeaudit.Warn,
+		Message:  fmt.Sprintf("%s has %d replicas but neither topology spread constraints nor pod anti-affinity, so all its replicas can be scheduled on the same node. topologySpreadConstraints or podAntiAffinity should be added.", getKind(resource), replicas),
+		Metadata: kubeaudit.Metadata{
+			ReplicasMetadataKey: strconv.Itoa(int(replicas)),
+		},
+	}
+}
+
+func (a *Availability) isProductionNamespace(namespace string) bool {
+	for _, pattern := range a.productionNamespaces {
+		if matched, _ := path.Match(pattern, namespace); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// isAutoscaled returns true if a HorizontalPodAutoscaler in the namespace of the workload scales it
+func isAutoscaled(resource k8s.Resource, resources []k8s.Resource) bool {
+	objectMeta := k8s.GetObjectMeta(resource)
+	kind := getKind(resource)
+
+	for _, r := range resources {
+		var namespace, targetKind, targetName string
+		switch hpa := r.(type) {
+		case *k8s.HorizontalPodAutoscalerV1:
+			namespace, targetKind, targetName = hpa.Namespace, hpa.Spec.ScaleTargetRef.Kind, hpa.Spec.ScaleTargetRef.Name
+		case *k8s.HorizontalPodAutoscalerV2:
+			namespace, targetKind, targetName = hpa.Namespace, hpa.Spec.ScaleTargetRef.Kind, hpa.Spec.ScaleTargetRef.Name
+		default:
+			continue
+		}
+
+		if namespace == objectMeta.GetNamespace() && targetKind == kind && targetName == objectMeta.GetName() {
+			return true
+		}
+	}
+	return false
+}
+
+// getReplicas returns the number of replicas of a workload, which defaults to 1
+func getReplicas(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
+	}
+	return *replicas
+}
+
+func getKind(resource k8s.Resource) string {
+	return resource.GetObjectKind().GroupVersionKind().Kind
+}
@@ -0,0 +1,62 @@
+package availability
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Shopify/kubeaudit"
+	"github.com/Shopify/kubeaudit/internal/test"
+	"github.com/Shopify/kubeaudit/pkg/override"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const fixtureDir = "fixtures"
+
+func TestAuditAvailability(t *testing.T) {
+	cases := []struct {
+		file           string
+		config         Config
+		expectedErrors []string
+	}{
+		{"availability-highly-available.yml", Config{}, []string{}},
+		{"availability-single-replica.yml", Config{}, []string{}},
+		{"availability-single-replica.yml", Config{ProductionNamespaces: []string{"availability-single-*"}}, []string{SingleReplica}},
+		{"availability-single-replica.yml", Config{ProductionNamespaces: []string{"prod-*"}}, []string{}},
+		{"availability-single-replica-allowed.yml", Config{ProductionNamespaces: []string{"availability-*"}}, []string{override.GetOverriddenResultName(SingleReplica)}},
+		{"availability-scaled-down.yml", Config{ProductionNamespaces: []string{"availability-*"}}, []string{}},
+		{"availability-autoscaled.yml", Config{ProductionNamespaces: []string{"availability-*"}}, []string{}},
+		{"availability-pdb-missing.yml", Config{}, []string{PodDisruptionBudgetMissing}},
+		{"availability-topology-spread-missing.yml", Config{}, []string{TopologySpreadMissing}},
+		{"availability-zero-disruptions.yml", Config{}, []string{
+			PodDisruptionBudgetZeroDisruptions,
+			override.GetOverriddenResultName(PodDisruptionBudgetZeroDisruptions),
+		}},
+		{"availability-pdb-selects-no-pods.yml", Config{}, []string{PodDisruptionBudgetSelectsNoPods}},
+		{"availability-redundant-override.yml", Config{}, []string{kubeaudit.RedundantAuditorOverride}},
+	}
+
+	for _, tc := range cases {
+		// This line is needed because of how scopes work with parallel tests (see https://gist.github.com/posener/92a55c4cd441fc5e5e85f27bca008721)
+		tc := tc
+		t.Run(tc.file, func(t *testing.T) {
+			t.Parallel()
+			auditor, err := New(tc.config)
+			require.NoError(t, err)
+			test.AuditManifest(t, fixtureDir, tc.file, auditor, tc.expectedErrors)
+			test.AuditLocal(t, fixtureDir, tc.file, auditor, strings.Split(tc.file, ".")[0], tc.expectedErrors)
+		})
+	}
+}
+
+// In cluster mode, the ReplicaSets of a Deployment are audited along with it and must not be counted as its pods
+func TestAuditZeroDisruptionsOwnedResources(t *testing.T) {
+	auditor, err := New(Config{})
+	require.NoError(t, err)
+	test.AuditManifest(t, fixtureDir, "availability-zero-disruptions-replicaset.yml", auditor, []string{PodDisruptionBudgetZeroDisruptions})
+}
+
+func TestNewAvailability(t *testing.T) {
+	_, err := New(Config{ProductionNamespaces: []string{"prod-[a-"}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,14 @@
+package availability
+
+type Config struct {
+	// ProductionNamespaces are the namespaces in which workloads must have more than one replica. Namespaces can be
+	// glob patterns (eg. "prod-*"). If it is empty, no namespace is a production namespace
+	ProductionNamespaces []string `yaml:"productionNamespaces"`
+}
+
+func (config *Config) GetProductionNamespaces() []string {
+	if config == nil {
+		return nil
+	}
+	return config.ProductionNamespaces
+}
@@ -0,0 +1,33 @@
+package availability
+
+import (
+	"fmt"
+
+	"github.com/Shopify/kubeaudit/pkg/k8s"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// fixByAddingPodDisruptionBudget creates a PodDisruptionBudget which lets one pod of the workload be disrupted at a
+// time
+type fixByAddingPodDisruptionBudget struct {
+	name      string
+	namespace string
+	selector  *metav1.LabelSelector
+}
+
+func (f *fixByAddingPodDisruptionBudget) Plan() string {
+	return fmt.Sprintf("Create a new PodDisruptionBudget resource with maxUnavailable set to 1 for %s", f.name)
+}
+
+func (f *fixByAddingPodDisruptionBudget) Apply(resource k8s.Resource) []k8s.Resource {
+	maxUnavailable := intstr.FromInt32(1)
+	pdb := k8s.NewPodDisruptionBudget()
+	pdb.ObjectMeta = k8s.ObjectMetaV1{
+		Name:      f.name,
+		Namespace: f.namespace,
+	}
+	pdb.Spec.MaxUnavailable = &maxUnavailable
+	pdb.Spec.Selector = f.selector
+	return []k8s.Resource{pdb}
+}
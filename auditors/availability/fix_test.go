@@ -0,0 +1,26 @@
+package availability
+
+import (
+	"testing"
+
+	"github.com/Shopify/kubeaudit/internal/test"
+	"github.com/Shopify/kubeaudit/pkg/k8s"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFixPodDisruptionBudgetMissing(t *testing.T) {
+	auditor, err := New(Config{})
+	require.NoError(t, err)
+
+	resources, report := test.FixSetup(t, fixtureDir, "availability-pdb-missing.yml", auditor)
+	assert.Empty(t, report.Results())
+	require.Len(t, resources, 2)
+
+	pdb, ok := resources[1].(*k8s.PodDisruptionBudgetV1)
+	require.True(t, ok)
+	assert.Equal(t, "statefulset", pdb.Name)
+	assert.Equal(t, "availability-pdb-missing", pdb.Namespace)
+	assert.Equal(t, 1, pdb.Spec.MaxUnavailable.IntValue())
+	assert.Equal(t, map[string]string{"app": "statefulset"}, pdb.Spec.Selector.MatchLabels)
+}
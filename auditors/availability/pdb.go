@@ -0,0 +1,169 @@
+package availability
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/Shopify/kubeaudit"
+	"github.com/Shopify/kubeaudit/pkg/k8s"
+	"github.com/Shopify/kubeaudit/pkg/override"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func auditPodDisruptionBudgetMissing(resource k8s.Resource, replicas int32, resources []k8s.Resource) *kubeaudit.AuditResult {
+	namespace := k8s.GetObjectMeta(resource).GetNamespace()
+	podLabels := labels.Set(k8s.GetPodObjectMeta(resource).GetLabels())
+	for _, pdb := range getPodDisruptionBudgets(resources, namespace) {
+		if getSelector(pdb).Matches(podLabels) {
+			return nil
+		}
+	}
+
+	return &kubeaudit.AuditResult{
+		Auditor:  Name,
+		Rule:     PodDisruptionBudgetMissing,
+		Severity: kubeaudit.Warn,
+		Message:  fmt.Sprintf("%s has %d replicas but no PodDisruptionBudget, so voluntary disruptions such as node drains can evict all its pods at once. A PodDisruptionBudget should be added.", getKind(resource), replicas),
+		PendingFix: &fixByAddingPodDisruptionBudget{
+			name:      k8s.GetObjectMeta(resource).GetName(),
+			namespace: namespace,
+			selector:  getWorkloadSelector(resource),
+		},
+		Metadata: kubeaudit.Metadata{
+			ReplicasMetadataKey: strconv.Itoa(int(replicas)),
+		},
+	}
+}
+
+func auditPodDisruptionBudget(pdb *k8s.PodDisruptionBudgetV1, resources []k8s.Resource) []*kubeaudit.AuditResult {
+	pods, podsKnown, selectsPods := countSelectedPods(pdb, resources)
+
+	var selectsNoPodsResult, zeroDisruptionsResult *kubeaudit.AuditResult
+	if !selectsPods {
+		selectsNoPodsResult = &kubeaudit.AuditResult{
+			Auditor:  Name,
+			Rule:     PodDisruptionBudgetSelectsNoPods,
+			Severity: kubeaudit.Warn,
+			Message:  fmt.Sprintf("PodDisruptionBudget %s doesn't select the pods of any workload. Its selector should be fixed or it should be removed.", pdb.Name),
+			Metadata: kubeaudit.Metadata{
+				PodDisruptionBudgetMetadataKey: pdb.Name,
+			},
+		}
+	} else if podsKnown && getAllowedDisruptions(pdb, pods) <= 0 {
+		zeroDisruptionsResult = &kubeaudit.AuditResult{
+			Auditor:  Name,
+			Rule:     PodDisruptionBudgetZeroDisruptions,
+			Severity: kubeaudit.Warn,
+			Message:  fmt.Sprintf("PodDisruptionBudget %s doesn't allow any of the %d pods it selects to be disrupted, which blocks node drains. It should allow at least one disruption.", pdb.Name, pods),
+			Metadata: kubeaudit.Metadata{
+				PodDisruptionBudgetMetadataKey: pdb.Name,
+				ReplicasMetadataKey:            strconv.Itoa(int(pods)),
+			},
+		}
+	}
+
+	auditResults := override.ApplyOverrides([]*kubeaudit.AuditResult{selectsNoPodsResult}, Name, "", pdb, EmptyPodDisruptionBudgetOverrideLabel)
+	return append(auditResults, override.ApplyOverrides([]*kubeaudit.AuditResult{zeroDisruptionsResult}, Name, "", pdb, ZeroDisruptionsOverrideLabel)...)
+}
+
+// countSelectedPods returns the number of pods the PodDisruptionBudget selects. The number is only known if every
+// selected workload has a fixed number of replicas, unlike DaemonSets, Jobs or autoscaled workloads
+func countSelectedPods(pdb *k8s.PodDisruptionBudgetV1, resources []k8s.Resource) (pods int32, known bool, selectsPods bool) {
+	selector := getSelector(pdb)
+	known = true
+
+	for _, resource := range resources {
+		objectMeta := k8s.GetObjectMeta(resource)
+		if objectMeta == nil || objectMeta.GetNamespace() != pdb.Namespace || k8s.GetPodSpec(resource) == nil {
+			continue
+		}
+		// Pods and ReplicaSets created by workloads are counted as part of their workload's replicas
+		if len(objectMeta.GetOwnerReferences()) > 0 {
+			continue
+		}
+		if !selector.Matches(labels.Set(k8s.GetPodObjectMeta(resource).GetLabels())) {
+			continue
+		}
+
+		selectsPods = true
+		// The replicas of autoscaled workloads change, so the number of pods isn't fixed
+		if isAutoscaled(resource, resources) {
+			known = false
+			continue
+		}
+		switch kubeType := resource.(type) {
+		case *k8s.DeploymentV1:
+			pods += getReplicas(kubeType.Spec.Replicas)
+		case *k8s.StatefulSetV1:
+			pods += getReplicas(kubeType.Spec.Replicas)
+		case *k8s.ReplicationControllerV1:
+			pods += getReplicas(kubeType.Spec.Replicas)
+		case *k8s.PodV1:
+			pods++
+		default:
+			known = false
+		}
+	}
+
+	return pods, known, selectsPods
+}
+
+// getAllowedDisruptions returns how many of the pods can be disrupted at once, rounding up like the disruption
+// controller
+func getAllowedDisruptions(pdb *k8s.PodDisruptionBudgetV1, pods int32) int32 {
+	if pdb.Spec.MaxUnavailable != nil {
+		maxUnavailable, err := intstr.GetScaledValueFromIntOrPercent(pdb.Spec.MaxUnavailable, int(pods), true)
+		if err != nil {
+			return 1
+		}
+		return int32(maxUnavailable)
+	}
+
+	if pdb.Spec.MinAvailable != nil {
+		minAvailable, err := intstr.GetScaledValueFromIntOrPercent(pdb.Spec.MinAvailable, int(pods), true)
+		if err != nil {
+			return 1
+		}
+		return pods - int32(minAvailable)
+	}
+
+	// A PodDisruptionBudget without minAvailable or maxUnavailable defaults to a minAvailable of 1
+	return pods - 1
+}
+
+func getPodDisruptionBudgets(resources []k8s.Resource, namespace string) []*k8s.PodDisruptionBudgetV1 {
+	var pdbs []*k8s.PodDisruptionBudgetV1
+	for _, resource := range resources {
+		if pdb, ok := resource.(*k8s.PodDisruptionBudgetV1); ok && pdb.Namespace == namespace {
+			pdbs = append(pdbs, pdb)
+		}
+	}
+	return pdbs
+}
+
+// getSelector returns the selector of the PodDisruptionBudget. A missing or invalid selector selects no pods
+func getSelector(pdb *k8s.PodDisruptionBudgetV1) labels.Selector {
+	selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+	if err != nil {
+		return labels.Nothing()
+	}
+	return selector
+}
+
+// getWorkloadSelector returns a copy of the selector of the workload, falling back to its pod template labels
+func getWorkloadSelector(resource k8s.Resource) *metav1.LabelSelector {
+	var selector *metav1.LabelSelector
+	switch kubeType := resource.(type) {
+	case *k8s.DeploymentV1:
+		selector = kubeType.Spec.Selector
+	case *k8s.StatefulSetV1:
+		selector = kubeType.Spec.Selector
+	}
+
+	if selector != nil {
+		return selector.DeepCopy()
+	}
+	return &metav1.LabelSelector{MatchLabels: k8s.GetPodObjectMeta(resource).GetLabels()}
+}
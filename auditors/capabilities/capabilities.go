@@ -48,12 +48,8 @@ func (a *Capabilities) Audit(resource k8s.Resource, _ []k8s.Resource) ([]*kubeau
 		}
 
 		for _, capability := range uniqueCapabilities(container) {
-			for _, auditResult := range auditContainer(container, capability, a.allowAddList) {
-				auditResult = override.ApplyOverride(auditResult, Name, container.Name, resource, getOverrideLabel(capability))
-				if auditResult != nil {
-					auditResults = append(auditResults, auditResult)
-				}
-			}
+			capabilityResults := auditContainer(container, capability, a.allowAddList)
+			auditResults = append(auditResults, override.ApplyOverrides(capabilityResults, Name, container.Name, resource, getOverrideLabel(capability))...)
 		}
 	}
 
@@ -91,12 +87,6 @@ func auditContainer(container *k8s.ContainerV1, capability string, allowAddList
 			auditResults = append(auditResults, auditResult)
 		}
 	}
-	// We need the audit result to be nil for ApplyOverride to check for RedundantAuditorOverride errors
-
-	if len(auditResults) == 0 {
-		return []*kubeaudit.AuditResult{nil}
-	}
-
 	return auditResults
 }
 
@@ -70,7 +70,7 @@ func (deprecatedAPIs *DeprecatedAPIs) Audit(resource k8s.Resource, _ []k8s.Resou
 	var auditResults []*kubeaudit.AuditResult
 	lastApplied, ok := k8s.GetAnnotations(resource)[v1.LastAppliedConfigAnnotation]
 	if ok && len(lastApplied) > 0 {
-		resource, _ = k8sinternal.DecodeResource([]byte(lastApplied))
+		resource, _ = k8sinternal.DecodeResource([]byte(lastApplied), nil)
 	}
 	deprecated, isDeprecated := resource.(apiLifecycleDeprecated)
 	if isDeprecated {
@@ -0,0 +1,392 @@
+package exposure
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/Shopify/kubeaudit"
+	"github.com/Shopify/kubeaudit/auditors/hostports"
+	"github.com/Shopify/kubeaudit/pkg/k8s"
+	"github.com/Shopify/kubeaudit/pkg/override"
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const Name = "exposure"
+
+const (
+	// ExposedWithoutNetworkPolicy occurs when a workload is exposed outside of the cluster and no NetworkPolicy
+	// restricts the ingress traffic of its pods
+	ExposedWithoutNetworkPolicy = "ExposedWithoutNetworkPolicy"
+	// PubliclyExposed occurs when a workload is exposed outside of the cluster and a NetworkPolicy restricts the ingress traffic
+	// of its pods
+	PubliclyExposed = "PubliclyExposed"
+	// LoadBalancerSourceRangesMissing occurs when a LoadBalancer Service doesn't restrict its client IP ranges
+	LoadBalancerSourceRangesMissing = "LoadBalancerSourceRangesMissing"
+	// IngressTLSMissing occurs when an Ingress doesn't configure TLS
+	IngressTLSMissing = "IngressTLSMissing"
+)
+
+const (
+	PublicExposureOverrideLabel           = "allow-public-exposure"
+	LoadBalancerSourceRangesOverrideLabel = "allow-load-balancer-without-source-ranges"
+	IngressTLSOverrideLabel               = "allow-ingress-without-tls"
+)
+
+// ExposurePathsMetadataKey is the audit result metadata key listing how a workload is exposed (eg.
+// "Ingress/web -> Service/web (ClusterIP)")
+const ExposurePathsMetadataKey = "ExposurePaths"
+
+// Exposure implements Auditable
+type Exposure struct{}
+
+func New() *Exposure {
+	return &Exposure{}
+}
+
+// Audit checks how workloads are exposed outside of the cluster through Services, Ingresses, Gateway API routes,
+// host ports and the host network, and that exposed workloads are protected by a NetworkPolicy. It also checks that
+// LoadBalancer Services restrict their source ranges and that Ingresses configure TLS
+func (a *Exposure) Audit(resource k8s.Resource, resources []k8s.Resource) ([]*kubeaudit.AuditResult, error) {
+	switch kubeType := resource.(type) {
+	case *k8s.ServiceV1:
+		return auditService(kubeType), nil
+	case *k8s.IngressV1:
+		return auditIngress(kubeType), nil
+	}
+
+	podSpec := k8s.GetPodSpec(resource)
+	objectMeta := k8s.GetObjectMeta(resource)
+	if podSpec == nil || objectMeta == nil {
+		return nil, nil
+	}
+
+	namespace := objectMeta.GetNamespace()
+	podLabels := k8s.GetLabels(resource)
+
+	paths := getExposurePaths(resource, podSpec, podLabels, namespace, resources)
+	if len(paths) == 0 {
+		return nil, nil
+	}
+
+	metadata := kubeaudit.Metadata{
+		ExposurePathsMetadataKey: strings.Join(paths, ", "),
+	}
+
+	if policy := getIngressNetworkPolicy(podLabels, namespace, resources); policy != nil {
+		metadata["PolicyName"] = policy.ObjectMeta.Name
+		return []*kubeaudit.AuditResult{{
+			Auditor:  Name,
+			Rule:     PubliclyExposed,
+			Severity: kubeaudit.Info,
+			Message:  fmt.Sprintf("Workload is exposed outside of the cluster and its ingress traffic is restricted by NetworkPolicy %s.", policy.ObjectMeta.Name),
+			Metadata: metadata,
+		}}, nil
+	}
+
+	auditResult := &kubeaudit.AuditResult{
+		Auditor:  Name,
+		Rule:     ExposedWithoutNetworkPolicy,
+		Severity: kubeaudit.Warn,
+		Message:  "Workload is exposed outside of the cluster but no NetworkPolicy restricts its ingress traffic.",
+		Metadata: metadata,
+	}
+	auditResult = override.ApplyOverride(auditResult, Name, "", resource, PublicExposureOverrideLabel)
+
+	return []*kubeaudit.AuditResult{auditResult}, nil
+}
+
+func auditService(service *k8s.ServiceV1) []*kubeaudit.AuditResult {
+	if service.Spec.Type != v1.ServiceTypeLoadBalancer || len(service.Spec.LoadBalancerSourceRanges) > 0 {
+		return nil
+	}
+
+	auditResult := &kubeaudit.AuditResult{
+		Auditor:  Name,
+		Rule:     LoadBalancerSourceRangesMissing,
+		Severity: kubeaudit.Warn,
+		Message:  "LoadBalancer Service accepts traffic from any IP address. loadBalancerSourceRanges should be set to the expected client IP ranges.",
+		Metadata: kubeaudit.Metadata{
+			"Service": service.ObjectMeta.Name,
+		},
+	}
+	auditResult = override.ApplyOverride(auditResult, Name, "", service, LoadBalancerSourceRangesOverrideLabel)
+
+	return []*kubeaudit.AuditResult{auditResult}
+}
+
+func auditIngress(ingress *k8s.IngressV1) []*kubeaudit.AuditResult {
+	if len(ingress.Spec.TLS) > 0 {
+		return nil
+	}
+
+	auditResult := &kubeaudit.AuditResult{
+		Auditor:  Name,
+		Rule:     IngressTLSMissing,
+		Severity: kubeaudit.Warn,
+		Message:  "Ingress serves traffic without TLS. A tls section should be added.",
+		Metadata: kubeaudit.Metadata{
+			"Ingress": ingress.ObjectMeta.Name,
+		},
+	}
+	auditResult = override.ApplyOverride(auditResult, Name, "", ingress, IngressTLSOverrideLabel)
+
+	return []*kubeaudit.AuditResult{auditResult}
+}
+
+// getExposurePaths returns the sorted list of ways pods with the given spec and labels are exposed outside of the
+// cluster
+func getExposurePaths(resource k8s.Resource, podSpec *k8s.PodSpecV1, podLabels map[string]string, namespace string, resources []k8s.Resource) []string {
+	var paths []string
+
+	if podSpec.HostNetwork {
+		paths = append(paths, "hostNetwork")
+	}
+
+	for _, container := range k8s.GetContainers(resource) {
+		for _, port := range container.Ports {
+			if port.HostPort == 0 {
+				continue
+			}
+			// Host ports allowed by an override of the hostports auditor are accepted ways of exposing the pods
+			if hasOverride, _ := override.GetContainerOverrideReason(container.Name, resource, hostports.GetOverrideLabel(port.HostPort)); hasOverride {
+				continue
+			}
+			paths = append(paths, fmt.Sprintf("hostPort %d (container %s)", port.HostPort, container.Name))
+		}
+	}
+
+	// Services selecting the pods, and whether they are exposed on their own
+	services := map[string]*k8s.ServiceV1{}
+	for _, resource := range resources {
+		service, ok := resource.(*k8s.ServiceV1)
+		if !ok || service.ObjectMeta.Namespace != namespace || len(service.Spec.Selector) == 0 {
+			continue
+		}
+		if !labels.SelectorFromSet(service.Spec.Selector).Matches(labels.Set(podLabels)) {
+			continue
+		}
+		services[service.ObjectMeta.Name] = service
+
+		if path, ok := getServicePath(service); ok {
+			paths = append(paths, path)
+		}
+	}
+
+	if len(services) > 0 {
+		for _, resource := range resources {
+			for _, route := range getRoutes(resource) {
+				for _, backend := range route.backends {
+					if backend.namespace != namespace {
+						continue
+					}
+					if service, ok := services[backend.name]; ok {
+						paths = append(paths, fmt.Sprintf("%s/%s -> Service/%s (%s)", route.kind, route.name, service.ObjectMeta.Name, getServiceType(service)))
+					}
+				}
+			}
+		}
+	}
+
+	return dedupe(paths)
+}
+
+func getServiceType(service *k8s.ServiceV1) v1.ServiceType {
+	if service.Spec.Type == "" {
+		return v1.ServiceTypeClusterIP
+	}
+	return service.Spec.Type
+}
+
+// getServicePath returns the exposure path of a Service which is reachable from outside the cluster on its own
+func getServicePath(service *k8s.ServiceV1) (string, bool) {
+	serviceType := getServiceType(service)
+	switch {
+	case serviceType == v1.ServiceTypeLoadBalancer || serviceType == v1.ServiceTypeNodePort:
+		return fmt.Sprintf("Service/%s (%s)", service.ObjectMeta.Name, serviceType), true
+	case len(service.Spec.ExternalIPs) > 0:
+		return fmt.Sprintf("Service/%s (externalIPs %s)", service.ObjectMeta.Name, strings.Join(service.Spec.ExternalIPs, ", ")), true
+	}
+	return "", false
+}
+
+// route is an Ingress or a Gateway API route, along with the Services it sends traffic to
+type route struct {
+	kind     string
+	name     string
+	backends []backend
+}
+
+type backend struct {
+	name      string
+	namespace string
+}
+
+// getRoutes returns the Ingress or Gateway API route for the resource, if it is one
+func getRoutes(resource k8s.Resource) []route {
+	switch kubeType := resource.(type) {
+	case *k8s.IngressV1:
+		return []route{{kind: "Ingress", name: kubeType.ObjectMeta.Name, backends: getIngressBackends(kubeType)}}
+	case *k8s.HTTPRouteV1Beta1:
+		r := route{kind: "HTTPRoute", name: kubeType.ObjectMeta.Name}
+		for _, rule := range kubeType.Spec.Rules {
+			for _, ref := range rule.BackendRefs {
+				r.addBackend(kubeType.ObjectMeta.Namespace, (*string)(ref.Group), (*string)(ref.Kind), string(ref.Name), (*string)(ref.Namespace))
+			}
+		}
+		return []route{r}
+	case *k8s.HTTPRouteV1Alpha2:
+		r := route{kind: "HTTPRoute", name: kubeType.ObjectMeta.Name}
+		for _, rule := range kubeType.Spec.Rules {
+			for _, ref := range rule.BackendRefs {
+				r.addBackend(kubeType.ObjectMeta.Namespace, (*string)(ref.Group), (*string)(ref.Kind), string(ref.Name), (*string)(ref.Namespace))
+			}
+		}
+		return []route{r}
+	case *k8s.TLSRouteV1Alpha2:
+		r := route{kind: "TLSRoute", name: kubeType.ObjectMeta.Name}
+		for _, rule := range kubeType.Spec.Rules {
+			for _, ref := range rule.BackendRefs {
+				r.addBackend(kubeType.ObjectMeta.Namespace, (*string)(ref.Group), (*string)(ref.Kind), string(ref.Name), (*string)(ref.Namespace))
+			}
+		}
+		return []route{r}
+	case *k8s.TCPRouteV1Alpha2:
+		r := route{kind: "TCPRoute", name: kubeType.ObjectMeta.Name}
+		for _, rule := range kubeType.Spec.Rules {
+			for _, ref := range rule.BackendRefs {
+				r.addBackend(kubeType.ObjectMeta.Namespace, (*string)(ref.Group), (*string)(ref.Kind), string(ref.Name), (*string)(ref.Namespace))
+			}
+		}
+		return []route{r}
+	case *k8s.UDPRouteV1Alpha2:
+		r := route{kind: "UDPRoute", name: kubeType.ObjectMeta.Name}
+		for _, rule := range kubeType.Spec.Rules {
+			for _, ref := range rule.BackendRefs {
+				r.addBackend(kubeType.ObjectMeta.Namespace, (*string)(ref.Group), (*string)(ref.Kind), string(ref.Name), (*string)(ref.Namespace))
+			}
+		}
+		return []route{r}
+	}
+	return nil
+}
+
+// addBackend adds a Gateway API backend reference to the route if it references a Service. Backend references
+// default to Services in the namespace of the route
+func (r *route) addBackend(routeNamespace string, group, kind *string, name string, namespace *string) {
+	if (group != nil && *group != "") || (kind != nil && *kind != "Service") {
+		return
+	}
+	b := backend{name: name, namespace: routeNamespace}
+	if namespace != nil {
+		b.namespace = *namespace
+	}
+	r.backends = append(r.backends, b)
+}
+
+func getIngressBackends(ingress *k8s.IngressV1) []backend {
+	var backends []backend
+	addBackend := func(ingressBackend *networkingv1.IngressBackend) {
+		if ingressBackend != nil && ingressBackend.Service != nil {
+			backends = append(backends, backend{name: ingressBackend.Service.Name, namespace: ingress.ObjectMeta.Namespace})
+		}
+	}
+
+	addBackend(ingress.Spec.DefaultBackend)
+	for _, rule := range ingress.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			addBackend(&path.Backend)
+		}
+	}
+	return backends
+}
+
+// getIngressNetworkPolicy returns a NetworkPolicy which restricts the ingress traffic of the pods, or nil if there
+// isn't one. The ingress traffic isn't restricted if any of the policies selecting the pods allows traffic from every
+// peer, since the allowed traffic is the union of the policies
+func getIngressNetworkPolicy(podLabels map[string]string, namespace string, resources []k8s.Resource) *k8s.NetworkPolicyV1 {
+	var restrictingPolicy *k8s.NetworkPolicyV1
+	for _, resource := range resources {
+		policy, ok := resource.(*k8s.NetworkPolicyV1)
+		if !ok || policy.ObjectMeta.Namespace != namespace || !isIngressPolicy(policy) {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(&policy.Spec.PodSelector)
+		if err != nil || !selector.Matches(labels.Set(podLabels)) {
+			continue
+		}
+		if allowsAllPeers(policy) {
+			return nil
+		}
+		if restrictingPolicy == nil {
+			restrictingPolicy = policy
+		}
+	}
+	return restrictingPolicy
+}
+
+// allowsAllPeers returns true if an ingress rule of the policy allows traffic from any source, either because it has
+// no peers or because one of its peers selects every namespace or every IP address
+func allowsAllPeers(policy *k8s.NetworkPolicyV1) bool {
+	for _, rule := range policy.Spec.Ingress {
+		if len(rule.From) == 0 {
+			return true
+		}
+		for _, peer := range rule.From {
+			if isAllPeer(peer) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isAllPeer(peer networkingv1.NetworkPolicyPeer) bool {
+	if peer.IPBlock != nil {
+		_, ipNet, err := net.ParseCIDR(peer.IPBlock.CIDR)
+		if err != nil {
+			return false
+		}
+		ones, _ := ipNet.Mask.Size()
+		return ones == 0
+	}
+	return peer.NamespaceSelector != nil && isEmptySelector(peer.NamespaceSelector) &&
+		(peer.PodSelector == nil || isEmptySelector(peer.PodSelector))
+}
+
+func isEmptySelector(selector *metav1.LabelSelector) bool {
+	return len(selector.MatchLabels) == 0 && len(selector.MatchExpressions) == 0
+}
+
+// isIngressPolicy returns true if the policy applies to ingress traffic. Policies without policy types always do
+func isIngressPolicy(policy *k8s.NetworkPolicyV1) bool {
+	if len(policy.Spec.PolicyTypes) == 0 {
+		return true
+	}
+	for _, policyType := range policy.Spec.PolicyTypes {
+		if policyType == networkingv1.PolicyTypeIngress {
+			return true
+		}
+	}
+	return false
+}
+
+func dedupe(values []string) []string {
+	seen := map[string]bool{}
+	var deduped []string
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			deduped = append(deduped, value)
+		}
+	}
+	sort.Strings(deduped)
+	return deduped
+}
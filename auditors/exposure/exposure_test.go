@@ -0,0 +1,50 @@
+package exposure
+
+import (
+	"testing"
+
+	"github.com/Shopify/kubeaudit/internal/test"
+	"github.com/Shopify/kubeaudit/pkg/override"
+	"github.com/stretchr/testify/assert"
+)
+
+const fixtureDir = "fixtures"
+
+func TestAuditExposure(t *testing.T) {
+	cases := []struct {
+		file           string
+		expectedErrors []string
+		expectedPaths  string
+	}{
+		{"loadbalancer.yml", []string{ExposedWithoutNetworkPolicy, LoadBalancerSourceRangesMissing}, "Service/web (LoadBalancer)"},
+		{"loadbalancer-protected.yml", []string{PubliclyExposed}, "Service/web (LoadBalancer)"},
+		{"loadbalancer-allow-all-policy.yml", []string{ExposedWithoutNetworkPolicy}, "Service/web (LoadBalancer)"},
+		{"loadbalancer-allowed.yml", []string{
+			override.GetOverriddenResultName(ExposedWithoutNetworkPolicy),
+			override.GetOverriddenResultName(LoadBalancerSourceRangesMissing),
+		}, "Service/web (LoadBalancer)"},
+		{"ingress.yml", []string{ExposedWithoutNetworkPolicy, IngressTLSMissing}, "Ingress/web -> Service/web (ClusterIP)"},
+		{"ingress-tls-allowed.yml", []string{PubliclyExposed, override.GetOverriddenResultName(IngressTLSMissing)}, "Ingress/web -> Service/web (ClusterIP)"},
+		{"httproute.yml", []string{ExposedWithoutNetworkPolicy}, "HTTPRoute/web -> Service/web (ClusterIP)"},
+		{"host-port.yml", []string{ExposedWithoutNetworkPolicy}, "hostPort 8080 (container container)"},
+		{"host-port-init-container.yml", []string{ExposedWithoutNetworkPolicy}, "hostPort 9090 (container sidecar)"},
+		{"host-port-allowed.yml", []string{ExposedWithoutNetworkPolicy}, "hostPort 9090 (container container)"},
+		{"not-exposed.yml", []string{}, ""},
+	}
+
+	for _, tc := range cases {
+		// This line is needed because of how scopes work with parallel tests (see https://gist.github.com/posener/92a55c4cd441fc5e5e85f27bca008721)
+		tc := tc
+		t.Run(tc.file, func(t *testing.T) {
+			t.Parallel()
+			report := test.AuditManifest(t, fixtureDir, tc.file, New(), tc.expectedErrors)
+			for _, result := range report.Results() {
+				for _, auditResult := range result.GetAuditResults() {
+					if auditResult.Metadata[ExposurePathsMetadataKey] != "" {
+						assert.Equal(t, tc.expectedPaths, auditResult.Metadata[ExposurePathsMetadataKey])
+					}
+				}
+			}
+		})
+	}
+}
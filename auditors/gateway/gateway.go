@@ -0,0 +1,381 @@
+package gateway
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Shopify/kubeaudit"
+	"github.com/Shopify/kubeaudit/pkg/k8s"
+	"github.com/Shopify/kubeaudit/pkg/override"
+	networkingv1 "k8s.io/api/networking/v1"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+const Name = "gateway"
+
+const (
+	// IngressHostWithoutTLS occurs when an Ingress with TLS entries serves a host which isn't covered by any of them.
+	// Ingresses without any TLS entry are reported by the exposure auditor
+	IngressHostWithoutTLS = "IngressHostWithoutTLS"
+	// ListenerWithoutTLS occurs when a Gateway listener serving HTTP traffic doesn't configure TLS
+	ListenerWithoutTLS = "ListenerWithoutTLS"
+	// WildcardHostname occurs when an Ingress, Gateway listener or HTTPRoute uses a wildcard hostname
+	WildcardHostname = "WildcardHostname"
+	// RoutesAllowedFromAllNamespaces occurs when a Gateway listener accepts routes from every namespace
+	RoutesAllowedFromAllNamespaces = "RoutesAllowedFromAllNamespaces"
+	// SecretNotFound occurs when an Ingress or Gateway references a TLS Secret which isn't part of the audited resources
+	SecretNotFound = "SecretNotFound"
+	// BackendNotFound occurs when an Ingress or HTTPRoute references a Service which isn't part of the audited resources
+	BackendNotFound = "BackendNotFound"
+)
+
+const (
+	WithoutTLSOverrideLabel          = "allow-without-tls"
+	WildcardHostnameOverrideLabel    = "allow-wildcard-hostname"
+	AllNamespacesRoutesOverrideLabel = "allow-routes-from-all-namespaces"
+	SecretNotFoundOverrideLabel      = "allow-secret-not-found"
+	BackendNotFoundOverrideLabel     = "allow-backend-not-found"
+)
+
+// Gateway implements Auditable
+type Gateway struct{}
+
+func New() *Gateway {
+	return &Gateway{}
+}
+
+// Audit checks that Ingresses and Gateways use TLS and specific hostnames, that Gateways only accept routes from
+// selected namespaces and that the Secrets and Services they reference are part of the audited resources
+func (a *Gateway) Audit(resource k8s.Resource, resources []k8s.Resource) ([]*kubeaudit.AuditResult, error) {
+	switch kubeType := resource.(type) {
+	case *k8s.IngressV1:
+		return auditIngress(kubeType, resources), nil
+	case *k8s.GatewayV1Beta1:
+		return auditGateway(kubeType, resources), nil
+	case *k8s.HTTPRouteV1Beta1:
+		return auditHTTPRoute(newHTTPRouteV1Beta1(kubeType), resources), nil
+	case *k8s.HTTPRouteV1Alpha2:
+		return auditHTTPRoute(newHTTPRouteV1Alpha2(kubeType), resources), nil
+	}
+	return nil, nil
+}
+
+// httpRoute holds the fields of a v1beta1 or v1alpha2 HTTPRoute which are audited
+type httpRoute struct {
+	resource    k8s.Resource
+	namespace   string
+	hostnames   []string
+	backendRefs []backendRef
+}
+
+type backendRef struct {
+	group, kind, namespace *string
+	name                   string
+}
+
+func newHTTPRouteV1Beta1(route *k8s.HTTPRouteV1Beta1) httpRoute {
+	r := httpRoute{resource: route, namespace: route.ObjectMeta.Namespace}
+	for _, hostname := range route.Spec.Hostnames {
+		r.hostnames = append(r.hostnames, string(hostname))
+	}
+	for _, rule := range route.Spec.Rules {
+		for _, ref := range rule.BackendRefs {
+			r.backendRefs = append(r.backendRefs, backendRef{
+				group:     (*string)(ref.Group),
+				kind:      (*string)(ref.Kind),
+				namespace: (*string)(ref.Namespace),
+				name:      string(ref.Name),
+			})
+		}
+	}
+	return r
+}
+
+func newHTTPRouteV1Alpha2(route *k8s.HTTPRouteV1Alpha2) httpRoute {
+	r := httpRoute{resource: route, namespace: route.ObjectMeta.Namespace}
+	for _, hostname := range route.Spec.Hostnames {
+		r.hostnames = append(r.hostnames, string(hostname))
+	}
+	for _, rule := range route.Spec.Rules {
+		for _, ref := range rule.BackendRefs {
+			r.backendRefs = append(r.backendRefs, backendRef{
+				group:     (*string)(ref.Group),
+				kind:      (*string)(ref.Kind),
+				namespace: (*string)(ref.Namespace),
+				name:      string(ref.Name),
+			})
+		}
+	}
+	return r
+}
+
+func auditIngress(ingress *k8s.IngressV1, resources []k8s.Resource) []*kubeaudit.AuditResult {
+	var auditResults []*kubeaudit.AuditResult
+	namespace := ingress.ObjectMeta.Namespace
+
+	for _, host := range getIngressHosts(ingress) {
+		if isWildcardHostname(host) {
+			auditResults = append(auditResults, newWildcardHostnameResult(ingress, "Host", host))
+		}
+
+		if len(ingress.Spec.TLS) > 0 && !isIngressHostTLS(ingress, host) {
+			auditResult := &kubeaudit.AuditResult{
+				Auditor:  Name,
+				Rule:     IngressHostWithoutTLS,
+				Severity: kubeaudit.Warn,
+				Message:  fmt.Sprintf("Ingress serves host %s without TLS. The host should be added to a tls entry.", displayHost(host)),
+				Metadata: kubeaudit.Metadata{
+					"Host": displayHost(host),
+				},
+			}
+			auditResult = override.ApplyOverride(auditResult, Name, "", ingress, WithoutTLSOverrideLabel)
+			auditResults = append(auditResults, auditResult)
+		}
+	}
+
+	for _, tls := range ingress.Spec.TLS {
+		if tls.SecretName != "" && !hasSecret(resources, namespace, tls.SecretName) {
+			auditResults = append(auditResults, newSecretNotFoundResult(ingress, namespace, tls.SecretName))
+		}
+	}
+
+	for _, service := range getIngressServices(ingress) {
+		if !hasService(resources, namespace, service) {
+			auditResults = append(auditResults, newBackendNotFoundResult(ingress, namespace, service))
+		}
+	}
+
+	return auditResults
+}
+
+func auditGateway(gateway *k8s.GatewayV1Beta1, resources []k8s.Resource) []*kubeaudit.AuditResult {
+	var auditResults []*kubeaudit.AuditResult
+
+	for _, listener := range gateway.Spec.Listeners {
+		if listener.Hostname != nil && isWildcardHostname(string(*listener.Hostname)) {
+			auditResult := newWildcardHostnameResult(gateway, "Hostname", string(*listener.Hostname))
+			auditResult.Metadata["Listener"] = string(listener.Name)
+			auditResults = append(auditResults, auditResult)
+		}
+
+		if listener.TLS == nil && isHTTPListener(listener) {
+			auditResult := &kubeaudit.AuditResult{
+				Auditor:  Name,
+				Rule:     ListenerWithoutTLS,
+				Severity: kubeaudit.Warn,
+				Message:  fmt.Sprintf("Gateway listener %s serves %s traffic without TLS. It should use the HTTPS protocol with a tls configuration.", listener.Name, listener.Protocol),
+				Metadata: kubeaudit.Metadata{
+					"Listener": string(listener.Name),
+					"Protocol": string(listener.Protocol),
+				},
+			}
+			auditResult = override.ApplyOverride(auditResult, Name, "", gateway, WithoutTLSOverrideLabel)
+			auditResults = append(auditResults, auditResult)
+		}
+
+		if isAllNamespacesListener(listener) {
+			auditResult := &kubeaudit.AuditResult{
+				Auditor:  Name,
+				Rule:     RoutesAllowedFromAllNamespaces,
+				Severity: kubeaudit.Warn,
+				Message:  fmt.Sprintf("Gateway listener %s accepts routes from every namespace. allowedRoutes.namespaces.from should be set to 'Same' or 'Selector'.", listener.Name),
+				Metadata: kubeaudit.Metadata{
+					"Listener": string(listener.Name),
+				},
+			}
+			auditResult = override.ApplyOverride(auditResult, Name, "", gateway, AllNamespacesRoutesOverrideLabel)
+			auditResults = append(auditResults, auditResult)
+		}
+
+		if listener.TLS == nil {
+			continue
+		}
+		for _, ref := range listener.TLS.CertificateRefs {
+			if !isCoreReference((*string)(ref.Group), (*string)(ref.Kind), "Secret") {
+				continue
+			}
+			namespace := referenceNamespace(gateway.ObjectMeta.Namespace, (*string)(ref.Namespace))
+			if !hasSecret(resources, namespace, string(ref.Name)) {
+				auditResults = append(auditResults, newSecretNotFoundResult(gateway, namespace, string(ref.Name)))
+			}
+		}
+	}
+
+	return auditResults
+}
+
+func auditHTTPRoute(route httpRoute, resources []k8s.Resource) []*kubeaudit.AuditResult {
+	var auditResults []*kubeaudit.AuditResult
+
+	for _, hostname := range route.hostnames {
+		if isWildcardHostname(hostname) {
+			auditResults = append(auditResults, newWildcardHostnameResult(route.resource, "Hostname", hostname))
+		}
+	}
+
+	for _, ref := range route.backendRefs {
+		if !isCoreReference(ref.group, ref.kind, "Service") {
+			continue
+		}
+		namespace := referenceNamespace(route.namespace, ref.namespace)
+		if !hasService(resources, namespace, ref.name) {
+			auditResults = append(auditResults, newBackendNotFoundResult(route.resource, namespace, ref.name))
+		}
+	}
+
+	return auditResults
+}
+
+func newWildcardHostnameResult(resource k8s.Resource, metadataKey, hostname string) *kubeaudit.AuditResult {
+	auditResult := &kubeaudit.AuditResult{
+		Auditor:  Name,
+		Rule:     WildcardHostname,
+		Severity: kubeaudit.Warn,
+		Message:  fmt.Sprintf("Hostname %s is a wildcard and matches every subdomain. A specific hostname should be used.", hostname),
+		Metadata: kubeaudit.Metadata{
+			metadataKey: hostname,
+		},
+	}
+	return override.ApplyOverride(auditResult, Name, "", resource, WildcardHostnameOverrideLabel)
+}
+
+func newSecretNotFoundResult(resource k8s.Resource, namespace, name string) *kubeaudit.AuditResult {
+	auditResult := &kubeaudit.AuditResult{
+		Auditor:  Name,
+		Rule:     SecretNotFound,
+		Severity: kubeaudit.Warn,
+		Message:  fmt.Sprintf("TLS Secret %s/%s isn't part of the audited resources. It may be missing or misspelled.", namespace, name),
+		Metadata: kubeaudit.Metadata{
+			"Namespace": namespace,
+			"Secret":    name,
+		},
+	}
+	return override.ApplyOverride(auditResult, Name, "", resource, SecretNotFoundOverrideLabel)
+}
+
+func newBackendNotFoundResult(resource k8s.Resource, namespace, name string) *kubeaudit.AuditResult {
+	auditResult := &kubeaudit.AuditResult{
+		Auditor:  Name,
+		Rule:     BackendNotFound,
+		Severity: kubeaudit.Warn,
+		Message:  fmt.Sprintf("Backend Service %s/%s isn't part of the audited resources. It may be missing or misspelled.", namespace, name),
+		Metadata: kubeaudit.Metadata{
+			"Namespace": namespace,
+			"Service":   name,
+		},
+	}
+	return override.ApplyOverride(auditResult, Name, "", resource, BackendNotFoundOverrideLabel)
+}
+
+// getIngressHosts returns the hosts served by the Ingress. Rules without a host and the default backend serve every
+// host, which is represented by an empty host
+func getIngressHosts(ingress *k8s.IngressV1) []string {
+	var hosts []string
+	seen := map[string]bool{}
+	addHost := func(host string) {
+		if !seen[host] {
+			seen[host] = true
+			hosts = append(hosts, host)
+		}
+	}
+
+	if ingress.Spec.DefaultBackend != nil {
+		addHost("")
+	}
+	for _, rule := range ingress.Spec.Rules {
+		addHost(rule.Host)
+	}
+	return hosts
+}
+
+// isIngressHostTLS returns true if a TLS entry of the Ingress covers the host. TLS entries without hosts cover every
+// host
+func isIngressHostTLS(ingress *k8s.IngressV1, host string) bool {
+	for _, tls := range ingress.Spec.TLS {
+		if len(tls.Hosts) == 0 {
+			return true
+		}
+		for _, tlsHost := range tls.Hosts {
+			if tlsHost == host {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func getIngressServices(ingress *k8s.IngressV1) []string {
+	var services []string
+	seen := map[string]bool{}
+	addBackend := func(backend *networkingv1.IngressBackend) {
+		if backend != nil && backend.Service != nil && !seen[backend.Service.Name] {
+			seen[backend.Service.Name] = true
+			services = append(services, backend.Service.Name)
+		}
+	}
+
+	addBackend(ingress.Spec.DefaultBackend)
+	for _, rule := range ingress.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			addBackend(&path.Backend)
+		}
+	}
+	return services
+}
+
+func displayHost(host string) string {
+	if host == "" {
+		return "*"
+	}
+	return host
+}
+
+func isWildcardHostname(hostname string) bool {
+	return strings.HasPrefix(hostname, "*")
+}
+
+// isHTTPListener returns true if the listener serves HTTP traffic, which should be encrypted
+func isHTTPListener(listener gatewayv1beta1.Listener) bool {
+	return listener.Protocol == gatewayv1beta1.HTTPProtocolType || listener.Protocol == gatewayv1beta1.HTTPSProtocolType
+}
+
+func isAllNamespacesListener(listener gatewayv1beta1.Listener) bool {
+	return listener.AllowedRoutes != nil && listener.AllowedRoutes.Namespaces != nil &&
+		listener.AllowedRoutes.Namespaces.From != nil && *listener.AllowedRoutes.Namespaces.From == gatewayv1beta1.NamespacesFromAll
+}
+
+// isCoreReference returns true if a Gateway API object reference points to the given kind in the core API group.
+// References default to the core API group and to the given kind
+func isCoreReference(group, kind *string, expectedKind string) bool {
+	return (group == nil || *group == "") && (kind == nil || *kind == expectedKind)
+}
+
+// referenceNamespace returns the namespace of a Gateway API object reference, which defaults to the namespace of the
+// referencing object
+func referenceNamespace(objectNamespace string, namespace *string) string {
+	if namespace != nil && *namespace != "" {
+		return *namespace
+	}
+	return objectNamespace
+}
+
+func hasSecret(resources []k8s.Resource, namespace, name string) bool {
+	for _, resource := range resources {
+		if secret, ok := resource.(*k8s.SecretV1); ok && secret.ObjectMeta.Namespace == namespace && secret.ObjectMeta.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func hasService(resources []k8s.Resource, namespace, name string) bool {
+	for _, resource := range resources {
+		if service, ok := resource.(*k8s.ServiceV1); ok && service.ObjectMeta.Namespace == namespace && service.ObjectMeta.Name == name {
+			return true
+		}
+	}
+	return false
+}
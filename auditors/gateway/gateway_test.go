@@ -0,0 +1,45 @@
+package gateway
+
+import (
+	"testing"
+
+	"github.com/Shopify/kubeaudit/internal/test"
+	"github.com/Shopify/kubeaudit/pkg/override"
+)
+
+const fixtureDir = "fixtures"
+
+func TestAuditGateway(t *testing.T) {
+	cases := []struct {
+		file           string
+		expectedErrors []string
+	}{
+		{"ingress-tls.yml", []string{}},
+		{"ingress-host-without-tls.yml", []string{IngressHostWithoutTLS}},
+		{"ingress-wildcard-missing-references.yml", []string{WildcardHostname, SecretNotFound, BackendNotFound}},
+		{"ingress-without-tls.yml", []string{}},
+		{"ingress-allowed.yml", []string{
+			override.GetOverriddenResultName(IngressHostWithoutTLS),
+			override.GetOverriddenResultName(WildcardHostname),
+			override.GetOverriddenResultName(SecretNotFound),
+		}},
+		{"gateway.yml", []string{}},
+		{"gateway-insecure.yml", []string{ListenerWithoutTLS, WildcardHostname, RoutesAllowedFromAllNamespaces, SecretNotFound}},
+		{"gateway-allowed.yml", []string{
+			override.GetOverriddenResultName(ListenerWithoutTLS),
+			override.GetOverriddenResultName(RoutesAllowedFromAllNamespaces),
+		}},
+		{"httproute.yml", []string{}},
+		{"httproute-wildcard-missing-backend.yml", []string{WildcardHostname, BackendNotFound}},
+		{"httproute-v1alpha2.yml", []string{WildcardHostname, override.GetOverriddenResultName(BackendNotFound)}},
+	}
+
+	for _, tc := range cases {
+		// This line is needed because of how scopes work with parallel tests (see https://gist.github.com/posener/92a55c4cd441fc5e5e85f27bca008721)
+		tc := tc
+		t.Run(tc.file, func(t *testing.T) {
+			t.Parallel()
+			test.AuditManifest(t, fixtureDir, tc.file, New(), tc.expectedErrors)
+		})
+	}
+}
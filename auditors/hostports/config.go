@@ -0,0 +1,13 @@
+package hostports
+
+type Config struct {
+	// AllowedPorts are the host ports containers can use
+	AllowedPorts []int32 `yaml:"allowedPorts"`
+}
+
+func (config *Config) GetAllowedPorts() []int32 {
+	if config == nil {
+		return nil
+	}
+	return config.AllowedPorts
+}
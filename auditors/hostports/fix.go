@@ -0,0 +1,21 @@
+package hostports
+
+import (
+	"fmt"
+
+	"github.com/Shopify/kubeaudit/pkg/k8s"
+)
+
+type fixHostPortSet struct {
+	container *k8s.ContainerV1
+	port      *k8s.ContainerPortV1
+}
+
+func (f *fixHostPortSet) Plan() string {
+	return fmt.Sprintf("Remove hostPort %d from port %d of container %s", f.port.HostPort, f.port.ContainerPort, f.container.Name)
+}
+
+func (f *fixHostPortSet) Apply(resource k8s.Resource) []k8s.Resource {
+	f.port.HostPort = 0
+	return nil
+}
@@ -0,0 +1,35 @@
+package hostports
+
+import (
+	"testing"
+
+	"github.com/Shopify/kubeaudit/internal/test"
+	"github.com/Shopify/kubeaudit/pkg/k8s"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFixHostPorts(t *testing.T) {
+	cases := []struct {
+		file              string
+		expectedHostPorts []int32
+	}{
+		{"host-port-nil.yml", []int32{0}},
+		{"host-port-set.yml", []int32{0, 0}},
+		{"host-port-set-allowed.yml", []int32{8080, 0}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.file, func(t *testing.T) {
+			resources, _ := test.FixSetup(t, fixtureDir, tc.file, New(Config{}))
+			require.Len(t, resources, 1)
+			var hostPorts []int32
+			for _, container := range k8s.GetContainers(resources[0]) {
+				for _, port := range container.Ports {
+					hostPorts = append(hostPorts, port.HostPort)
+				}
+			}
+			assert.Equal(t, tc.expectedHostPorts, hostPorts)
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package hostports
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/Shopify/kubeaudit"
+	"github.com/Shopify/kubeaudit/pkg/k8s"
+	"github.com/Shopify/kubeaudit/pkg/override"
+)
+
+const Name = "hostports"
+
+const (
+	// HostPortSet occurs when a container port is bound to a host port which is not allowed
+	HostPortSet = "HostPortSet"
+)
+
+const overrideLabelPrefix = "allow-host-port-"
+
+// HostPortMetadataKey is the audit result metadata key holding the host port
+const HostPortMetadataKey = "HostPort"
+
+// HostPorts implements Auditable
+type HostPorts struct {
+	allowedPorts map[int32]bool
+}
+
+func New(config Config) *HostPorts {
+	allowedPorts := map[int32]bool{}
+	for _, port := range config.GetAllowedPorts() {
+		allowedPorts[port] = true
+	}
+	return &HostPorts{
+		allowedPorts: allowedPorts,
+	}
+}
+
+// Audit checks that containers don't bind their ports to host ports, except for the allowed host ports
+func (a *HostPorts) Audit(resource k8s.Resource, _ []k8s.Resource) ([]*kubeaudit.AuditResult, error) {
+	var auditResults []*kubeaudit.AuditResult
+
+	for _, container := range k8s.GetContainers(resource) {
+		for i := range container.Ports {
+			port := &container.Ports[i]
+			if port.HostPort == 0 {
+				continue
+			}
+			auditResult := a.auditPort(container, port)
+			auditResult = override.ApplyOverride(auditResult, Name, container.Name, resource, GetOverrideLabel(port.HostPort))
+			if auditResult != nil {
+				auditResults = append(auditResults, auditResult)
+			}
+		}
+	}
+
+	return auditResults, nil
+}
+
+// GetOverrideLabel returns the override label which allows the host port
+func GetOverrideLabel(hostPort int32) string {
+	return overrideLabelPrefix + strconv.Itoa(int(hostPort))
+}
+
+func (a *HostPorts) auditPort(container *k8s.ContainerV1, port *k8s.ContainerPortV1) *kubeaudit.AuditResult {
+	if a.allowedPorts[port.HostPort] {
+		return nil
+	}
+
+	return &kubeaudit.AuditResult{
+		Auditor:  Name,
+		Rule:     HostPortSet,
+		Severity: kubeaudit.Error,
+		Message: fmt.Sprintf("Container port %d is bound to host port %d. The hostPort should be removed. If you need this host port, add an override label such as '%s: SomeReason'.",
+			port.ContainerPort, port.HostPort, override.GetContainerOverrideLabel(container.Name, GetOverrideLabel(port.HostPort))),
+		PendingFix: &fixHostPortSet{
+			container: container,
+			port:      port,
+		},
+		Metadata: kubeaudit.Metadata{
+			"Container":         container.Name,
+			"ContainerPort":     strconv.Itoa(int(port.ContainerPort)),
+			HostPortMetadataKey: strconv.Itoa(int(port.HostPort)),
+		},
+	}
+}
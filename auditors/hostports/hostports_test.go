@@ -0,0 +1,36 @@
+package hostports
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Shopify/kubeaudit"
+	"github.com/Shopify/kubeaudit/internal/test"
+	"github.com/Shopify/kubeaudit/pkg/override"
+)
+
+const fixtureDir = "fixtures"
+
+func TestAuditHostPorts(t *testing.T) {
+	cases := []struct {
+		file           string
+		config         Config
+		expectedErrors []string
+	}{
+		{"host-port-nil.yml", Config{}, []string{}},
+		{"host-port-set.yml", Config{}, []string{HostPortSet}},
+		{"host-port-set.yml", Config{AllowedPorts: []int32{8080}}, []string{}},
+		{"host-port-set-allowed.yml", Config{}, []string{override.GetOverriddenResultName(HostPortSet), HostPortSet}},
+		{"host-port-set-allowed.yml", Config{AllowedPorts: []int32{443, 8080}}, []string{kubeaudit.RedundantAuditorOverride}},
+	}
+
+	for _, tc := range cases {
+		// This line is needed because of how scopes work with parallel tests (see https://gist.github.com/posener/92a55c4cd441fc5e5e85f27bca008721)
+		tc := tc
+		t.Run(tc.file, func(t *testing.T) {
+			t.Parallel()
+			test.AuditManifest(t, fixtureDir, tc.file, New(tc.config), tc.expectedErrors)
+			test.AuditLocal(t, fixtureDir, tc.file, New(tc.config), strings.Split(tc.file, ".")[0], tc.expectedErrors)
+		})
+	}
+}
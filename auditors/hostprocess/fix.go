@@ -0,0 +1,34 @@
+package hostprocess
+
+import (
+	"fmt"
+
+	"github.com/Shopify/kubeaudit/pkg/k8s"
+	apiv1 "k8s.io/api/core/v1"
+)
+
+// fixHostProcess sets hostProcess to false for the container. The pod-level value is set to false too, since the
+// containers of a pod must either all be HostProcess containers or none of them
+type fixHostProcess struct {
+	container *k8s.ContainerV1
+}
+
+func (f *fixHostProcess) Plan() string {
+	return fmt.Sprintf("Set windowsOptions.hostProcess to 'false' in container SecurityContext for container %s and in PodSecurityContext", f.container.Name)
+}
+
+func (f *fixHostProcess) Apply(resource k8s.Resource) []k8s.Resource {
+	if f.container.SecurityContext == nil {
+		f.container.SecurityContext = &k8s.SecurityContextV1{}
+	}
+	if f.container.SecurityContext.WindowsOptions == nil {
+		f.container.SecurityContext.WindowsOptions = &apiv1.WindowsSecurityContextOptions{}
+	}
+	f.container.SecurityContext.WindowsOptions.HostProcess = k8s.NewFalse()
+
+	podSpec := k8s.GetPodSpec(resource)
+	if isPodHostProcessTrue(podSpec) {
+		podSpec.SecurityContext.WindowsOptions.HostProcess = k8s.NewFalse()
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package hostprocess
+
+import (
+	"testing"
+
+	"github.com/Shopify/kubeaudit/internal/test"
+	"github.com/Shopify/kubeaudit/pkg/k8s"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFixHostProcess(t *testing.T) {
+	cases := []struct {
+		file                string
+		expectedHostProcess bool
+	}{
+		{"host-process-csc-true.yml", false},
+		{"host-process-psc-true.yml", false},
+		{"host-process-psc-true-allowed.yml", true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.file, func(t *testing.T) {
+			resources, _ := test.FixSetup(t, fixtureDir, tc.file, New())
+			require.Len(t, resources, 1)
+			podSpec := k8s.GetPodSpec(resources[0])
+			assert.Equal(t, tc.expectedHostProcess, isPodHostProcessTrue(podSpec))
+			for _, container := range k8s.GetContainers(resources[0]) {
+				if tc.expectedHostProcess {
+					continue
+				}
+				assert.False(t, *container.SecurityContext.WindowsOptions.HostProcess)
+			}
+		})
+	}
+}
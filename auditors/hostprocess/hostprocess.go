@@ -0,0 +1,89 @@
+package hostprocess
+
+import (
+	"github.com/Shopify/kubeaudit"
+	"github.com/Shopify/kubeaudit/pkg/k8s"
+	"github.com/Shopify/kubeaudit/pkg/override"
+)
+
+const Name = "hostprocess"
+
+const (
+	// HostProcessCSCTrue occurs when windowsOptions.hostProcess is set to true in the container SecurityContext
+	HostProcessCSCTrue = "HostProcessCSCTrue"
+	// HostProcessPSCTrue occurs when windowsOptions.hostProcess is set to true in the PodSecurityContext and not set in
+	// the container SecurityContext
+	HostProcessPSCTrue = "HostProcessPSCTrue"
+)
+
+const OverrideLabel = "allow-host-process"
+
+// HostProcess implements Auditable
+type HostProcess struct{}
+
+func New() *HostProcess {
+	return &HostProcess{}
+}
+
+// Audit checks that no container runs as a Windows HostProcess container
+func (a *HostProcess) Audit(resource k8s.Resource, _ []k8s.Resource) ([]*kubeaudit.AuditResult, error) {
+	var auditResults []*kubeaudit.AuditResult
+
+	podSpec := k8s.GetPodSpec(resource)
+	if podSpec == nil {
+		return nil, nil
+	}
+
+	for _, container := range k8s.GetContainers(resource) {
+		auditResult := auditContainer(container, podSpec)
+		auditResult = override.ApplyOverride(auditResult, Name, container.Name, resource, OverrideLabel)
+		if auditResult != nil {
+			auditResults = append(auditResults, auditResult)
+		}
+	}
+
+	return auditResults, nil
+}
+
+func auditContainer(container *k8s.ContainerV1, podSpec *k8s.PodSpecV1) *kubeaudit.AuditResult {
+	if container.SecurityContext != nil && container.SecurityContext.WindowsOptions != nil &&
+		container.SecurityContext.WindowsOptions.HostProcess != nil {
+		if !*container.SecurityContext.WindowsOptions.HostProcess {
+			return nil
+		}
+		return &kubeaudit.AuditResult{
+			Auditor:  Name,
+			Rule:     HostProcessCSCTrue,
+			Severity: kubeaudit.Error,
+			Message:  "windowsOptions.hostProcess is set to 'true' in container SecurityContext. It should be set to 'false'.",
+			PendingFix: &fixHostProcess{
+				container: container,
+			},
+			Metadata: kubeaudit.Metadata{
+				"Container": container.Name,
+			},
+		}
+	}
+
+	if isPodHostProcessTrue(podSpec) {
+		return &kubeaudit.AuditResult{
+			Auditor:  Name,
+			Rule:     HostProcessPSCTrue,
+			Severity: kubeaudit.Error,
+			Message:  "windowsOptions.hostProcess is set to 'true' in PodSecurityContext. It should be set to 'false'.",
+			PendingFix: &fixHostProcess{
+				container: container,
+			},
+			Metadata: kubeaudit.Metadata{
+				"Container": container.Name,
+			},
+		}
+	}
+
+	return nil
+}
+
+func isPodHostProcessTrue(podSpec *k8s.PodSpecV1) bool {
+	return podSpec.SecurityContext != nil && podSpec.SecurityContext.WindowsOptions != nil &&
+		podSpec.SecurityContext.WindowsOptions.HostProcess != nil && *podSpec.SecurityContext.WindowsOptions.HostProcess
+}
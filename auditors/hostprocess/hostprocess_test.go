@@ -0,0 +1,35 @@
+package hostprocess
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Shopify/kubeaudit"
+	"github.com/Shopify/kubeaudit/internal/test"
+	"github.com/Shopify/kubeaudit/pkg/override"
+)
+
+const fixtureDir = "fixtures"
+
+func TestAuditHostProcess(t *testing.T) {
+	cases := []struct {
+		file           string
+		expectedErrors []string
+	}{
+		{"host-process-nil.yml", []string{}},
+		{"host-process-csc-true.yml", []string{HostProcessCSCTrue}},
+		{"host-process-psc-true.yml", []string{HostProcessPSCTrue}},
+		{"host-process-psc-true-allowed.yml", []string{override.GetOverriddenResultName(HostProcessPSCTrue)}},
+		{"host-process-redundant-override.yml", []string{kubeaudit.RedundantAuditorOverride}},
+	}
+
+	for _, tc := range cases {
+		// This line is needed because of how scopes work with parallel tests (see https://gist.github.com/posener/92a55c4cd441fc5e5e85f27bca008721)
+		tc := tc
+		t.Run(tc.file, func(t *testing.T) {
+			t.Parallel()
+			test.AuditManifest(t, fixtureDir, tc.file, New(), tc.expectedErrors)
+			test.AuditLocal(t, fixtureDir, tc.file, New(), strings.Split(tc.file, ".")[0], tc.expectedErrors)
+		})
+	}
+}
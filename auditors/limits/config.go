@@ -3,14 +3,64 @@ package limits
 import (
 	"fmt"
 
+	v1 "k8s.io/api/core/v1"
 	k8sResource "k8s.io/apimachinery/pkg/api/resource"
 )
 
 type Config struct {
 	CPU    string `yaml:"cpu"`
 	Memory string `yaml:"memory"`
+	// EphemeralStorage is the max ephemeral-storage limit. If it is set, containers must set an ephemeral-storage limit
+	EphemeralStorage string `yaml:"ephemeralStorage"`
+
+	MinCPURequest    string `yaml:"minCPURequest"`
+	MaxCPURequest    string `yaml:"maxCPURequest"`
+	MinMemoryRequest string `yaml:"minMemoryRequest"`
+	MaxMemoryRequest string `yaml:"maxMemoryRequest"`
+	// RequireRequests reports containers which don't set their CPU or memory requests explicitly
+	RequireRequests bool `yaml:"requireRequests"`
+
+	// QoSClass is the lowest pod QoS class allowed, either "Burstable" or "Guaranteed"
+	QoSClass string `yaml:"qosClass"`
+
+	// MaxCPUOvercommitRatio and MaxMemoryOvercommitRatio are the max ratios of limits to requests (eg. 2 allows a
+	// limit of twice the request)
+	MaxCPUOvercommitRatio    float64 `yaml:"maxCPUOvercommitRatio"`
+	MaxMemoryOvercommitRatio float64 `yaml:"maxMemoryOvercommitRatio"`
+
+	// RequireLimitRange and RequireResourceQuota report namespaces without a LimitRange or a ResourceQuota
+	RequireLimitRange    bool `yaml:"requireLimitRange"`
+	RequireResourceQuota bool `yaml:"requireResourceQuota"`
+
+	// DefaultLimitRange configures the container defaults of the LimitRange created to fix a namespace without one
+	DefaultLimitRange DefaultLimitRangeConfig `yaml:"defaultLimitRange"`
+
+	// Sidecar configures the max limits of sidecar containers (init containers with a restartPolicy of Always), which
+	// keep running alongside the regular containers but usually need less resources
+	Sidecar SidecarConfig `yaml:"sidecar"`
 }
 
+// SidecarConfig configures the max limits of sidecar containers. The max limits of the regular containers are used for
+// the ones which aren't set
+type SidecarConfig struct {
+	CPU    string `yaml:"cpu"`
+	Memory string `yaml:"memory"`
+}
+
+type DefaultLimitRangeConfig struct {
+	CPU           string `yaml:"cpu"`
+	Memory        string `yaml:"memory"`
+	CPURequest    string `yaml:"cpuRequest"`
+	MemoryRequest string `yaml:"memoryRequest"`
+}
+
+const (
+	DefaultLimitRangeCPU           = "500m"
+	DefaultLimitRangeMemory        = "512Mi"
+	DefaultLimitRangeCPURequest    = "100m"
+	DefaultLimitRangeMemoryRequest = "128Mi"
+)
+
 func (config *Config) GetCPU() (k8sResource.Quantity, error) {
 	cpuArg := ""
 	if config != nil {
@@ -40,3 +90,142 @@ func (config *Config) GetMemory() (k8sResource.Quantity, error) {
 	}
 	return k8sResource.Quantity{}, nil
 }
+
+func (config *Config) GetEphemeralStorage() (k8sResource.Quantity, error) {
+	if config == nil {
+		return parseQuantity("", "max ephemeral-storage limit")
+	}
+	return parseQuantity(config.EphemeralStorage, "max ephemeral-storage limit")
+}
+
+func (config *Config) GetSidecarCPU() (k8sResource.Quantity, error) {
+	if config == nil || config.Sidecar.CPU == "" {
+		return config.GetCPU()
+	}
+	return parseQuantity(config.Sidecar.CPU, "max sidecar CPU limit")
+}
+
+func (config *Config) GetSidecarMemory() (k8sResource.Quantity, error) {
+	if config == nil || config.Sidecar.Memory == "" {
+		return config.GetMemory()
+	}
+	return parseQuantity(config.Sidecar.Memory, "max sidecar memory limit")
+}
+
+func (config *Config) GetMinCPURequest() (k8sResource.Quantity, error) {
+	if config == nil {
+		return parseQuantity("", "min CPU request")
+	}
+	return parseQuantity(config.MinCPURequest, "min CPU request")
+}
+
+func (config *Config) GetMaxCPURequest() (k8sResource.Quantity, error) {
+	if config == nil {
+		return parseQuantity("", "max CPU request")
+	}
+	return parseQuantity(config.MaxCPURequest, "max CPU request")
+}
+
+func (config *Config) GetMinMemoryRequest() (k8sResource.Quantity, error) {
+	if config == nil {
+		return parseQuantity("", "min memory request")
+	}
+	return parseQuantity(config.MinMemoryRequest, "min memory request")
+}
+
+func (config *Config) GetMaxMemoryRequest() (k8sResource.Quantity, error) {
+	if config == nil {
+		return parseQuantity("", "max memory request")
+	}
+	return parseQuantity(config.MaxMemoryRequest, "max memory request")
+}
+
+func (config *Config) GetRequireRequests() bool {
+	return config != nil && config.RequireRequests
+}
+
+func (config *Config) GetRequireLimitRange() bool {
+	return config != nil && config.RequireLimitRange
+}
+
+func (config *Config) GetRequireResourceQuota() bool {
+	return config != nil && config.RequireResourceQuota
+}
+
+func (config *Config) GetQoSClass() (v1.PodQOSClass, error) {
+	if config == nil || config.QoSClass == "" {
+		return "", nil
+	}
+	switch qosClass := v1.PodQOSClass(config.QoSClass); qosClass {
+	case v1.PodQOSBurstable, v1.PodQOSGuaranteed:
+		return qosClass, nil
+	}
+	return "", fmt.Errorf("error parsing QoS class: %q should be one of %q or %q", config.QoSClass, v1.PodQOSBurstable, v1.PodQOSGuaranteed)
+}
+
+func (config *Config) GetMaxCPUOvercommitRatio() (float64, error) {
+	if config == nil {
+		return 0, nil
+	}
+	return parseRatio(config.MaxCPUOvercommitRatio, "max CPU overcommit ratio")
+}
+
+func (config *Config) GetMaxMemoryOvercommitRatio() (float64, error) {
+	if config == nil {
+		return 0, nil
+	}
+	return parseRatio(config.MaxMemoryOvercommitRatio, "max memory overcommit ratio")
+}
+
+// GetDefaultLimitRange returns the default container limits and requests of the LimitRange created to fix a namespace
+// without one
+func (config *Config) GetDefaultLimitRange() (limits, requests v1.ResourceList, err error) {
+	defaults := DefaultLimitRangeConfig{}
+	if config != nil {
+		defaults = config.DefaultLimitRange
+	}
+
+	limits, requests = v1.ResourceList{}, v1.ResourceList{}
+	for _, item := range []struct {
+		list         v1.ResourceList
+		resource     v1.ResourceName
+		value        string
+		defaultValue string
+		name         string
+	}{
+		{limits, v1.ResourceCPU, defaults.CPU, DefaultLimitRangeCPU, "default LimitRange CPU limit"},
+		{limits, v1.ResourceMemory, defaults.Memory, DefaultLimitRangeMemory, "default LimitRange memory limit"},
+		{requests, v1.ResourceCPU, defaults.CPURequest, DefaultLimitRangeCPURequest, "default LimitRange CPU request"},
+		{requests, v1.ResourceMemory, defaults.MemoryRequest, DefaultLimitRangeMemoryRequest, "default LimitRange memory request"},
+	} {
+		value := item.value
+		if value == "" {
+			value = item.defaultValue
+		}
+		quantity, err := parseQuantity(value, item.name)
+		if err != nil {
+			return nil, nil, err
+		}
+		item.list[item.resource] = quantity
+	}
+
+	return limits, requests, nil
+}
+
+func parseQuantity(value, name string) (k8sResource.Quantity, error) {
+	if value == "" {
+		return k8sResource.Quantity{}, nil
+	}
+	quantity, err := k8sResource.ParseQuantity(value)
+	if err != nil {
+		return quantity, fmt.Errorf("error parsing %s: %w", name, err)
+	}
+	return quantity, nil
+}
+
+func parseRatio(ratio float64, name string) (float64, error) {
+	if ratio != 0 && ratio < 1 {
+		return 0, fmt.Errorf("error parsing %s: %v should be at least 1", name, ratio)
+	}
+	return ratio, nil
+}
@@ -0,0 +1,92 @@
+package limits
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Shopify/kubeaudit"
+	"github.com/Shopify/kubeaudit/pkg/k8s"
+	v1 "k8s.io/api/core/v1"
+	k8sResource "k8s.io/apimachinery/pkg/api/resource"
+)
+
+type fixBySettingRequests struct {
+	container *k8s.ContainerV1
+	requests  v1.ResourceList
+}
+
+// newFixBySettingRequestsFromLimits returns a fix which sets the requests for the given resources to the limits. It
+// returns nil if none of the resources have a limit to copy
+func newFixBySettingRequestsFromLimits(container *k8s.ContainerV1, resources ...v1.ResourceName) kubeaudit.PendingFix {
+	requests := v1.ResourceList{}
+	for _, resource := range resources {
+		if limit, ok := getLimits(container)[resource]; ok && !limit.IsZero() {
+			requests[resource] = limit.DeepCopy()
+		}
+	}
+	if len(requests) == 0 {
+		return nil
+	}
+	return &fixBySettingRequests{container: container, requests: requests}
+}
+
+// newFixBySettingRequest returns a fix which sets the request for the resource. It returns nil if the request would
+// exceed the limit, as Kubernetes rejects requests higher than limits
+func newFixBySettingRequest(container *k8s.ContainerV1, resource v1.ResourceName, request k8sResource.Quantity) kubeaudit.PendingFix {
+	if limit, ok := getLimits(container)[resource]; ok && !limit.IsZero() && request.Cmp(limit) > 0 {
+		return nil
+	}
+	return &fixBySettingRequests{container: container, requests: v1.ResourceList{resource: request}}
+}
+
+func (f *fixBySettingRequests) Plan() string {
+	requests := make([]string, 0, len(f.requests))
+	for resource, request := range f.requests {
+		requests = append(requests, fmt.Sprintf("%s=%s", resource, request.String()))
+	}
+	sort.Strings(requests)
+	return fmt.Sprintf("Set resource requests %s for container %s", strings.Join(requests, ", "), f.container.Name)
+}
+
+func (f *fixBySettingRequests) Apply(resource k8s.Resource) []k8s.Resource {
+	if f.container.Resources.Requests == nil {
+		f.container.Resources.Requests = v1.ResourceList{}
+	}
+	for resourceName, request := range f.requests {
+		f.container.Resources.Requests[resourceName] = request
+	}
+	return nil
+}
+
+// setFixContainer points the request fix at the container in the resource, as the container may have been audited
+// using a copy with the LimitRange defaults applied
+func setFixContainer(auditResult *kubeaudit.AuditResult, container *k8s.ContainerV1) {
+	if fix, ok := auditResult.PendingFix.(*fixBySettingRequests); ok {
+		fix.container = container
+	}
+}
+
+type fixByAddingLimitRange struct {
+	namespace string
+	limits    v1.ResourceList
+	requests  v1.ResourceList
+}
+
+func (f *fixByAddingLimitRange) Plan() string {
+	return fmt.Sprintf("Create a new LimitRange resource with default container limits and requests for namespace %s", f.namespace)
+}
+
+func (f *fixByAddingLimitRange) Apply(resource k8s.Resource) []k8s.Resource {
+	limitRange := k8s.NewLimitRange()
+	limitRange.ObjectMeta = k8s.ObjectMetaV1{
+		Name:      DefaultLimitRangeName,
+		Namespace: f.namespace,
+	}
+	limitRange.Spec.Limits = []k8s.LimitRangeItemV1{{
+		Type:           v1.LimitTypeContainer,
+		Default:        f.limits.DeepCopy(),
+		DefaultRequest: f.requests.DeepCopy(),
+	}}
+	return []k8s.Resource{limitRange}
+}
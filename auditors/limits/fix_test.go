@@ -0,0 +1,80 @@
+package limits
+
+import (
+	"testing"
+
+	"github.com/Shopify/kubeaudit/internal/test"
+	"github.com/Shopify/kubeaudit/pkg/k8s"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	k8sResource "k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestFixLimits(t *testing.T) {
+	cases := []struct {
+		file             string
+		config           Config
+		expectedRequests map[string]v1.ResourceList
+	}{
+		{"resources-limit-nil.yml", Config{}, map[string]v1.ResourceList{"container": nil}},
+		{"resources-limit-no-memory.yml", Config{RequireRequests: true}, map[string]v1.ResourceList{"container": {"cpu": k8sResource.MustParse("1")}}},
+		{"resources-requests-nil.yml", Config{RequireRequests: true}, map[string]v1.ResourceList{
+			"container": {"cpu": k8sResource.MustParse("750m"), "memory": k8sResource.MustParse("512Mi")},
+		}},
+		{"resources-requests-no-memory.yml", Config{RequireRequests: true}, map[string]v1.ResourceList{
+			"container": {"cpu": k8sResource.MustParse("500m"), "memory": k8sResource.MustParse("512Mi")},
+		}},
+		{"resources-limit.yml", Config{MinCPURequest: "600m", MaxMemoryRequest: "128Mi"}, map[string]v1.ResourceList{
+			"container": {"cpu": k8sResource.MustParse("600m"), "memory": k8sResource.MustParse("128Mi")},
+		}},
+		// A min request higher than the limit can't be fixed
+		{"resources-limit.yml", Config{MinCPURequest: "1"}, map[string]v1.ResourceList{
+			"container": {"cpu": k8sResource.MustParse("500m"), "memory": k8sResource.MustParse("256Mi")},
+		}},
+		{"resources-limit.yml", Config{MaxCPUOvercommitRatio: 1.2, MaxMemoryOvercommitRatio: 1.5}, map[string]v1.ResourceList{
+			"container": {"cpu": k8sResource.MustParse("625m"), "memory": k8sResource.MustParse("357913942")},
+		}},
+		{"resources-qos-multi-containers.yml", Config{QoSClass: "Guaranteed"}, map[string]v1.ResourceList{
+			"container1": {"cpu": k8sResource.MustParse("750m"), "memory": k8sResource.MustParse("512Mi")},
+			"container2": {"cpu": k8sResource.MustParse("750m"), "memory": k8sResource.MustParse("512Mi")},
+		}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.file, func(t *testing.T) {
+			auditor, err := New(tc.config)
+			assert.Nil(t, err)
+			resources, _ := test.FixSetup(t, fixtureDir, tc.file, auditor)
+			for _, resource := range resources {
+				for _, container := range k8s.GetContainers(resource) {
+					expected, ok := tc.expectedRequests[container.Name]
+					assert.True(t, ok, container.Name)
+					assert.Equal(t, len(expected), len(container.Resources.Requests), container.Name)
+					for name, request := range expected {
+						actual := container.Resources.Requests[name]
+						assert.Zero(t, request.Cmp(actual), "%s %s: expected %s, got %s", container.Name, name, request.String(), actual.String())
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestFixLimitRange(t *testing.T) {
+	auditor, err := New(Config{RequireLimitRange: true, DefaultLimitRange: DefaultLimitRangeConfig{CPU: "1", MemoryRequest: "256Mi"}})
+	assert.Nil(t, err)
+
+	resources, report := test.FixSetup(t, fixtureDir, "namespace-missing-limitrange-and-quota.yml", auditor)
+	assert.NotNil(t, report)
+
+	limitRanges := getLimitRanges(resources, "namespace-missing-limitrange-and-quota")
+	if assert.Len(t, limitRanges, 1) && assert.Len(t, limitRanges[0].Spec.Limits, 1) {
+		item := limitRanges[0].Spec.Limits[0]
+		assert.Equal(t, DefaultLimitRangeName, limitRanges[0].ObjectMeta.Name)
+		assert.Equal(t, v1.LimitTypeContainer, item.Type)
+		assert.Equal(t, "1", item.Default.Cpu().String())
+		assert.Equal(t, DefaultLimitRangeMemory, item.Default.Memory().String())
+		assert.Equal(t, DefaultLimitRangeCPURequest, item.DefaultRequest.Cpu().String())
+		assert.Equal(t, "256Mi", item.DefaultRequest.Memory().String())
+	}
+}
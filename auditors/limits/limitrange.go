@@ -0,0 +1,138 @@
+package limits
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/Shopify/kubeaudit"
+	"github.com/Shopify/kubeaudit/pkg/k8s"
+	v1 "k8s.io/api/core/v1"
+)
+
+// LimitRangeMetadataKey is the audit result metadata key holding the names of the LimitRanges which set default
+// limits or requests for the container
+const LimitRangeMetadataKey = "LimitRange"
+
+// DefaultLimitRangeName is the name of the LimitRange created to fix a namespace without one
+const DefaultLimitRangeName = "default-limits"
+
+func (limits *Limits) auditNamespace(namespace *k8s.NamespaceV1, resources []k8s.Resource) (auditResults []*kubeaudit.AuditResult) {
+	name := namespace.ObjectMeta.Name
+
+	if limits.requireLimitRange && len(getLimitRanges(resources, name)) == 0 {
+		auditResults = append(auditResults, &kubeaudit.AuditResult{
+			Auditor:  Name,
+			Rule:     MissingLimitRange,
+			Severity: kubeaudit.Warn,
+			Message:  fmt.Sprintf("Namespace %s has no LimitRange so containers without resource limits are unbounded.", name),
+			Metadata: kubeaudit.Metadata{
+				"Namespace": name,
+			},
+			PendingFix: &fixByAddingLimitRange{
+				namespace: name,
+				limits:    limits.defaultLimitRangeLimits,
+				requests:  limits.defaultLimitRangeRequests,
+			},
+		})
+	}
+
+	if limits.requireResourceQuota && !hasResourceQuota(resources, name) {
+		auditResults = append(auditResults, &kubeaudit.AuditResult{
+			Auditor:  Name,
+			Rule:     MissingResourceQuota,
+			Severity: kubeaudit.Warn,
+			Message:  fmt.Sprintf("Namespace %s has no ResourceQuota so its total resource usage is unbounded.", name),
+			Metadata: kubeaudit.Metadata{
+				"Namespace": name,
+			},
+		})
+	}
+
+	return
+}
+
+// getLimitRanges returns the LimitRanges in the namespace sorted by name
+func getLimitRanges(resources []k8s.Resource, namespace string) (limitRanges []*k8s.LimitRangeV1) {
+	for _, resource := range resources {
+		limitRange, ok := resource.(*k8s.LimitRangeV1)
+		if ok && limitRange.ObjectMeta.Namespace == namespace {
+			limitRanges = append(limitRanges, limitRange)
+		}
+	}
+	sort.Slice(limitRanges, func(i, j int) bool {
+		return limitRanges[i].ObjectMeta.Name < limitRanges[j].ObjectMeta.Name
+	})
+	return
+}
+
+func hasResourceQuota(resources []k8s.Resource, namespace string) bool {
+	for _, resource := range resources {
+		if resourceQuota, ok := resource.(*k8s.ResourceQuotaV1); ok && resourceQuota.ObjectMeta.Namespace == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// applyLimitRangeDefaults returns a copy of the container with the default limits and requests it would get from the
+// LimitRanges when it is admitted, along with the names of the LimitRanges which set any of them
+func applyLimitRangeDefaults(container *k8s.ContainerV1, limitRanges []*k8s.LimitRangeV1) (*k8s.ContainerV1, []string) {
+	if len(limitRanges) == 0 {
+		return container, nil
+	}
+
+	effective := container.DeepCopy()
+	var applied []string
+
+	for _, limitRange := range limitRanges {
+		isApplied := false
+		for _, item := range limitRange.Spec.Limits {
+			if item.Type != v1.LimitTypeContainer {
+				continue
+			}
+
+			// The API server defaults an unset default limit to the max, and an unset default request to the default
+			// limit
+			defaultLimits := item.Default
+			if defaultLimits == nil {
+				defaultLimits = item.Max
+			}
+			defaultRequests := item.DefaultRequest
+			if defaultRequests == nil {
+				defaultRequests = defaultLimits
+			}
+
+			for resource, limit := range defaultLimits {
+				if _, ok := effective.Resources.Limits[resource]; ok {
+					continue
+				}
+				if effective.Resources.Limits == nil {
+					effective.Resources.Limits = v1.ResourceList{}
+				}
+				effective.Resources.Limits[resource] = limit.DeepCopy()
+				isApplied = true
+			}
+
+			for resource, request := range defaultRequests {
+				// An unset request defaults to the container's own limit before LimitRange defaults are applied
+				if _, ok := container.Resources.Limits[resource]; ok {
+					continue
+				}
+				if _, ok := effective.Resources.Requests[resource]; ok {
+					continue
+				}
+				if effective.Resources.Requests == nil {
+					effective.Resources.Requests = v1.ResourceList{}
+				}
+				effective.Resources.Requests[resource] = request.DeepCopy()
+				isApplied = true
+			}
+		}
+
+		if isApplied {
+			applied = append(applied, limitRange.ObjectMeta.Name)
+		}
+	}
+
+	return effective, applied
+}
@@ -2,6 +2,9 @@ package limits
 
 import (
 	"fmt"
+	"math"
+	"strconv"
+	"strings"
 
 	"github.com/Shopify/kubeaudit"
 	"github.com/Shopify/kubeaudit/pkg/k8s"
@@ -22,47 +25,170 @@ const (
 	LimitsCPUExceeded = "LimitsCPUExceeded"
 	// LimitsMemoryExceeded occurs when the memory limit specified for a container is higher than the specified max memory limit
 	LimitsMemoryExceeded = "LimitsMemoryExceeded"
+	// LimitsEphemeralStorageNotSet occurs when there is no ephemeral-storage limit specified for a container and a max
+	// ephemeral-storage limit is configured
+	LimitsEphemeralStorageNotSet = "LimitsEphemeralStorageNotSet"
+	// LimitsEphemeralStorageExceeded occurs when the ephemeral-storage limit specified for a container is higher than
+	// the specified max ephemeral-storage limit
+	LimitsEphemeralStorageExceeded = "LimitsEphemeralStorageExceeded"
+	// RequestsNotSet occurs when there are no cpu and memory requests specified for a container
+	RequestsNotSet = "RequestsNotSet"
+	// RequestsCPUNotSet occurs when there is no cpu request specified for a container
+	RequestsCPUNotSet = "RequestsCPUNotSet"
+	// RequestsMemoryNotSet occurs when there is no memory request specified for a container
+	RequestsMemoryNotSet = "RequestsMemoryNotSet"
+	// RequestsCPUTooLow occurs when the CPU request of a container is lower than the specified min CPU request
+	RequestsCPUTooLow = "RequestsCPUTooLow"
+	// RequestsCPUExceeded occurs when the CPU request of a container is higher than the specified max CPU request
+	RequestsCPUExceeded = "RequestsCPUExceeded"
+	// RequestsMemoryTooLow occurs when the memory request of a container is lower than the specified min memory request
+	RequestsMemoryTooLow = "RequestsMemoryTooLow"
+	// RequestsMemoryExceeded occurs when the memory request of a container is higher than the specified max memory request
+	RequestsMemoryExceeded = "RequestsMemoryExceeded"
+	// CPUOvercommitRatioExceeded occurs when the ratio of the CPU limit to the CPU request of a container is higher
+	// than the specified max ratio
+	CPUOvercommitRatioExceeded = "CPUOvercommitRatioExceeded"
+	// MemoryOvercommitRatioExceeded occurs when the ratio of the memory limit to the memory request of a container is
+	// higher than the specified max ratio
+	MemoryOvercommitRatioExceeded = "MemoryOvercommitRatioExceeded"
+	// QoSClassNotMet occurs when a container prevents its pod from having the specified QoS class
+	QoSClassNotMet = "QoSClassNotMet"
+	// MissingLimitRange occurs when there is no LimitRange in a namespace
+	MissingLimitRange = "MissingLimitRange"
+	// MissingResourceQuota occurs when there is no ResourceQuota in a namespace
+	MissingResourceQuota = "MissingResourceQuota"
 )
 
 // Limits implements Auditable
 type Limits struct {
-	maxCPU    k8sResource.Quantity
-	maxMemory k8sResource.Quantity
+	maxCPU                   k8sResource.Quantity
+	maxMemory                k8sResource.Quantity
+	maxSidecarCPU            k8sResource.Quantity
+	maxSidecarMemory         k8sResource.Quantity
+	maxEphemeralStorage      k8sResource.Quantity
+	minCPURequest            k8sResource.Quantity
+	maxCPURequest            k8sResource.Quantity
+	minMemoryRequest         k8sResource.Quantity
+	maxMemoryRequest         k8sResource.Quantity
+	requireRequests          bool
+	requireLimitRange        bool
+	requireResourceQuota     bool
+	qosClass                 v1.PodQOSClass
+	maxCPUOvercommitRatio    float64
+	maxMemoryOvercommitRatio float64
+
+	defaultLimitRangeLimits   v1.ResourceList
+	defaultLimitRangeRequests v1.ResourceList
 }
 
 func New(config Config) (*Limits, error) {
-	maxCPU, err := config.GetCPU()
-	if err != nil {
-		return nil, fmt.Errorf("error creating Limits auditor: %w", err)
+	limits := &Limits{}
+
+	for _, item := range []struct {
+		quantity *k8sResource.Quantity
+		get      func() (k8sResource.Quantity, error)
+	}{
+		{&limits.maxCPU, config.GetCPU},
+		{&limits.maxMemory, config.GetMemory},
+		{&limits.maxSidecarCPU, config.GetSidecarCPU},
+		{&limits.maxSidecarMemory, config.GetSidecarMemory},
+		{&limits.maxEphemeralStorage, config.GetEphemeralStorage},
+		{&limits.minCPURequest, config.GetMinCPURequest},
+		{&limits.maxCPURequest, config.GetMaxCPURequest},
+		{&limits.minMemoryRequest, config.GetMinMemoryRequest},
+		{&limits.maxMemoryRequest, config.GetMaxMemoryRequest},
+	} {
+		quantity, err := item.get()
+		if err != nil {
+			return nil, fmt.Errorf("error creating Limits auditor: %w", err)
+		}
+		*item.quantity = quantity
 	}
 
-	maxMemory, err := config.GetMemory()
-	if err != nil {
+	limits.requireRequests = config.GetRequireRequests()
+	limits.requireLimitRange = config.GetRequireLimitRange()
+	limits.requireResourceQuota = config.GetRequireResourceQuota()
+
+	var err error
+	if limits.qosClass, err = config.GetQoSClass(); err != nil {
+		return nil, fmt.Errorf("error creating Limits auditor: %w", err)
+	}
+	if limits.maxCPUOvercommitRatio, err = config.GetMaxCPUOvercommitRatio(); err != nil {
+		return nil, fmt.Errorf("error creating Limits auditor: %w", err)
+	}
+	if limits.maxMemoryOvercommitRatio, err = config.GetMaxMemoryOvercommitRatio(); err != nil {
+		return nil, fmt.Errorf("error creating Limits auditor: %w", err)
+	}
+	if limits.defaultLimitRangeLimits, limits.defaultLimitRangeRequests, err = config.GetDefaultLimitRange(); err != nil {
 		return nil, fmt.Errorf("error creating Limits auditor: %w", err)
 	}
 
-	return &Limits{
-		maxCPU:    maxCPU,
-		maxMemory: maxMemory,
-	}, nil
+	return limits, nil
 }
 
-// Audit checks that the container cpu and memory limits do not exceed specified limits
-func (limits *Limits) Audit(resource k8s.Resource, _ []k8s.Resource) ([]*kubeaudit.AuditResult, error) {
+// Audit checks that the container cpu and memory limits and requests are set and within the specified bounds, taking
+// the defaults of the namespace LimitRanges into account. Sidecar containers are checked against the sidecar max limits. If
+// required, it also checks that each namespace has a LimitRange and a ResourceQuota
+func (limits *Limits) Audit(resource k8s.Resource, resources []k8s.Resource) ([]*kubeaudit.AuditResult, error) {
+	if namespace, ok := resource.(*k8s.NamespaceV1); ok {
+		return limits.auditNamespace(namespace, resources), nil
+	}
+
 	var auditResults []*kubeaudit.AuditResult
 
-	for _, container := range k8s.GetContainers(resource) {
-		for _, auditResult := range limits.auditContainer(container) {
-			if auditResult != nil {
-				auditResults = append(auditResults, auditResult)
+	var limitRanges []*k8s.LimitRangeV1
+	if objectMeta := k8s.GetObjectMeta(resource); objectMeta != nil {
+		limitRanges = getLimitRanges(resources, objectMeta.GetNamespace())
+	}
+
+	containers := getResourceContainers(resource)
+	effectiveContainers := make([]*k8s.ContainerV1, len(containers))
+	appliedLimitRanges := make([][]string, len(containers))
+	for i, container := range containers {
+		effectiveContainers[i], appliedLimitRanges[i] = applyLimitRangeDefaults(container, limitRanges)
+	}
+	podQoSClass := getQoSClass(effectiveContainers)
+
+	for i, container := range effectiveContainers {
+		containerType, _ := k8s.GetContainerType(resource, container.Name)
+		containerResults := limits.auditContainer(container, containerType)
+		containerResults = append(containerResults, limits.auditEphemeralStorage(container)...)
+		containerResults = append(containerResults, limits.auditRequests(container)...)
+		containerResults = append(containerResults, limits.auditOvercommit(container)...)
+		containerResults = append(containerResults, limits.auditQoSClass(container, podQoSClass))
+		for _, auditResult := range containerResults {
+			if auditResult == nil {
+				continue
 			}
+			setFixContainer(auditResult, containers[i])
+			if len(appliedLimitRanges[i]) > 0 {
+				auditResult.Metadata[LimitRangeMetadataKey] = strings.Join(appliedLimitRanges[i], ", ")
+			}
+			auditResults = append(auditResults, auditResult)
 		}
 	}
 
 	return auditResults, nil
 }
 
-func (limits *Limits) auditContainer(container *k8s.ContainerV1) (auditResults []*kubeaudit.AuditResult) {
+// getResourceContainers returns the containers of a resource which can have resources. Ephemeral containers can't set
+// resources and aren't counted for the pod QoS class so they are left out
+func getResourceContainers(resource k8s.Resource) []*k8s.ContainerV1 {
+	var containers []*k8s.ContainerV1
+	for _, container := range k8s.GetContainers(resource) {
+		if containerType, _ := k8s.GetContainerType(resource, container.Name); containerType != k8s.ContainerTypeEphemeral {
+			containers = append(containers, container)
+		}
+	}
+	return containers
+}
+
+func (limits *Limits) auditContainer(container *k8s.ContainerV1, containerType k8s.ContainerType) (auditResults []*kubeaudit.AuditResult) {
+	maxCPU, maxMemory := limits.maxCPU, limits.maxMemory
+	if containerType == k8s.ContainerTypeSidecar {
+		maxCPU, maxMemory = limits.maxSidecarCPU, limits.maxSidecarMemory
+	}
+
 	if isLimitsNil(container) {
 		auditResult := &kubeaudit.AuditResult{
 			Auditor:  Name,
@@ -91,17 +217,16 @@ func (limits *Limits) auditContainer(container *k8s.ContainerV1) (auditResults [
 			},
 		}
 		auditResults = append(auditResults, auditResult)
-	} else if exceedsCPULimit(container, limits) {
-		maxCPU := limits.maxCPU.String()
+	} else if exceedsCPULimit(container, maxCPU) {
 		auditResult := &kubeaudit.AuditResult{
 			Auditor:  Name,
 			Rule:     LimitsCPUExceeded,
 			Severity: kubeaudit.Warn,
-			Message:  fmt.Sprintf("CPU limit exceeded. It is set to '%s' which exceeds the max CPU limit of '%s'.", cpu, maxCPU),
+			Message:  fmt.Sprintf("CPU limit exceeded. It is set to '%s' which exceeds the max CPU limit of '%s'.", cpu, maxCPU.String()),
 			Metadata: kubeaudit.Metadata{
 				"Container":         container.Name,
 				"ContainerCpuLimit": cpu,
-				"MaxCPU":            maxCPU,
+				"MaxCPU":            maxCPU.String(),
 			},
 		}
 		auditResults = append(auditResults, auditResult)
@@ -118,17 +243,16 @@ func (limits *Limits) auditContainer(container *k8s.ContainerV1) (auditResults [
 			},
 		}
 		auditResults = append(auditResults, auditResult)
-	} else if exceedsMemoryLimit(container, limits) {
-		maxMemory := limits.maxMemory.String()
+	} else if exceedsMemoryLimit(container, maxMemory) {
 		auditResult := &kubeaudit.AuditResult{
 			Auditor:  Name,
 			Rule:     LimitsMemoryExceeded,
 			Severity: kubeaudit.Warn,
-			Message:  fmt.Sprintf("Memory limit exceeded. It is set to '%s' which exceeds the max Memory limit of '%s'.", memory, maxMemory),
+			Message:  fmt.Sprintf("Memory limit exceeded. It is set to '%s' which exceeds the max Memory limit of '%s'.", memory, maxMemory.String()),
 			Metadata: kubeaudit.Metadata{
 				"Container":            container.Name,
 				"ContainerMemoryLimit": memory,
-				"MaxMemory":            maxMemory,
+				"MaxMemory":            maxMemory.String(),
 			},
 		}
 		auditResults = append(auditResults, auditResult)
@@ -137,18 +261,16 @@ func (limits *Limits) auditContainer(container *k8s.ContainerV1) (auditResults [
 	return
 }
 
-func exceedsCPULimit(container *k8s.ContainerV1, limits *Limits) bool {
+func exceedsCPULimit(container *k8s.ContainerV1, maxCPU k8sResource.Quantity) bool {
 	containerLimits := getLimits(container)
 	cpuLimit := containerLimits.Cpu().MilliValue()
-	maxCPU := limits.maxCPU.MilliValue()
-	return maxCPU > 0 && cpuLimit > maxCPU
+	return maxCPU.MilliValue() > 0 && cpuLimit > maxCPU.MilliValue()
 }
 
-func exceedsMemoryLimit(container *k8s.ContainerV1, limits *Limits) bool {
+func exceedsMemoryLimit(container *k8s.ContainerV1, maxMemory k8sResource.Quantity) bool {
 	containerLimits := getLimits(container)
 	memoryLimit := containerLimits.Memory().Value()
-	maxMemory := limits.maxMemory.Value()
-	return maxMemory > 0 && memoryLimit > maxMemory
+	return maxMemory.Value() > 0 && memoryLimit > maxMemory.Value()
 }
 
 func isLimitsNil(container *k8s.ContainerV1) bool {
@@ -174,3 +296,272 @@ func getLimits(container *k8s.ContainerV1) v1.ResourceList {
 
 	return container.Resources.Limits
 }
+
+func (limits *Limits) auditEphemeralStorage(container *k8s.ContainerV1) []*kubeaudit.AuditResult {
+	if limits.maxEphemeralStorage.IsZero() {
+		return nil
+	}
+
+	ephemeralStorage, ok := getLimits(container)[v1.ResourceEphemeralStorage]
+	if !ok || ephemeralStorage.IsZero() {
+		return []*kubeaudit.AuditResult{{
+			Auditor:  Name,
+			Rule:     LimitsEphemeralStorageNotSet,
+			Severity: kubeaudit.Warn,
+			Message:  "Resource ephemeral-storage limit not set.",
+			Metadata: kubeaudit.Metadata{
+				"Container":           container.Name,
+				"MaxEphemeralStorage": limits.maxEphemeralStorage.String(),
+			},
+		}}
+	}
+
+	if ephemeralStorage.Cmp(limits.maxEphemeralStorage) > 0 {
+		maxEphemeralStorage := limits.maxEphemeralStorage.String()
+		return []*kubeaudit.AuditResult{{
+			Auditor:  Name,
+			Rule:     LimitsEphemeralStorageExceeded,
+			Severity: kubeaudit.Warn,
+			Message:  fmt.Sprintf("Ephemeral-storage limit exceeded. It is set to '%s' which exceeds the max ephemeral-storage limit of '%s'.", ephemeralStorage.String(), maxEphemeralStorage),
+			Metadata: kubeaudit.Metadata{
+				"Container":                      container.Name,
+				"ContainerEphemeralStorageLimit": ephemeralStorage.String(),
+				"MaxEphemeralStorage":            maxEphemeralStorage,
+			},
+		}}
+	}
+
+	return nil
+}
+
+func (limits *Limits) auditRequests(container *k8s.ContainerV1) (auditResults []*kubeaudit.AuditResult) {
+	if limits.requireRequests && isRequestUnset(container, v1.ResourceCPU) && isRequestUnset(container, v1.ResourceMemory) {
+		return []*kubeaudit.AuditResult{{
+			Auditor:    Name,
+			Rule:       RequestsNotSet,
+			Severity:   kubeaudit.Warn,
+			Message:    "Resource requests not set.",
+			PendingFix: newFixBySettingRequestsFromLimits(container, v1.ResourceCPU, v1.ResourceMemory),
+			Metadata: kubeaudit.Metadata{
+				"Container": container.Name,
+			},
+		}}
+	}
+
+	cpuRequest, hasCPURequest := getRequest(container, v1.ResourceCPU)
+	memoryRequest, hasMemoryRequest := getRequest(container, v1.ResourceMemory)
+
+	for _, check := range []struct {
+		resource     v1.ResourceName
+		title        string
+		request      k8sResource.Quantity
+		hasRequest   bool
+		min, max     k8sResource.Quantity
+		notSetRule   string
+		tooLowRule   string
+		exceededRule string
+		requestKey   string
+	}{
+		{v1.ResourceCPU, "CPU", cpuRequest, hasCPURequest, limits.minCPURequest, limits.maxCPURequest, RequestsCPUNotSet, RequestsCPUTooLow, RequestsCPUExceeded, "ContainerCpuRequest"},
+		{v1.ResourceMemory, "Memory", memoryRequest, hasMemoryRequest, limits.minMemoryRequest, limits.maxMemoryRequest, RequestsMemoryNotSet, RequestsMemoryTooLow, RequestsMemoryExceeded, "ContainerMemoryRequest"},
+	} {
+		if limits.requireRequests && isRequestUnset(container, check.resource) {
+			auditResults = append(auditResults, &kubeaudit.AuditResult{
+				Auditor:    Name,
+				Rule:       check.notSetRule,
+				Severity:   kubeaudit.Warn,
+				Message:    fmt.Sprintf("Resource %s request not set.", check.title),
+				PendingFix: newFixBySettingRequestsFromLimits(container, check.resource),
+				Metadata: kubeaudit.Metadata{
+					"Container": container.Name,
+				},
+			})
+		}
+
+		if !check.hasRequest {
+			continue
+		}
+
+		request := check.request.String()
+
+		if !check.min.IsZero() && check.request.Cmp(check.min) < 0 {
+			min := check.min.String()
+			auditResults = append(auditResults, &kubeaudit.AuditResult{
+				Auditor:    Name,
+				Rule:       check.tooLowRule,
+				Severity:   kubeaudit.Warn,
+				Message:    fmt.Sprintf("%s request too low. It is set to '%s' which is lower than the min %s request of '%s'.", check.title, request, check.title, min),
+				PendingFix: newFixBySettingRequest(container, check.resource, check.min),
+				Metadata: kubeaudit.Metadata{
+					"Container":                              container.Name,
+					check.requestKey:                         request,
+					fmt.Sprintf("Min%sRequest", check.title): min,
+				},
+			})
+		} else if !check.max.IsZero() && check.request.Cmp(check.max) > 0 {
+			max := check.max.String()
+			auditResults = append(auditResults, &kubeaudit.AuditResult{
+				Auditor:    Name,
+				Rule:       check.exceededRule,
+				Severity:   kubeaudit.Warn,
+				Message:    fmt.Sprintf("%s request exceeded. It is set to '%s' which exceeds the max %s request of '%s'.", check.title, request, check.title, max),
+				PendingFix: newFixBySettingRequest(container, check.resource, check.max),
+				Metadata: kubeaudit.Metadata{
+					"Container":                              container.Name,
+					check.requestKey:                         request,
+					fmt.Sprintf("Max%sRequest", check.title): max,
+				},
+			})
+		}
+	}
+
+	return
+}
+
+func (limits *Limits) auditOvercommit(container *k8s.ContainerV1) (auditResults []*kubeaudit.AuditResult) {
+	for _, check := range []struct {
+		resource v1.ResourceName
+		title    string
+		maxRatio float64
+		rule     string
+	}{
+		{v1.ResourceCPU, "CPU", limits.maxCPUOvercommitRatio, CPUOvercommitRatioExceeded},
+		{v1.ResourceMemory, "Memory", limits.maxMemoryOvercommitRatio, MemoryOvercommitRatioExceeded},
+	} {
+		if check.maxRatio == 0 {
+			continue
+		}
+
+		limit, ok := getLimits(container)[check.resource]
+		if !ok || limit.IsZero() {
+			continue
+		}
+		request, ok := getRequest(container, check.resource)
+		if !ok || request.IsZero() {
+			continue
+		}
+
+		ratio := float64(limit.MilliValue()) / float64(request.MilliValue())
+		if ratio <= check.maxRatio {
+			continue
+		}
+
+		formattedRatio := strconv.FormatFloat(ratio, 'f', -1, 64)
+		formattedMaxRatio := strconv.FormatFloat(check.maxRatio, 'f', -1, 64)
+		auditResults = append(auditResults, &kubeaudit.AuditResult{
+			Auditor:    Name,
+			Rule:       check.rule,
+			Severity:   kubeaudit.Warn,
+			Message:    fmt.Sprintf("%s overcommit ratio exceeded. The limit '%s' is %s times the request '%s' which exceeds the max ratio of %s.", check.title, limit.String(), formattedRatio, request.String(), formattedMaxRatio),
+			PendingFix: newFixBySettingRequest(container, check.resource, divideQuantity(check.resource, limit, check.maxRatio)),
+			Metadata: kubeaudit.Metadata{
+				"Container": container.Name,
+				fmt.Sprintf("%sOvercommitRatio", check.title):    formattedRatio,
+				fmt.Sprintf("Max%sOvercommitRatio", check.title): formattedMaxRatio,
+			},
+		})
+	}
+
+	return
+}
+
+func (limits *Limits) auditQoSClass(container *k8s.ContainerV1, podQoSClass v1.PodQOSClass) *kubeaudit.AuditResult {
+	if limits.qosClass == "" || qosClassRank(podQoSClass) >= qosClassRank(limits.qosClass) {
+		return nil
+	}
+
+	// Only flag the containers which keep the pod from reaching the required QoS class
+	if qosClassRank(getQoSClass([]*k8s.ContainerV1{container})) >= qosClassRank(limits.qosClass) {
+		return nil
+	}
+
+	var pendingFix kubeaudit.PendingFix
+	if limits.qosClass == v1.PodQOSGuaranteed {
+		pendingFix = newFixBySettingRequestsFromLimits(container, v1.ResourceCPU, v1.ResourceMemory)
+		if fix, ok := pendingFix.(*fixBySettingRequests); ok && len(fix.requests) < 2 {
+			// Guaranteed requires both a CPU and a memory limit to copy requests from
+			pendingFix = nil
+		}
+	}
+
+	return &kubeaudit.AuditResult{
+		Auditor:    Name,
+		Rule:       QoSClassNotMet,
+		Severity:   kubeaudit.Warn,
+		Message:    fmt.Sprintf("Pod QoS class is '%s' which is lower than the required QoS class '%s'.", podQoSClass, limits.qosClass),
+		PendingFix: pendingFix,
+		Metadata: kubeaudit.Metadata{
+			"Container":        container.Name,
+			"QoSClass":         string(podQoSClass),
+			"RequiredQoSClass": string(limits.qosClass),
+		},
+	}
+}
+
+func isRequestUnset(container *k8s.ContainerV1, resource v1.ResourceName) bool {
+	request, ok := container.Resources.Requests[resource]
+	return !ok || request.IsZero()
+}
+
+// getRequest returns the effective request of the container for the resource. Kubernetes defaults an unset CPU or
+// memory request to the limit
+func getRequest(container *k8s.ContainerV1, resource v1.ResourceName) (k8sResource.Quantity, bool) {
+	if request, ok := container.Resources.Requests[resource]; ok && !request.IsZero() {
+		return request, true
+	}
+	if limit, ok := getLimits(container)[resource]; ok && !limit.IsZero() {
+		return limit, true
+	}
+	return k8sResource.Quantity{}, false
+}
+
+// getQoSClass computes the QoS class of a pod with the given containers
+// (see https://kubernetes.io/docs/tasks/configure-pod-container/quality-service-pod/)
+func getQoSClass(containers []*k8s.ContainerV1) v1.PodQOSClass {
+	bestEffort := true
+	guaranteed := true
+
+	for _, container := range containers {
+		for _, resource := range []v1.ResourceName{v1.ResourceCPU, v1.ResourceMemory} {
+			limit, hasLimit := getLimits(container)[resource]
+			hasLimit = hasLimit && !limit.IsZero()
+			request, hasRequest := getRequest(container, resource)
+
+			if hasLimit || hasRequest {
+				bestEffort = false
+			}
+			if !hasLimit || !hasRequest || request.Cmp(limit) != 0 {
+				guaranteed = false
+			}
+		}
+	}
+
+	switch {
+	case len(containers) == 0 || bestEffort:
+		return v1.PodQOSBestEffort
+	case guaranteed:
+		return v1.PodQOSGuaranteed
+	default:
+		return v1.PodQOSBurstable
+	}
+}
+
+func qosClassRank(qosClass v1.PodQOSClass) int {
+	switch qosClass {
+	case v1.PodQOSGuaranteed:
+		return 2
+	case v1.PodQOSBurstable:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// divideQuantity divides the quantity by the ratio, rounding up to the nearest millicore for CPU and the nearest byte
+// otherwise
+func divideQuantity(resource v1.ResourceName, quantity k8sResource.Quantity, ratio float64) k8sResource.Quantity {
+	if resource == v1.ResourceCPU {
+		return *k8sResource.NewMilliQuantity(int64(math.Ceil(float64(quantity.MilliValue())/ratio)), quantity.Format)
+	}
+	return *k8sResource.NewQuantity(int64(math.Ceil(float64(quantity.Value())/ratio)), quantity.Format)
+}
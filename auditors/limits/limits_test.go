@@ -7,6 +7,7 @@ import (
 
 	"github.com/Shopify/kubeaudit/internal/test"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 const fixtureDir = "fixtures"
@@ -14,27 +15,53 @@ const fixtureDir = "fixtures"
 func TestAuditLimits(t *testing.T) {
 	cases := []struct {
 		file           string
-		maxCPU         string
-		maxMemory      string
+		config         Config
 		expectedErrors []string
 	}{
-		{"resources-limit-nil.yml", "", "", []string{LimitsNotSet}},
-		{"resources-limit-no-cpu.yml", "", "", []string{LimitsCPUNotSet}},
-		{"resources-limit-no-memory.yml", "", "", []string{LimitsMemoryNotSet}},
-		{"resources-limit.yml", "", "", []string{}},
-		{"resources-limit.yml", "600m", "", []string{LimitsCPUExceeded}},
-		{"resources-limit.yml", "", "384", []string{LimitsMemoryExceeded}},
-		{"resources-limit.yml", "600m", "384", []string{LimitsCPUExceeded, LimitsMemoryExceeded}},
-		{"resources-limit.yml", "750m", "512Mi", []string{}},
+		{"resources-limit-nil.yml", Config{}, []string{LimitsNotSet}},
+		{"resources-limit-nil.yml", Config{RequireRequests: true}, []string{LimitsNotSet, RequestsNotSet}},
+		{"resources-limit-no-cpu.yml", Config{}, []string{LimitsCPUNotSet}},
+		{"resources-limit-no-cpu.yml", Config{RequireRequests: true}, []string{LimitsCPUNotSet, RequestsNotSet}},
+		{"resources-limit-no-memory.yml", Config{}, []string{LimitsMemoryNotSet}},
+		{"resources-limit-no-memory.yml", Config{RequireRequests: true}, []string{LimitsMemoryNotSet, RequestsNotSet}},
+		{"resources-limit.yml", Config{}, []string{}},
+		{"resources-limit.yml", Config{CPU: "600m"}, []string{LimitsCPUExceeded}},
+		{"resources-limit.yml", Config{Memory: "384"}, []string{LimitsMemoryExceeded}},
+		{"resources-limit.yml", Config{CPU: "600m", Memory: "384"}, []string{LimitsCPUExceeded, LimitsMemoryExceeded}},
+		{"resources-limit.yml", Config{CPU: "750m", Memory: "512Mi"}, []string{}},
+		{"resources-requests-nil.yml", Config{}, []string{}},
+		{"resources-requests-nil.yml", Config{RequireRequests: true}, []string{RequestsNotSet}},
+		{"resources-requests-no-memory.yml", Config{}, []string{}},
+		{"resources-requests-no-memory.yml", Config{RequireRequests: true}, []string{RequestsMemoryNotSet}},
+		{"resources-limit.yml", Config{MinCPURequest: "600m", MinMemoryRequest: "128Mi"}, []string{RequestsCPUTooLow}},
+		{"resources-limit.yml", Config{MinCPURequest: "250m", MinMemoryRequest: "384Mi"}, []string{RequestsMemoryTooLow}},
+		{"resources-limit.yml", Config{MaxCPURequest: "250m", MaxMemoryRequest: "384Mi"}, []string{RequestsCPUExceeded}},
+		{"resources-limit.yml", Config{MaxCPURequest: "500m", MaxMemoryRequest: "128Mi"}, []string{RequestsMemoryExceeded}},
+		{"resources-limit.yml", Config{MaxCPUOvercommitRatio: 1.2}, []string{CPUOvercommitRatioExceeded}},
+		{"resources-limit.yml", Config{MaxMemoryOvercommitRatio: 1.5}, []string{MemoryOvercommitRatioExceeded}},
+		{"resources-limit.yml", Config{MaxCPUOvercommitRatio: 1.5, MaxMemoryOvercommitRatio: 2}, []string{}},
+		{"resources-guaranteed.yml", Config{MaxCPUOvercommitRatio: 1, MaxMemoryOvercommitRatio: 1}, []string{}},
+		{"resources-limit.yml", Config{QoSClass: "Burstable"}, []string{}},
+		{"resources-limit.yml", Config{QoSClass: "Guaranteed"}, []string{QoSClassNotMet}},
+		{"resources-limit-nil.yml", Config{QoSClass: "Burstable"}, []string{LimitsNotSet, QoSClassNotMet}},
+		{"resources-guaranteed.yml", Config{QoSClass: "Guaranteed"}, []string{}},
+		{"resources-qos-multi-containers.yml", Config{QoSClass: "Guaranteed"}, []string{QoSClassNotMet}},
+		{"resources-limit.yml", Config{EphemeralStorage: "1Gi"}, []string{LimitsEphemeralStorageNotSet}},
+		{"resources-guaranteed.yml", Config{EphemeralStorage: "512Mi"}, []string{LimitsEphemeralStorageExceeded}},
+		{"resources-guaranteed.yml", Config{EphemeralStorage: "1Gi"}, []string{}},
+		{"resources-sidecar.yml", Config{CPU: "750m", Memory: "512Mi"}, []string{}},
+		{"resources-sidecar.yml", Config{CPU: "750m", Memory: "512Mi", Sidecar: SidecarConfig{CPU: "100m"}}, []string{LimitsCPUExceeded}},
+		{"resources-sidecar.yml", Config{CPU: "750m", Memory: "512Mi", Sidecar: SidecarConfig{Memory: "64Mi"}}, []string{LimitsMemoryExceeded}},
+		{"resources-sidecar.yml", Config{CPU: "500m", Sidecar: SidecarConfig{CPU: "250m"}}, []string{LimitsCPUExceeded}},
 	}
 
 	for i, tc := range cases {
 		// These lines are needed because of how scopes work with parallel tests (see https://gist.github.com/posener/92a55c4cd441fc5e5e85f27bca008721)
 		tc := tc
 		i := i
-		t.Run(fmt.Sprintf("%s %s %s", tc.file, tc.maxCPU, tc.maxMemory), func(t *testing.T) {
+		t.Run(fmt.Sprintf("%s %+v", tc.file, tc.config), func(t *testing.T) {
 			t.Parallel()
-			auditor, err := New(Config{CPU: tc.maxCPU, Memory: tc.maxMemory})
+			auditor, err := New(tc.config)
 			assert.Nil(t, err)
 			test.AuditManifest(t, fixtureDir, tc.file, auditor, tc.expectedErrors)
 			test.AuditLocal(t, fixtureDir, tc.file, auditor, fmt.Sprintf("%s%d", strings.Split(tc.file, ".")[0], i), tc.expectedErrors)
@@ -47,5 +74,87 @@ func TestAuditLimits(t *testing.T) {
 
 		_, err = New(Config{CPU: "", Memory: "badvalue"})
 		assert.NotNil(t, err)
+
+		_, err = New(Config{MinMemoryRequest: "badvalue"})
+		assert.NotNil(t, err)
+
+		_, err = New(Config{Sidecar: SidecarConfig{CPU: "badvalue"}})
+		assert.NotNil(t, err)
+
+		_, err = New(Config{QoSClass: "BestEffort"})
+		assert.NotNil(t, err)
+
+		_, err = New(Config{MaxCPUOvercommitRatio: 0.5})
+		assert.NotNil(t, err)
+
+		_, err = New(Config{DefaultLimitRange: DefaultLimitRangeConfig{CPU: "badvalue"}})
+		assert.NotNil(t, err)
+	})
+}
+
+func TestAuditLimitsSidecar(t *testing.T) {
+	cases := []struct {
+		config             Config
+		expectedContainers map[string]string
+	}{
+		{Config{CPU: "150m"}, map[string]string{"container": "regular", "sidecar": "sidecar"}},
+		{Config{CPU: "500m", Sidecar: SidecarConfig{CPU: "250m"}}, map[string]string{"container": "regular"}},
+		{Config{CPU: "750m", Sidecar: SidecarConfig{CPU: "150m"}}, map[string]string{"sidecar": "sidecar"}},
+	}
+
+	for _, tc := range cases {
+		auditor, err := New(tc.config)
+		require.NoError(t, err)
+		report := test.AuditManifest(t, fixtureDir, "resources-sidecar.yml", auditor, []string{LimitsCPUExceeded})
+
+		containers := map[string]string{}
+		for _, result := range report.Results() {
+			for _, auditResult := range result.GetAuditResults() {
+				containers[auditResult.Metadata["Container"]] = auditResult.Metadata["ContainerType"]
+			}
+		}
+		assert.Equal(t, tc.expectedContainers, containers)
+	}
+}
+
+func TestAuditLimitRanges(t *testing.T) {
+	cases := []struct {
+		file           string
+		config         Config
+		expectedErrors []string
+	}{
+		{"namespace-missing-limitrange-and-quota.yml", Config{}, []string{}},
+		{"namespace-missing-limitrange-and-quota.yml", Config{RequireLimitRange: true}, []string{MissingLimitRange}},
+		{"namespace-missing-limitrange-and-quota.yml", Config{RequireResourceQuota: true}, []string{MissingResourceQuota}},
+		{"namespace-missing-limitrange-and-quota.yml", Config{RequireLimitRange: true, RequireResourceQuota: true}, []string{MissingLimitRange, MissingResourceQuota}},
+		{"namespace-has-limitrange-and-quota.yml", Config{RequireLimitRange: true, RequireResourceQuota: true}, []string{}},
+		{"namespace-has-limitrange-and-quota.yml", Config{}, []string{}},
+		{"namespace-has-limitrange-and-quota.yml", Config{CPU: "400m"}, []string{LimitsCPUExceeded}},
+		{"namespace-has-limitrange-and-quota.yml", Config{QoSClass: "Guaranteed"}, []string{QoSClassNotMet}},
+		{"namespace-limitrange-defaults.yml", Config{}, []string{}},
+		{"namespace-limitrange-defaults.yml", Config{QoSClass: "Guaranteed"}, []string{}},
+		{"namespace-limitrange-defaults.yml", Config{MaxMemoryRequest: "256Mi"}, []string{RequestsMemoryExceeded}},
+	}
+
+	for _, tc := range cases {
+		// This line is needed because of how scopes work with parallel tests (see https://gist.github.com/posener/92a55c4cd441fc5e5e85f27bca008721)
+		tc := tc
+		t.Run(fmt.Sprintf("%s %+v", tc.file, tc.config), func(t *testing.T) {
+			auditor, err := New(tc.config)
+			assert.Nil(t, err)
+			test.AuditManifest(t, fixtureDir, tc.file, auditor, tc.expectedErrors)
+			test.AuditLocal(t, fixtureDir, tc.file, auditor, strings.Split(tc.file, ".")[0], tc.expectedErrors)
+		})
+	}
+
+	t.Run("LimitRange metadata", func(t *testing.T) {
+		auditor, err := New(Config{CPU: "400m"})
+		require.NoError(t, err)
+		report := test.AuditManifest(t, fixtureDir, "namespace-has-limitrange-and-quota.yml", auditor, []string{LimitsCPUExceeded})
+		for _, result := range report.Results() {
+			for _, auditResult := range result.GetAuditResults() {
+				assert.Equal(t, "limits", auditResult.Metadata[LimitRangeMetadataKey])
+			}
+		}
 	})
 }
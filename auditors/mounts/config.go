@@ -2,6 +2,8 @@ package mounts
 
 type Config struct {
 	SensitivePaths []string `yaml:"denyPathsList"`
+	// AllowedPaths are safe paths which are never reported, even if they are under a sensitive path
+	AllowedPaths []string `yaml:"allowPathsList"`
 }
 
 func (config *Config) GetSensitivePaths() []string {
@@ -10,3 +12,10 @@ func (config *Config) GetSensitivePaths() []string {
 	}
 	return config.SensitivePaths
 }
+
+func (config *Config) GetAllowedPaths() []string {
+	if config == nil {
+		return nil
+	}
+	return config.AllowedPaths
+}
@@ -2,6 +2,7 @@ package mounts
 
 import (
 	"fmt"
+	"path"
 
 	"github.com/Shopify/kubeaudit"
 	"github.com/Shopify/kubeaudit/pkg/k8s"
@@ -22,26 +23,37 @@ var DefaultSensitivePaths = []string{"/proc", "/var/run/docker.sock", "/", "/etc
 const overrideLabelPrefix = "allow-host-path-mount-"
 
 const (
-	MountNameMetadataKey     = "MountName"
-	MountPathMetadataKey     = "MountPath"
-	MountReadOnlyMetadataKey = "MountReadOnly"
-	MountVolumeNameKey       = "MountVolume"
-	MountVolumeHostPathKey   = "MountVolumeHostPath"
+	MatchedPatternMetadataKey = "MatchedPattern"
+	MountNameMetadataKey      = "MountName"
+	MountPathMetadataKey      = "MountPath"
+	MountReadOnlyMetadataKey  = "MountReadOnly"
+	MountVolumeNameKey        = "MountVolume"
+	MountVolumeHostPathKey    = "MountVolumeHostPath"
 )
 
 // SensitivePathMounts implements Auditable
 type SensitivePathMounts struct {
-	sensitivePaths map[string]bool
+	sensitivePaths []string
+	allowedPaths   []string
 }
 
-func New(config Config) *SensitivePathMounts {
-	paths := make(map[string]bool)
-	for _, path := range config.GetSensitivePaths() {
-		paths[path] = true
+// New creates a mounts auditor. Paths in the config can be glob patterns (eg. "/var/run/*.sock") and also match
+// the paths under them, except for "/" which only matches the filesystem root
+func New(config Config) (*SensitivePathMounts, error) {
+	sensitivePaths, err := normalizePatterns(config.GetSensitivePaths())
+	if err != nil {
+		return nil, fmt.Errorf("error parsing sensitive paths: %w", err)
 	}
-	return &SensitivePathMounts{
-		sensitivePaths: paths,
+
+	allowedPaths, err := normalizePatterns(config.GetAllowedPaths())
+	if err != nil {
+		return nil, fmt.Errorf("error parsing allowed paths: %w", err)
 	}
+
+	return &SensitivePathMounts{
+		sensitivePaths: sensitivePaths,
+		allowedPaths:   allowedPaths,
+	}, nil
 }
 
 // Audit checks that the container does not have any sensitive host path
@@ -53,7 +65,7 @@ func (sensitive *SensitivePathMounts) Audit(resource k8s.Resource, _ []k8s.Resou
 		return auditResults, nil
 	}
 
-	sensitiveVolumes := auditPodVolumes(spec, sensitive.sensitivePaths)
+	sensitiveVolumes := sensitive.auditPodVolumes(spec)
 
 	if len(sensitiveVolumes) == 0 {
 		return auditResults, nil
@@ -71,26 +83,37 @@ func (sensitive *SensitivePathMounts) Audit(resource k8s.Resource, _ []k8s.Resou
 	return auditResults, nil
 }
 
-func auditPodVolumes(podSpec *k8s.PodSpecV1, sensitivePaths map[string]bool) map[string]v1.Volume {
+// sensitiveVolume is a host path volume matching one of the sensitive path patterns
+type sensitiveVolume struct {
+	volume         v1.Volume
+	matchedPattern string
+}
+
+func (sensitive *SensitivePathMounts) auditPodVolumes(podSpec *k8s.PodSpecV1) map[string]sensitiveVolume {
 	if podSpec.Volumes == nil {
 		return nil
 	}
 
-	found := make(map[string]v1.Volume)
+	found := make(map[string]sensitiveVolume)
 	for _, volume := range podSpec.Volumes {
 		if volume.HostPath == nil {
 			continue
 		}
 
-		if _, ok := sensitivePaths[volume.HostPath.Path]; ok {
-			found[volume.Name] = volume
+		hostPath := path.Clean("/" + volume.HostPath.Path)
+		if matchPattern(sensitive.allowedPaths, hostPath) != "" {
+			continue
+		}
+
+		if pattern := matchPattern(sensitive.sensitivePaths, hostPath); pattern != "" {
+			found[volume.Name] = sensitiveVolume{volume: volume, matchedPattern: pattern}
 		}
 	}
 
 	return found
 }
 
-func auditContainer(container *k8s.ContainerV1, sensitiveVolumes map[string]v1.Volume) []*kubeaudit.AuditResult {
+func auditContainer(container *k8s.ContainerV1, sensitiveVolumes map[string]sensitiveVolume) []*kubeaudit.AuditResult {
 	if container.VolumeMounts == nil {
 		return nil
 	}
@@ -98,19 +121,28 @@ func auditContainer(container *k8s.ContainerV1, sensitiveVolumes map[string]v1.V
 	var auditResults []*kubeaudit.AuditResult
 
 	for _, mount := range container.VolumeMounts {
-		if volume, ok := sensitiveVolumes[mount.Name]; ok {
+		if sensitive, ok := sensitiveVolumes[mount.Name]; ok {
+			volume := sensitive.volume
+
+			// Read-only mounts can still leak secrets but can't be used to modify the host
+			severity := kubeaudit.Error
+			if mount.ReadOnly {
+				severity = kubeaudit.Warn
+			}
+
 			auditResults = append(auditResults, &kubeaudit.AuditResult{
 				Auditor:  Name,
 				Rule:     SensitivePathsMounted,
-				Severity: kubeaudit.Error,
+				Severity: severity,
 				Message:  fmt.Sprintf("Sensitive path mounted as volume: %s (hostPath: %s). It should be removed from the container's mounts list.", mount.Name, volume.HostPath.Path),
 				Metadata: kubeaudit.Metadata{
-					"Container":              container.Name,
-					MountNameMetadataKey:     mount.Name,
-					MountPathMetadataKey:     mount.MountPath,
-					MountReadOnlyMetadataKey: fmt.Sprintf("%t", mount.ReadOnly),
-					MountVolumeNameKey:       volume.Name,
-					MountVolumeHostPathKey:   volume.HostPath.Path,
+					"Container":               container.Name,
+					MatchedPatternMetadataKey: sensitive.matchedPattern,
+					MountNameMetadataKey:      mount.Name,
+					MountPathMetadataKey:      mount.MountPath,
+					MountReadOnlyMetadataKey:  fmt.Sprintf("%t", mount.ReadOnly),
+					MountVolumeNameKey:        volume.Name,
+					MountVolumeHostPathKey:    volume.HostPath.Path,
 				},
 			})
 		}
@@ -119,6 +151,40 @@ func auditContainer(container *k8s.ContainerV1, sensitiveVolumes map[string]v1.V
 	return auditResults
 }
 
+// normalizePatterns cleans the path patterns so they can be compared to cleaned host paths, and validates the glob
+// syntax
+func normalizePatterns(patterns []string) ([]string, error) {
+	normalized := make([]string, 0, len(patterns))
+	for _, pattern := range patterns {
+		pattern = path.Clean("/" + pattern)
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid path pattern %q: %w", pattern, err)
+		}
+		normalized = append(normalized, pattern)
+	}
+	return normalized, nil
+}
+
+// matchPattern returns the first pattern matching the cleaned host path or one of its parent directories, or an
+// empty string if none match. The root pattern "/" only matches the root itself, otherwise it would match every path
+func matchPattern(patterns []string, hostPath string) string {
+	for _, pattern := range patterns {
+		if pattern == "/" {
+			if hostPath == "/" {
+				return pattern
+			}
+			continue
+		}
+
+		for dir := hostPath; dir != "/"; dir = path.Dir(dir) {
+			if matched, _ := path.Match(pattern, dir); matched {
+				return pattern
+			}
+		}
+	}
+	return ""
+}
+
 func getOverrideLabel(mountName string) string {
 	return overrideLabelPrefix + mountName
 }
@@ -4,8 +4,11 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/Shopify/kubeaudit"
 	"github.com/Shopify/kubeaudit/internal/test"
 	"github.com/Shopify/kubeaudit/pkg/override"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 const fixtureDir = "fixtures"
@@ -14,21 +17,86 @@ func TestSensitivePathsMounted(t *testing.T) {
 	cases := []struct {
 		file           string
 		fixtureDir     string
+		config         Config
 		expectedErrors []string
 	}{
-		{"docker-sock-mounted.yml", fixtureDir, []string{SensitivePathsMounted}},
-		{"proc-mounted.yml", fixtureDir, []string{SensitivePathsMounted}},
-		{"proc-mounted-allowed.yml", fixtureDir, []string{override.GetOverriddenResultName(SensitivePathsMounted)}},
-		{"proc-mounted-allowed-multi-containers-multi-labels.yml", fixtureDir, []string{override.GetOverriddenResultName(SensitivePathsMounted)}},
-		{"proc-mounted-allowed-multi-containers-single-label.yml", fixtureDir, []string{SensitivePathsMounted, override.GetOverriddenResultName(SensitivePathsMounted)}},
+		{"docker-sock-mounted.yml", fixtureDir, Config{}, []string{SensitivePathsMounted}},
+		{"proc-mounted.yml", fixtureDir, Config{}, []string{SensitivePathsMounted}},
+		{"proc-mounted-allowed.yml", fixtureDir, Config{}, []string{override.GetOverriddenResultName(SensitivePathsMounted)}},
+		{"proc-mounted-allowed-multi-containers-multi-labels.yml", fixtureDir, Config{}, []string{override.GetOverriddenResultName(SensitivePathsMounted)}},
+		{"proc-mounted-allowed-multi-containers-single-label.yml", fixtureDir, Config{}, []string{SensitivePathsMounted, override.GetOverriddenResultName(SensitivePathsMounted)}},
+		{"etc-kubernetes-pki-mounted.yml", fixtureDir, Config{}, []string{SensitivePathsMounted}},
+		{"docker-sock-trailing-slash-mounted.yml", fixtureDir, Config{}, []string{SensitivePathsMounted}},
+		{"proc-subdirectory-mounted.yml", fixtureDir, Config{}, []string{SensitivePathsMounted}},
+		{"proc-mounted-read-only.yml", fixtureDir, Config{}, []string{SensitivePathsMounted}},
+		{"data-mounted.yml", fixtureDir, Config{}, []string{}},
+		{"containerd-sock-mounted.yml", fixtureDir, Config{}, []string{}},
+		{"containerd-sock-mounted.yml", fixtureDir, Config{SensitivePaths: []string{"/var/run/*.sock"}}, []string{SensitivePathsMounted}},
+		{"etc-ssl-mounted.yml", fixtureDir, Config{}, []string{SensitivePathsMounted}},
+		{"etc-ssl-mounted.yml", fixtureDir, Config{AllowedPaths: []string{"/etc/ssl/"}}, []string{}},
 	}
 
-	config := Config{}
+	for _, tc := range cases {
+		auditor, err := New(tc.config)
+		require.NoError(t, err)
+		t.Run(tc.file, func(t *testing.T) {
+			test.AuditManifest(t, tc.fixtureDir, tc.file, auditor, tc.expectedErrors)
+			test.AuditLocal(t, tc.fixtureDir, tc.file, auditor, strings.Split(tc.file, ".")[0], tc.expectedErrors)
+		})
+	}
+}
+
+func TestSensitivePathsMountedMetadata(t *testing.T) {
+	cases := []struct {
+		file             string
+		expectedSeverity kubeaudit.SeverityLevel
+		expectedPattern  string
+	}{
+		{"etc-kubernetes-pki-mounted.yml", kubeaudit.Error, "/etc"},
+		{"docker-sock-trailing-slash-mounted.yml", kubeaudit.Error, "/var/run/docker.sock"},
+		{"proc-mounted-read-only.yml", kubeaudit.Warn, "/proc"},
+	}
+
+	auditor, err := New(Config{})
+	require.NoError(t, err)
 
 	for _, tc := range cases {
 		t.Run(tc.file, func(t *testing.T) {
-			test.AuditManifest(t, tc.fixtureDir, tc.file, New(config), tc.expectedErrors)
-			test.AuditLocal(t, tc.fixtureDir, tc.file, New(config), strings.Split(tc.file, ".")[0], tc.expectedErrors)
+			report := test.AuditManifest(t, fixtureDir, tc.file, auditor, []string{SensitivePathsMounted})
+			results := report.Results()
+			require.Len(t, results, 1)
+			auditResult := results[0].GetAuditResults()[0]
+			assert.Equal(t, tc.expectedSeverity, auditResult.Severity)
+			assert.Equal(t, tc.expectedPattern, auditResult.Metadata[MatchedPatternMetadataKey])
 		})
 	}
 }
+
+func TestMatchPattern(t *testing.T) {
+	cases := []struct {
+		patterns        []string
+		hostPath        string
+		expectedPattern string
+	}{
+		{[]string{"/"}, "/", "/"},
+		{[]string{"/"}, "/data", ""},
+		{[]string{"/etc"}, "/etc", "/etc"},
+		{[]string{"/etc"}, "/etc/kubernetes/pki", "/etc"},
+		{[]string{"/etc"}, "/etcetera", ""},
+		{[]string{"/home/*/.ssh"}, "/home/admin/.ssh/id_rsa", "/home/*/.ssh"},
+		{[]string{"/var/run/*.sock"}, "/var/run/docker.sock", "/var/run/*.sock"},
+		{[]string{"/var/run/*.sock"}, "/var/run/docker", ""},
+	}
+
+	for _, tc := range cases {
+		assert.Equal(t, tc.expectedPattern, matchPattern(tc.patterns, tc.hostPath), "%v %s", tc.patterns, tc.hostPath)
+	}
+}
+
+func TestNewInvalidPattern(t *testing.T) {
+	_, err := New(Config{SensitivePaths: []string{"/var/run/[docker.sock"}})
+	assert.Error(t, err)
+
+	_, err = New(Config{AllowedPaths: []string{"/etc/[ssl"}})
+	assert.Error(t, err)
+}
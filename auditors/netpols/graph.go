@@ -0,0 +1,320 @@
+package netpols
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/Shopify/kubeaudit/pkg/k8s"
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+// AllIPs is the graph node for traffic to or from any IP address
+const AllIPs = "0.0.0.0/0"
+
+// IPBlockKind is the kind of the graph nodes which represent NetworkPolicy ipBlocks
+const IPBlockKind = "IPBlock"
+
+// Graph holds the network flows allowed by the NetworkPolicies between the audited workloads, and between the audited
+// workloads and ipBlocks
+type Graph struct {
+	Nodes []GraphNode `json:"nodes"`
+	Edges []GraphEdge `json:"edges"`
+}
+
+// GraphNode is a workload or an ipBlock
+type GraphNode struct {
+	ID        string `json:"id"`
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// GraphEdge is an allowed flow. Ports lists the allowed ports as protocol/port (eg. TCP/443), and is empty if all
+// ports are allowed
+type GraphEdge struct {
+	From  string   `json:"from"`
+	To    string   `json:"to"`
+	Ports []string `json:"ports,omitempty"`
+}
+
+// portSet is a set of protocol/port strings. A nil portSet allows all ports
+type portSet []string
+
+// NewGraph evaluates the NetworkPolicies in the resources against the workloads and namespaces in the resources and
+// returns the graph of allowed flows. Pods which aren't selected by any NetworkPolicy of a policy type allow all
+// traffic of that type
+func NewGraph(resources []k8s.Resource) *Graph {
+	n := newNetwork(resources)
+	nodes := map[string]GraphNode{}
+	edges := map[[2]string]portSet{}
+
+	addEdge := func(from, to string, ports portSet) {
+		key := [2]string{from, to}
+		existing, ok := edges[key]
+		if !ok {
+			edges[key] = ports
+			return
+		}
+		edges[key] = unionPorts(existing, ports)
+	}
+	addIPBlockNode := func(id string) {
+		nodes[id] = GraphNode{ID: id, Kind: IPBlockKind, Name: id}
+	}
+
+	for _, w := range n.workloads {
+		nodes[w.id()] = GraphNode{ID: w.id(), Kind: w.kind, Name: w.name, Namespace: w.namespace}
+
+		for _, flow := range n.getIPBlockFlows(w, networkingv1.PolicyTypeIngress) {
+			addIPBlockNode(flow.ipBlock)
+			addEdge(flow.ipBlock, w.id(), flow.ports)
+		}
+		for _, flow := range n.getIPBlockFlows(w, networkingv1.PolicyTypeEgress) {
+			addIPBlockNode(flow.ipBlock)
+			addEdge(w.id(), flow.ipBlock, flow.ports)
+		}
+	}
+
+	for _, src := range n.workloads {
+		for _, dst := range n.workloads {
+			if src.id() == dst.id() {
+				continue
+			}
+			egressAllowed, egressPorts := n.isAllowed(src, dst, networkingv1.PolicyTypeEgress)
+			if !egressAllowed {
+				continue
+			}
+			ingressAllowed, ingressPorts := n.isAllowed(dst, src, networkingv1.PolicyTypeIngress)
+			if !ingressAllowed {
+				continue
+			}
+			if ports, ok := intersectPorts(egressPorts, ingressPorts); ok {
+				addEdge(src.id(), dst.id(), ports)
+			}
+		}
+	}
+
+	graph := &Graph{Nodes: []GraphNode{}, Edges: []GraphEdge{}}
+	for _, node := range nodes {
+		graph.Nodes = append(graph.Nodes, node)
+	}
+	sort.Slice(graph.Nodes, func(i, j int) bool { return graph.Nodes[i].ID < graph.Nodes[j].ID })
+
+	for key, ports := range edges {
+		graph.Edges = append(graph.Edges, GraphEdge{From: key[0], To: key[1], Ports: ports})
+	}
+	sort.Slice(graph.Edges, func(i, j int) bool {
+		if graph.Edges[i].From != graph.Edges[j].From {
+			return graph.Edges[i].From < graph.Edges[j].From
+		}
+		return graph.Edges[i].To < graph.Edges[j].To
+	})
+
+	return graph
+}
+
+// WriteJSON writes the graph as JSON
+func (g *Graph) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(g)
+}
+
+// WriteDOT writes the graph in the Graphviz DOT format
+func (g *Graph) WriteDOT(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("digraph netpols {\n")
+	for _, node := range g.Nodes {
+		shape := "ellipse"
+		if node.Kind == IPBlockKind {
+			shape = "box"
+		}
+		fmt.Fprintf(&b, "  %q [label=%q, shape=%s];\n", node.ID, node.ID, shape)
+	}
+	for _, edge := range g.Edges {
+		label := "all ports"
+		if len(edge.Ports) > 0 {
+			label = strings.Join(edge.Ports, ", ")
+		}
+		fmt.Fprintf(&b, "  %q -> %q [label=%q];\n", edge.From, edge.To, label)
+	}
+	b.WriteString("}\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// isAllowed returns true if the policies of the given type which select the workload allow traffic with the peer, and
+// the ports the traffic is allowed on
+func (n *network) isAllowed(w workload, peer workload, policyType networkingv1.PolicyType) (bool, portSet) {
+	policies := n.getSelectingPolicies(w, policyType)
+	if len(policies) == 0 {
+		return true, nil
+	}
+
+	allowed := false
+	var allowedPorts portSet
+	for _, policy := range policies {
+		for _, rule := range getRules(policy, policyType) {
+			if !n.rulePeersMatch(rule.peers, policy.ObjectMeta.Namespace, peer) {
+				continue
+			}
+			ports := toPortSet(rule.ports)
+			if !allowed {
+				allowed, allowedPorts = true, ports
+			} else {
+				allowedPorts = unionPorts(allowedPorts, ports)
+			}
+		}
+	}
+
+	return allowed, allowedPorts
+}
+
+func (n *network) rulePeersMatch(peers []networkingv1.NetworkPolicyPeer, policyNamespace string, w workload) bool {
+	if len(peers) == 0 {
+		return true
+	}
+	for _, peer := range peers {
+		if n.peerMatchesWorkload(peer, policyNamespace, w) {
+			return true
+		}
+	}
+	return false
+}
+
+type ipBlockFlow struct {
+	ipBlock string
+	ports   portSet
+}
+
+// getIPBlockFlows returns the ipBlocks the workload can receive traffic from (for ingress) or send traffic to (for
+// egress)
+func (n *network) getIPBlockFlows(w workload, policyType networkingv1.PolicyType) []ipBlockFlow {
+	policies := n.getSelectingPolicies(w, policyType)
+	if len(policies) == 0 {
+		return []ipBlockFlow{{ipBlock: AllIPs}}
+	}
+
+	var flows []ipBlockFlow
+	for _, policy := range policies {
+		for _, rule := range getRules(policy, policyType) {
+			ports := toPortSet(rule.ports)
+			if len(rule.peers) == 0 {
+				flows = append(flows, ipBlockFlow{ipBlock: AllIPs, ports: ports})
+			}
+			for _, peer := range rule.peers {
+				if peer.IPBlock != nil {
+					flows = append(flows, ipBlockFlow{ipBlock: ipBlockID(peer.IPBlock), ports: ports})
+				}
+			}
+		}
+	}
+	return flows
+}
+
+func ipBlockID(ipBlock *networkingv1.IPBlock) string {
+	if len(ipBlock.Except) == 0 {
+		return ipBlock.CIDR
+	}
+	return fmt.Sprintf("%s except %s", ipBlock.CIDR, strings.Join(ipBlock.Except, ", "))
+}
+
+type rule struct {
+	peers []networkingv1.NetworkPolicyPeer
+	ports []networkingv1.NetworkPolicyPort
+}
+
+func getRules(policy *k8s.NetworkPolicyV1, policyType networkingv1.PolicyType) []rule {
+	var rules []rule
+	if policyType == networkingv1.PolicyTypeIngress {
+		for _, ingress := range policy.Spec.Ingress {
+			rules = append(rules, rule{peers: ingress.From, ports: ingress.Ports})
+		}
+		return rules
+	}
+	for _, egress := range policy.Spec.Egress {
+		rules = append(rules, rule{peers: egress.To, ports: egress.Ports})
+	}
+	return rules
+}
+
+func toPortSet(ports []networkingv1.NetworkPolicyPort) portSet {
+	if len(ports) == 0 {
+		return nil
+	}
+
+	set := portSet{}
+	for _, port := range ports {
+		protocol := "TCP"
+		if port.Protocol != nil {
+			protocol = string(*port.Protocol)
+		}
+
+		switch {
+		case port.Port == nil:
+			set = append(set, protocol+"/*")
+		case port.EndPort != nil:
+			set = append(set, fmt.Sprintf("%s/%s-%d", protocol, port.Port.String(), *port.EndPort))
+		default:
+			set = append(set, fmt.Sprintf("%s/%s", protocol, port.Port.String()))
+		}
+	}
+	return set
+}
+
+func unionPorts(a, b portSet) portSet {
+	if a == nil || b == nil {
+		return nil
+	}
+
+	seen := map[string]bool{}
+	union := portSet{}
+	for _, port := range append(append(portSet{}, a...), b...) {
+		if !seen[port] {
+			seen[port] = true
+			union = append(union, port)
+		}
+	}
+	sort.Strings(union)
+	return union
+}
+
+// intersectPorts returns the ports allowed by both port sets. ok is false if no ports are allowed by both
+func intersectPorts(a, b portSet) (ports portSet, ok bool) {
+	if a == nil {
+		return b, true
+	}
+	if b == nil {
+		return a, true
+	}
+
+	intersection := portSet{}
+	for _, portA := range a {
+		for _, portB := range b {
+			if port, ok := intersectPort(portA, portB); ok {
+				intersection = append(intersection, port)
+			}
+		}
+	}
+	intersection = unionPorts(intersection, portSet{})
+	return intersection, len(intersection) > 0
+}
+
+// intersectPort returns the narrower of two protocol/port strings if they overlap. Port ranges and named ports are
+// only matched exactly
+func intersectPort(a, b string) (string, bool) {
+	protocolA, portA, _ := strings.Cut(a, "/")
+	protocolB, portB, _ := strings.Cut(b, "/")
+	switch {
+	case protocolA != protocolB:
+		return "", false
+	case portA == "*":
+		return b, true
+	case portB == "*" || portA == portB:
+		return a, true
+	}
+	return "", false
+}
@@ -0,0 +1,73 @@
+package netpols
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Shopify/kubeaudit/internal/k8sinternal"
+	"github.com/Shopify/kubeaudit/pkg/k8s"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewGraph(t *testing.T) {
+	graph := NewGraph(decodeFixture(t, "reachability.yml"))
+
+	var nodes []string
+	for _, node := range graph.Nodes {
+		nodes = append(nodes, node.ID)
+	}
+	assert.Equal(t, []string{
+		AllIPs,
+		"backend/Deployment/api",
+		"backend/StatefulSet/db",
+		"frontend/Deployment/web",
+	}, nodes)
+
+	assert.Equal(t, []GraphEdge{
+		{From: AllIPs, To: "frontend/Deployment/web", Ports: []string{"TCP/443"}},
+		{From: "backend/Deployment/api", To: "backend/StatefulSet/db", Ports: []string{"TCP/5432"}},
+		{From: "frontend/Deployment/web", To: "backend/Deployment/api", Ports: []string{"TCP/8080"}},
+	}, graph.Edges)
+}
+
+func TestNewGraphUnrestricted(t *testing.T) {
+	graph := NewGraph(decodeFixture(t, "reachability-unrestricted.yml"))
+
+	assert.Equal(t, []GraphEdge{
+		{From: AllIPs, To: "reachability-unrestricted/Deployment/deployment"},
+		{From: "reachability-unrestricted/Deployment/deployment", To: AllIPs},
+	}, graph.Edges)
+}
+
+func TestGraphWriters(t *testing.T) {
+	graph := NewGraph(decodeFixture(t, "reachability.yml"))
+
+	var dot bytes.Buffer
+	require.NoError(t, graph.WriteDOT(&dot))
+	assert.Contains(t, dot.String(), "digraph netpols {\n")
+	assert.Contains(t, dot.String(), `"0.0.0.0/0" [label="0.0.0.0/0", shape=box];`)
+	assert.Contains(t, dot.String(), `"frontend/Deployment/web" -> "backend/Deployment/api" [label="TCP/8080"];`)
+
+	var out bytes.Buffer
+	require.NoError(t, graph.WriteJSON(&out))
+	var decoded Graph
+	require.NoError(t, json.Unmarshal(out.Bytes(), &decoded))
+	assert.Equal(t, *graph, decoded)
+}
+
+func decodeFixture(t *testing.T, fixture string) []k8s.Resource {
+	data, err := os.ReadFile(filepath.Join(fixtureDir, fixture))
+	require.NoError(t, err)
+
+	var resources []k8s.Resource
+	for _, document := range bytes.Split(data, []byte("\n---\n")) {
+		resource, err := k8sinternal.DecodeResource(document, nil)
+		require.NoError(t, err)
+		resources = append(resources, resource)
+	}
+	return resources
+}
@@ -2,6 +2,7 @@ package netpols
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/Shopify/kubeaudit"
 	"github.com/Shopify/kubeaudit/pkg/k8s"
@@ -40,8 +41,21 @@ func New() *DefaultDenyNetworkPolicies {
 	return &DefaultDenyNetworkPolicies{}
 }
 
-// Audit checks that each namespace resource has a default deny NetworkPolicy for all ingress and egress traffic
+// Audit checks that each namespace resource has a default deny NetworkPolicy for all ingress and egress traffic. It
+// also evaluates the NetworkPolicies against the audited pods to find workloads reachable from every namespace or IP
+// address, workloads with unrestricted egress and NetworkPolicies which don't select any pods
 func (a *DefaultDenyNetworkPolicies) Audit(resource k8s.Resource, resources []k8s.Resource) ([]*kubeaudit.AuditResult, error) {
+	if networkPolicy, ok := resource.(*k8s.NetworkPolicyV1); ok {
+		if auditResult := a.auditNetworkPolicySelectsPods(networkPolicy, newNetwork(resources)); auditResult != nil {
+			return []*kubeaudit.AuditResult{auditResult}, nil
+		}
+		return nil, nil
+	}
+
+	if w, ok := newWorkload(resource); ok {
+		return a.auditWorkload(w, newNetwork(resources)), nil
+	}
+
 	if !k8s.IsNamespaceV1(resource) {
 		return nil, nil
 	}
@@ -151,25 +165,37 @@ func auditNetworkPoliciesForDenyAll(resource k8s.Resource, resources []k8s.Resou
 	hasIngressOverride, ingressOverrideReason := override.GetResourceOverrideReason(resource, IngressOverrideLabel)
 	hasEgressOverride, egressOverrideReason := override.GetResourceOverrideReason(resource, EgressOverrideLabel)
 
+	auditResult := &kubeaudit.AuditResult{
+		Auditor:  Name,
+		Rule:     MissingDefaultDenyIngressAndEgressNetworkPolicy,
+		Severity: kubeaudit.Error,
+		Message:  "Namespace is missing a default deny ingress and egress NetworkPolicy.",
+		Metadata: kubeaudit.Metadata{
+			"Namespace": namespace,
+		},
+		PendingFix: &fixByAddingNetworkPolicy{
+			policyList: []string{"Ingress", "Egress"},
+			namespace:  namespace,
+		},
+	}
+
 	if !hasIngressOverride && !hasEgressOverride {
-		auditResult := &kubeaudit.AuditResult{
-			Auditor:  Name,
-			Rule:     MissingDefaultDenyIngressAndEgressNetworkPolicy,
-			Severity: kubeaudit.Error,
-			Message:  "Namespace is missing a default deny ingress and egress NetworkPolicy.",
-			Metadata: kubeaudit.Metadata{
-				"Namespace": namespace,
-			},
-			PendingFix: &fixByAddingNetworkPolicy{
-				policyList: []string{"Ingress", "Egress"},
-				namespace:  namespace,
-			},
+		// Overrides which are set but ignored (eg. expired) are added to the audit result so they can be listed
+		var ignoredKeys []string
+		for _, overrideLabel := range []string{IngressOverrideLabel, EgressOverrideLabel} {
+			if ignoredOverride := override.GetResourceOverride(resource, overrideLabel); ignoredOverride != nil {
+				auditResult = override.ApplyOverride(auditResult, Name, "", resource, overrideLabel)
+				ignoredKeys = append(ignoredKeys, ignoredOverride.Key)
+			}
+		}
+		if len(ignoredKeys) > 0 {
+			auditResult.Metadata[override.OverrideKeyMetadataKey] = strings.Join(ignoredKeys, ", ")
 		}
 		return []*kubeaudit.AuditResult{auditResult}
 	}
 
 	if hasIngressOverride && hasEgressOverride {
-		auditResult := &kubeaudit.AuditResult{
+		overriddenResult := &kubeaudit.AuditResult{
 			Auditor:  Name,
 			Rule:     override.GetOverriddenResultName(MissingDefaultDenyIngressAndEgressNetworkPolicy),
 			Severity: kubeaudit.Warn,
@@ -177,14 +203,19 @@ func auditNetworkPoliciesForDenyAll(resource k8s.Resource, resources []k8s.Resou
 			Metadata: kubeaudit.Metadata{
 				"Namespace":      namespace,
 				"OverrideReason": fmt.Sprintf("Ingress: %s, Egress: %s", ingressOverrideReason, egressOverrideReason),
+				override.OverrideKeyMetadataKey: strings.Join([]string{
+					override.GetResourceOverride(resource, IngressOverrideLabel).Key,
+					override.GetResourceOverride(resource, EgressOverrideLabel).Key,
+				}, ", "),
 			},
+			OverriddenResult: auditResult,
 		}
-		return []*kubeaudit.AuditResult{auditResult}
+		return []*kubeaudit.AuditResult{overriddenResult}
 	}
 
 	// At this point there is exactly one override label for either ingress or egress which means one needs to be
 	// fixed and the other is overridden
-	auditResult := &kubeaudit.AuditResult{
+	auditResult = &kubeaudit.AuditResult{
 		Auditor:  Name,
 		Rule:     MissingDefaultDenyIngressNetworkPolicy,
 		Severity: kubeaudit.Error,
@@ -0,0 +1,356 @@
+package netpols
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/Shopify/kubeaudit"
+	"github.com/Shopify/kubeaudit/pkg/k8s"
+	"github.com/Shopify/kubeaudit/pkg/override"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	// IngressAllowedFromAllNamespaces occurs when the pods of a workload accept ingress traffic from every namespace
+	IngressAllowedFromAllNamespaces = "IngressAllowedFromAllNamespaces"
+	// IngressAllowedFromInternet occurs when the pods of a workload accept ingress traffic from any IP address
+	IngressAllowedFromInternet = "IngressAllowedFromInternet"
+	// EgressUnrestricted occurs when the egress traffic of the pods of a workload isn't restricted by any network policy
+	EgressUnrestricted = "EgressUnrestricted"
+	// NetworkPolicySelectsNoPods occurs when the pod selector of a network policy doesn't match any of the audited pods
+	NetworkPolicySelectsNoPods = "NetworkPolicySelectsNoPods"
+)
+
+const (
+	AllNamespacesIngressOverrideLabel = "allow-ingress-from-all-namespaces"
+	InternetIngressOverrideLabel      = "allow-ingress-from-internet"
+	UnrestrictedEgressOverrideLabel   = "allow-unrestricted-egress"
+)
+
+// namespaceNameLabel is set on every namespace by the API server so policies can select namespaces by name
+const namespaceNameLabel = "kubernetes.io/metadata.name"
+
+// workload is a resource which creates pods, along with the labels of its pods
+type workload struct {
+	resource  k8s.Resource
+	kind      string
+	name      string
+	namespace string
+	labels    map[string]string
+}
+
+func (w workload) id() string {
+	return fmt.Sprintf("%s/%s/%s", w.namespace, w.kind, w.name)
+}
+
+// network holds the workloads, namespaces and network policies of the audited resources
+type network struct {
+	workloads       []workload
+	namespaceLabels map[string]map[string]string
+	policies        []*k8s.NetworkPolicyV1
+}
+
+func newNetwork(resources []k8s.Resource) *network {
+	n := &network{
+		namespaceLabels: map[string]map[string]string{},
+		policies:        getNetworkPolicies(resources, AllNamespaces),
+	}
+
+	for _, resource := range resources {
+		if namespace, ok := resource.(*k8s.NamespaceV1); ok {
+			namespaceLabels := map[string]string{namespaceNameLabel: namespace.ObjectMeta.Name}
+			for key, value := range namespace.ObjectMeta.Labels {
+				namespaceLabels[key] = value
+			}
+			n.namespaceLabels[namespace.ObjectMeta.Name] = namespaceLabels
+			continue
+		}
+
+		if w, ok := newWorkload(resource); ok {
+			n.workloads = append(n.workloads, w)
+		}
+	}
+
+	return n
+}
+
+func newWorkload(resource k8s.Resource) (workload, bool) {
+	if resource == nil || k8s.GetPodSpec(resource) == nil {
+		return workload{}, false
+	}
+
+	objectMeta := k8s.GetObjectMeta(resource)
+	if objectMeta == nil {
+		return workload{}, false
+	}
+
+	var podLabels map[string]string
+	if podObjectMeta := k8s.GetPodObjectMeta(resource); podObjectMeta != nil {
+		podLabels = podObjectMeta.GetLabels()
+	}
+
+	return workload{
+		resource:  resource,
+		kind:      resource.GetObjectKind().GroupVersionKind().Kind,
+		name:      objectMeta.GetName(),
+		namespace: objectMeta.GetNamespace(),
+		labels:    podLabels,
+	}, true
+}
+
+// getNamespaceLabels returns the labels of the namespace. Namespaces which aren't part of the audited resources only
+// have the name label
+func (n *network) getNamespaceLabels(namespace string) map[string]string {
+	if namespaceLabels, ok := n.namespaceLabels[namespace]; ok {
+		return namespaceLabels
+	}
+	return map[string]string{namespaceNameLabel: namespace}
+}
+
+// getSelectingPolicies returns the network policies of the given type which select the workload
+func (n *network) getSelectingPolicies(w workload, policyType networkingv1.PolicyType) []*k8s.NetworkPolicyV1 {
+	var policies []*k8s.NetworkPolicyV1
+	for _, policy := range n.policies {
+		if policy.ObjectMeta.Namespace == w.namespace && hasPolicyType(policy, policyType) && selectorMatches(&policy.Spec.PodSelector, w.labels) {
+			policies = append(policies, policy)
+		}
+	}
+	return policies
+}
+
+// peerMatchesWorkload returns true if the network policy peer of a policy in the given namespace matches the workload
+func (n *network) peerMatchesWorkload(peer networkingv1.NetworkPolicyPeer, policyNamespace string, w workload) bool {
+	if peer.IPBlock != nil {
+		return false
+	}
+
+	if peer.NamespaceSelector == nil {
+		if w.namespace != policyNamespace {
+			return false
+		}
+	} else if !selectorMatches(peer.NamespaceSelector, n.getNamespaceLabels(w.namespace)) {
+		return false
+	}
+
+	return peer.PodSelector == nil || selectorMatches(peer.PodSelector, w.labels)
+}
+
+// hasPolicyType returns true if the policy applies to the policy type. Policies without policy types apply to
+// ingress, and to egress if they have egress rules
+func hasPolicyType(policy *k8s.NetworkPolicyV1, policyType networkingv1.PolicyType) bool {
+	if len(policy.Spec.PolicyTypes) == 0 {
+		return policyType == networkingv1.PolicyTypeIngress || len(policy.Spec.Egress) > 0
+	}
+	return isNetworkPolicyType(policy, string(policyType))
+}
+
+func selectorMatches(selector *metav1.LabelSelector, objectLabels map[string]string) bool {
+	s, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return false
+	}
+	return s.Matches(labels.Set(objectLabels))
+}
+
+// isAllNamespacesPeer returns true if the peer matches every pod in every namespace
+func isAllNamespacesPeer(peer networkingv1.NetworkPolicyPeer) bool {
+	return peer.IPBlock == nil && peer.NamespaceSelector != nil && isEmptySelector(peer.NamespaceSelector) &&
+		(peer.PodSelector == nil || isEmptySelector(peer.PodSelector))
+}
+
+// privateCIDRs are the address ranges which aren't reachable from the internet
+var privateCIDRs = []string{
+	"10.0.0.0/8",
+	"100.64.0.0/10",
+	"127.0.0.0/8",
+	"169.254.0.0/16",
+	"172.16.0.0/12",
+	"192.168.0.0/16",
+	"::1/128",
+	"fc00::/7",
+	"fe80::/10",
+}
+
+// isInternetPeer returns true if the peer matches every IP address, except for any private addresses. An ipBlock which
+// excepts every public address doesn't match the internet
+func isInternetPeer(peer networkingv1.NetworkPolicyPeer) bool {
+	if peer.IPBlock == nil {
+		return false
+	}
+	_, ipNet, err := net.ParseCIDR(peer.IPBlock.CIDR)
+	if err != nil {
+		return false
+	}
+	if ones, _ := ipNet.Mask.Size(); ones != 0 {
+		return false
+	}
+
+	var excluded []*net.IPNet
+	for _, cidr := range append(append([]string{}, privateCIDRs...), peer.IPBlock.Except...) {
+		if _, exceptNet, err := net.ParseCIDR(cidr); err == nil {
+			excluded = append(excluded, exceptNet)
+		}
+	}
+	return !isCovered(ipNet, excluded)
+}
+
+// isCovered returns true if every address of the network is in one of the excluded networks
+func isCovered(ipNet *net.IPNet, excluded []*net.IPNet) bool {
+	ones, bits := ipNet.Mask.Size()
+	overlaps := false
+	for _, exclude := range excluded {
+		excludeOnes, excludeBits := exclude.Mask.Size()
+		if excludeBits != bits || !exclude.Contains(ipNet.IP) && !ipNet.Contains(exclude.IP) {
+			continue
+		}
+		if excludeOnes <= ones {
+			return true
+		}
+		overlaps = true
+	}
+	if !overlaps || ones == bits {
+		return false
+	}
+
+	// The network is partly excluded so each half of it is checked
+	mask := net.CIDRMask(ones+1, bits)
+	lower := &net.IPNet{IP: ipNet.IP.Mask(mask), Mask: mask}
+	upperIP := append(net.IP{}, lower.IP...)
+	upperIP[ones/8] |= 0x80 >> (ones % 8)
+	upper := &net.IPNet{IP: upperIP, Mask: mask}
+	return isCovered(lower, excluded) && isCovered(upper, excluded)
+}
+
+func isEmptySelector(selector *metav1.LabelSelector) bool {
+	return len(selector.MatchLabels) == 0 && len(selector.MatchExpressions) == 0
+}
+
+func (a *DefaultDenyNetworkPolicies) auditWorkload(w workload, n *network) []*kubeaudit.AuditResult {
+	var auditResults []*kubeaudit.AuditResult
+
+	// Pods which aren't selected by any policy of a type allow all traffic of that type
+	ingressPolicies := n.getSelectingPolicies(w, networkingv1.PolicyTypeIngress)
+	ingressUnrestricted := len(ingressPolicies) == 0
+	allNamespacesPolicy, internetPolicy := "", ""
+	for _, policy := range ingressPolicies {
+		for _, rule := range policy.Spec.Ingress {
+			if len(rule.From) == 0 {
+				allNamespacesPolicy = firstNonEmpty(allNamespacesPolicy, policy.ObjectMeta.Name)
+				internetPolicy = firstNonEmpty(internetPolicy, policy.ObjectMeta.Name)
+			}
+			for _, peer := range rule.From {
+				if isAllNamespacesPeer(peer) {
+					allNamespacesPolicy = firstNonEmpty(allNamespacesPolicy, policy.ObjectMeta.Name)
+				}
+				if isInternetPeer(peer) {
+					internetPolicy = firstNonEmpty(internetPolicy, policy.ObjectMeta.Name)
+				}
+			}
+		}
+	}
+
+	if allNamespacesPolicy != "" || ingressUnrestricted {
+		auditResult := &kubeaudit.AuditResult{
+			Auditor:  Name,
+			Rule:     IngressAllowedFromAllNamespaces,
+			Severity: kubeaudit.Warn,
+			Message:  fmt.Sprintf("%s %s accepts ingress traffic from pods in every namespace.", w.kind, w.name),
+			Metadata: workloadMetadata(w, allNamespacesPolicy),
+		}
+		auditResult = override.ApplyOverride(auditResult, Name, "", w.resource, AllNamespacesIngressOverrideLabel)
+		auditResults = append(auditResults, auditResult)
+	}
+
+	if internetPolicy != "" || ingressUnrestricted {
+		auditResult := &kubeaudit.AuditResult{
+			Auditor:  Name,
+			Rule:     IngressAllowedFromInternet,
+			Severity: kubeaudit.Warn,
+			Message:  fmt.Sprintf("%s %s accepts ingress traffic from any IP address.", w.kind, w.name),
+			Metadata: workloadMetadata(w, internetPolicy),
+		}
+		auditResult = override.ApplyOverride(auditResult, Name, "", w.resource, InternetIngressOverrideLabel)
+		auditResults = append(auditResults, auditResult)
+	}
+
+	egressPolicies := n.getSelectingPolicies(w, networkingv1.PolicyTypeEgress)
+	egressUnrestricted := len(egressPolicies) == 0
+	unrestrictedEgressPolicy := ""
+	for _, policy := range egressPolicies {
+		for _, rule := range policy.Spec.Egress {
+			if len(rule.To) == 0 && len(rule.Ports) == 0 {
+				unrestrictedEgressPolicy = firstNonEmpty(unrestrictedEgressPolicy, policy.ObjectMeta.Name)
+			}
+		}
+	}
+
+	if unrestrictedEgressPolicy != "" || egressUnrestricted {
+		auditResult := &kubeaudit.AuditResult{
+			Auditor:  Name,
+			Rule:     EgressUnrestricted,
+			Severity: kubeaudit.Warn,
+			Message:  fmt.Sprintf("%s %s can send egress traffic to any destination.", w.kind, w.name),
+			Metadata: workloadMetadata(w, unrestrictedEgressPolicy),
+		}
+		auditResult = override.ApplyOverride(auditResult, Name, "", w.resource, UnrestrictedEgressOverrideLabel)
+		auditResults = append(auditResults, auditResult)
+	}
+
+	return auditResults
+}
+
+// workloadMetadata returns the metadata of a reachability audit result. The policy is the name of the network policy
+// which allows the traffic, or empty if the workload isn't selected by any network policy
+func workloadMetadata(w workload, policy string) kubeaudit.Metadata {
+	metadata := kubeaudit.Metadata{"Namespace": w.namespace}
+	if policy != "" {
+		metadata["PolicyName"] = policy
+	}
+	return metadata
+}
+
+// auditNetworkPolicySelectsPods checks that a network policy with a pod selector selects at least one of the audited
+// pods in its namespace. Policies which select every pod in the namespace are left alone as they also apply to pods
+// created later, as are policies in namespaces without any audited pods
+func (a *DefaultDenyNetworkPolicies) auditNetworkPolicySelectsPods(policy *k8s.NetworkPolicyV1, n *network) *kubeaudit.AuditResult {
+	if isEmptySelector(&policy.Spec.PodSelector) {
+		return nil
+	}
+
+	hasWorkloads := false
+	for _, w := range n.workloads {
+		if w.namespace != policy.ObjectMeta.Namespace {
+			continue
+		}
+		if selectorMatches(&policy.Spec.PodSelector, w.labels) {
+			return nil
+		}
+		hasWorkloads = true
+	}
+	if !hasWorkloads {
+		return nil
+	}
+
+	return &kubeaudit.AuditResult{
+		Auditor:  Name,
+		Rule:     NetworkPolicySelectsNoPods,
+		Severity: kubeaudit.Warn,
+		Message:  fmt.Sprintf("NetworkPolicy %s doesn't select any pods. Its pod selector may be wrong.", policy.ObjectMeta.Name),
+		Metadata: kubeaudit.Metadata{
+			"Namespace":   policy.ObjectMeta.Namespace,
+			"PolicyName":  policy.ObjectMeta.Name,
+			"PodSelector": metav1.FormatLabelSelector(&policy.Spec.PodSelector),
+		},
+	}
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
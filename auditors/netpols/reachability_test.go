@@ -0,0 +1,44 @@
+package netpols
+
+import (
+	"testing"
+
+	"github.com/Shopify/kubeaudit/internal/test"
+	"github.com/Shopify/kubeaudit/pkg/override"
+)
+
+func TestAuditReachability(t *testing.T) {
+	cases := []struct {
+		file           string
+		expectedErrors []string
+	}{
+		{"reachability.yml", []string{IngressAllowedFromInternet, NetworkPolicySelectsNoPods}},
+		{"reachability-unrestricted.yml", []string{
+			MissingDefaultDenyIngressAndEgressNetworkPolicy,
+			IngressAllowedFromAllNamespaces,
+			IngressAllowedFromInternet,
+			EgressUnrestricted,
+		}},
+		{"reachability-no-policy.yml", []string{IngressAllowedFromAllNamespaces, IngressAllowedFromInternet, EgressUnrestricted}},
+		{"reachability-allow-all.yml", []string{
+			AllowAllIngressNetworkPolicyExists,
+			AllowAllEgressNetworkPolicyExists,
+			IngressAllowedFromAllNamespaces,
+			IngressAllowedFromInternet,
+			EgressUnrestricted,
+		}},
+		{"reachability-internet-except.yml", []string{IngressAllowedFromInternet}},
+		{"reachability-internet-except-all.yml", nil},
+		{"reachability-all-namespaces.yml", []string{IngressAllowedFromAllNamespaces}},
+		{"reachability-all-namespaces-allowed.yml", []string{override.GetOverriddenResultName(IngressAllowedFromAllNamespaces)}},
+	}
+
+	for _, tc := range cases {
+		// This line is needed because of how scopes work with parallel tests (see https://gist.github.com/posener/92a55c4cd441fc5e5e85f27bca008721)
+		tc := tc
+		t.Run(tc.file, func(t *testing.T) {
+			t.Parallel()
+			test.AuditManifest(t, fixtureDir, tc.file, New(), tc.expectedErrors)
+		})
+	}
+}
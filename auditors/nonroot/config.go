@@ -0,0 +1,137 @@
+package nonroot
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type Config struct {
+	// AllowedUIDs are the ranges of UIDs containers can run as (eg. "10000-65535" or "1000"). If it is empty, every
+	// non-root UID is allowed
+	AllowedUIDs []string `yaml:"allowedUIDs"`
+	// AllowedGIDs are the ranges of GIDs which can be used for runAsGroup, fsGroup and supplementalGroups. If it is
+	// empty, every non-root GID is allowed
+	AllowedGIDs []string `yaml:"allowedGIDs"`
+	// DefaultUID is the UID autofix sets runAsUser to. If it is 0, autofix doesn't set runAsUser
+	DefaultUID int64 `yaml:"defaultUID"`
+	// DefaultGID is the GID autofix sets runAsGroup and fsGroup to. If it is 0, autofix doesn't set them
+	DefaultGID int64 `yaml:"defaultGID"`
+	// OCILayoutDir is an OCI image layout directory holding the images of the containers. If it is set, the USER of
+	// the images is checked for containers which don't set runAsUser
+	OCILayoutDir string `yaml:"ociLayoutDir"`
+	// ImageConfigCache is a YAML or JSON file mapping image references to their OCI image config. It is used like
+	// OCILayoutDir, for the images which are not in the layout
+	ImageConfigCache string `yaml:"imageConfigCache"`
+	// ImagePlatform is the platform (eg. "linux/arm64") whose image is picked from multi-platform images in
+	// OCILayoutDir. Defaults to "linux/amd64"
+	ImagePlatform string `yaml:"imagePlatform"`
+}
+
+func (config *Config) GetAllowedUIDs() []string {
+	if config == nil {
+		return nil
+	}
+	return config.AllowedUIDs
+}
+
+func (config *Config) GetAllowedGIDs() []string {
+	if config == nil {
+		return nil
+	}
+	return config.AllowedGIDs
+}
+
+func (config *Config) GetDefaultUID() int64 {
+	if config == nil {
+		return 0
+	}
+	return config.DefaultUID
+}
+
+func (config *Config) GetDefaultGID() int64 {
+	if config == nil {
+		return 0
+	}
+	return config.DefaultGID
+}
+
+func (config *Config) GetOCILayoutDir() string {
+	if config == nil {
+		return ""
+	}
+	return config.OCILayoutDir
+}
+
+func (config *Config) GetImageConfigCache() string {
+	if config == nil {
+		return ""
+	}
+	return config.ImageConfigCache
+}
+
+func (config *Config) GetImagePlatform() string {
+	if config == nil {
+		return ""
+	}
+	return config.ImagePlatform
+}
+
+// idRange is an inclusive range of UIDs or GIDs
+type idRange struct {
+	min int64
+	max int64
+}
+
+// idRanges are the allowed UIDs or GIDs. An empty list allows every ID
+type idRanges []idRange
+
+func (ranges idRanges) contains(id int64) bool {
+	if len(ranges) == 0 {
+		return true
+	}
+	for _, r := range ranges {
+		if id >= r.min && id <= r.max {
+			return true
+		}
+	}
+	return false
+}
+
+func (ranges idRanges) String() string {
+	var rangeStrings []string
+	for _, r := range ranges {
+		if r.min == r.max {
+			rangeStrings = append(rangeStrings, strconv.FormatInt(r.min, 10))
+		} else {
+			rangeStrings = append(rangeStrings, fmt.Sprintf("%d-%d", r.min, r.max))
+		}
+	}
+	return strings.Join(rangeStrings, ", ")
+}
+
+// parseIDRanges parses ranges of the form "min-max", or a single ID
+func parseIDRanges(rangeStrings []string) (idRanges, error) {
+	ranges := idRanges{}
+	for _, rangeString := range rangeStrings {
+		minString, maxString, isRange := strings.Cut(strings.TrimSpace(rangeString), "-")
+		if !isRange {
+			maxString = minString
+		}
+
+		min, err := strconv.ParseInt(strings.TrimSpace(minString), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ID range %q: %w", rangeString, err)
+		}
+		max, err := strconv.ParseInt(strings.TrimSpace(maxString), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ID range %q: %w", rangeString, err)
+		}
+		if min < 0 || max < min {
+			return nil, fmt.Errorf("invalid ID range %q", rangeString)
+		}
+
+		ranges = append(ranges, idRange{min: min, max: max})
+	}
+	return ranges, nil
+}
@@ -8,9 +8,14 @@ import (
 
 type fixRunAsNonRoot struct {
 	container *k8s.ContainerV1
+	// defaultUID is set as the runAsUser of the container if it isn't 0
+	defaultUID int64
 }
 
 func (f *fixRunAsNonRoot) Plan() string {
+	if f.defaultUID != 0 {
+		return fmt.Sprintf("Set runAsNonRoot to 'true' and runAsUser to '%d' in container SecurityContext for container %s", f.defaultUID, f.container.Name)
+	}
 	return fmt.Sprintf("Set runAsNonRoot to 'true' in container SecurityContext for container %s", f.container.Name)
 }
 
@@ -19,10 +24,92 @@ func (f *fixRunAsNonRoot) Apply(resource k8s.Resource) []k8s.Resource {
 		f.container.SecurityContext = &k8s.SecurityContextV1{}
 	}
 
-	if f.container.SecurityContext.RunAsUser != nil && *f.container.SecurityContext.RunAsUser == 0 {
+	if f.defaultUID != 0 {
+		uid := f.defaultUID
+		f.container.SecurityContext.RunAsUser = &uid
+	} else if f.container.SecurityContext.RunAsUser != nil && *f.container.SecurityContext.RunAsUser == 0 {
 		f.container.SecurityContext.RunAsUser = nil
 	}
 
 	f.container.SecurityContext.RunAsNonRoot = k8s.NewTrue()
 	return nil
 }
+
+type fixRunAsUser struct {
+	container *k8s.ContainerV1
+	uid       int64
+}
+
+func (f *fixRunAsUser) Plan() string {
+	return fmt.Sprintf("Set runAsUser to '%d' in container SecurityContext for container %s", f.uid, f.container.Name)
+}
+
+func (f *fixRunAsUser) Apply(resource k8s.Resource) []k8s.Resource {
+	if f.container.SecurityContext == nil {
+		f.container.SecurityContext = &k8s.SecurityContextV1{}
+	}
+	uid := f.uid
+	f.container.SecurityContext.RunAsUser = &uid
+	return nil
+}
+
+type fixRunAsGroup struct {
+	container *k8s.ContainerV1
+	gid       int64
+}
+
+func (f *fixRunAsGroup) Plan() string {
+	return fmt.Sprintf("Set runAsGroup to '%d' in container SecurityContext for container %s", f.gid, f.container.Name)
+}
+
+func (f *fixRunAsGroup) Apply(resource k8s.Resource) []k8s.Resource {
+	if f.container.SecurityContext == nil {
+		f.container.SecurityContext = &k8s.SecurityContextV1{}
+	}
+	gid := f.gid
+	f.container.SecurityContext.RunAsGroup = &gid
+	return nil
+}
+
+type fixFSGroup struct {
+	gid int64
+}
+
+func (f *fixFSGroup) Plan() string {
+	return fmt.Sprintf("Set fsGroup to '%d' in PodSecurityContext", f.gid)
+}
+
+func (f *fixFSGroup) Apply(resource k8s.Resource) []k8s.Resource {
+	podSpec := k8s.GetPodSpec(resource)
+	if podSpec.SecurityContext == nil {
+		podSpec.SecurityContext = &k8s.PodSecurityContextV1{}
+	}
+	gid := f.gid
+	podSpec.SecurityContext.FSGroup = &gid
+	return nil
+}
+
+// fixSupplementalGroups removes the root group and the GIDs outside the allowed GID ranges from supplementalGroups
+type fixSupplementalGroups struct {
+	allowedGIDs idRanges
+}
+
+func (f *fixSupplementalGroups) Plan() string {
+	return "Remove GID 0 and the GIDs outside the allowed GID ranges from supplementalGroups in PodSecurityContext"
+}
+
+func (f *fixSupplementalGroups) Apply(resource k8s.Resource) []k8s.Resource {
+	podSpec := k8s.GetPodSpec(resource)
+	if podSpec.SecurityContext == nil {
+		return nil
+	}
+
+	var supplementalGroups []int64
+	for _, gid := range podSpec.SecurityContext.SupplementalGroups {
+		if gid != 0 && f.allowedGIDs.contains(gid) {
+			supplementalGroups = append(supplementalGroups, gid)
+		}
+	}
+	podSpec.SecurityContext.SupplementalGroups = supplementalGroups
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/Shopify/kubeaudit/internal/test"
 	"github.com/Shopify/kubeaudit/pkg/k8s"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestFixRunAsNonRoot(t *testing.T) {
@@ -52,7 +53,7 @@ func TestFixRunAsNonRoot(t *testing.T) {
 
 	for _, tc := range cases {
 		t.Run(tc.file, func(t *testing.T) {
-			resources, _ := test.FixSetup(t, tc.fixtureDir, tc.file, New())
+			resources, _ := test.FixSetup(t, tc.fixtureDir, tc.file, newAuditor(t, Config{}))
 			for _, resource := range resources {
 				containers := k8s.GetContainers(resource)
 				for _, container := range containers {
@@ -72,7 +73,7 @@ func TestFixRunAsNonRoot(t *testing.T) {
 	}
 	for _, file := range files {
 		t.Run(file, func(t *testing.T) {
-			resources, _ := test.FixSetup(t, fixtureDir, file, New())
+			resources, _ := test.FixSetup(t, fixtureDir, file, newAuditor(t, Config{}))
 			for _, resource := range resources {
 				containers := k8s.GetContainers(resource)
 				for _, container := range containers {
@@ -87,3 +88,91 @@ func TestFixRunAsNonRoot(t *testing.T) {
 		})
 	}
 }
+
+func TestFixGroups(t *testing.T) {
+	cases := []struct {
+		file               string
+		config             Config
+		expectedRunAsGroup *int64
+		expectedFSGroup    *int64
+	}{
+		{"run-as-group-0.yml", Config{}, newInt64(0), nil},
+		{"run-as-group-0.yml", Config{DefaultGID: 1000}, newInt64(1000), nil},
+		{"run-as-group-0-allowed.yml", Config{DefaultGID: 1000}, newInt64(0), nil},
+		{"run-as-group-psc-0.yml", Config{DefaultGID: 1000}, newInt64(1000), nil},
+		{"fs-group-0.yml", Config{}, nil, newInt64(0)},
+		{"fs-group-0.yml", Config{DefaultGID: 1000}, nil, newInt64(1000)},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.file, func(t *testing.T) {
+			resources, _ := test.FixSetup(t, fixtureDir, tc.file, newAuditor(t, tc.config))
+			require.Len(t, resources, 1)
+			podSpec := k8s.GetPodSpec(resources[0])
+			assert.Equal(t, tc.expectedFSGroup, podSpec.SecurityContext.FSGroup)
+			for _, container := range k8s.GetContainers(resources[0]) {
+				if tc.expectedRunAsGroup == nil {
+					assert.True(t, container.SecurityContext == nil || container.SecurityContext.RunAsGroup == nil)
+				} else {
+					assert.Equal(t, tc.expectedRunAsGroup, container.SecurityContext.RunAsGroup)
+				}
+			}
+		})
+	}
+
+	resources, _ := test.FixSetup(t, fixtureDir, "supplemental-groups-0.yml", newAuditor(t, Config{}))
+	require.Len(t, resources, 1)
+	assert.Equal(t, []int64{1000}, k8s.GetPodSpec(resources[0]).SecurityContext.SupplementalGroups)
+}
+
+func TestFixDefaultIDs(t *testing.T) {
+	auditor := newAuditor(t, Config{
+		AllowedUIDs: []string{"10000-65535"},
+		AllowedGIDs: []string{"10000-65535"},
+		DefaultUID:  10000,
+		DefaultGID:  10000,
+	})
+
+	resources, report := test.FixSetup(t, fixtureDir, "ids-outside-allowed-range.yml", auditor)
+	require.Len(t, resources, 1)
+	podSpec := k8s.GetPodSpec(resources[0])
+	assert.Equal(t, newInt64(10000), podSpec.SecurityContext.FSGroup)
+	assert.Empty(t, podSpec.SecurityContext.SupplementalGroups)
+	for _, container := range k8s.GetContainers(resources[0]) {
+		assert.Equal(t, newInt64(10000), container.SecurityContext.RunAsUser)
+		assert.Equal(t, newInt64(10000), container.SecurityContext.RunAsGroup)
+	}
+	assert.Empty(t, report.Results())
+
+	resources, _ = test.FixSetup(t, fixtureDir, "run-as-user-0.yml", auditor)
+	for _, container := range k8s.GetContainers(resources[0]) {
+		assert.Equal(t, newInt64(10000), container.SecurityContext.RunAsUser)
+		assert.True(t, *container.SecurityContext.RunAsNonRoot)
+	}
+}
+
+func TestFixImageUser(t *testing.T) {
+	auditor := newAuditor(t, Config{ImageConfigCache: "fixtures/images/image-config-cache.yml", DefaultUID: 10000})
+
+	resources, report := test.FixSetup(t, fixtureDir, "image-user.yml", auditor)
+	require.Len(t, resources, 1)
+	for _, container := range k8s.GetContainers(resources[0]) {
+		switch container.Name {
+		case "root-image", "named-image", "run-as-user":
+			assert.Equal(t, newInt64(10000), container.SecurityContext.RunAsUser)
+		default:
+			assert.Nil(t, container.SecurityContext)
+		}
+	}
+	assert.Empty(t, report.Results())
+}
+
+func newAuditor(t *testing.T, config Config) *RunAsNonRoot {
+	auditor, err := NewWithConfig(config)
+	require.NoError(t, err)
+	return auditor
+}
+
+func newInt64(value int64) *int64 {
+	return &value
+}
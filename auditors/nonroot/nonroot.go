@@ -1,9 +1,15 @@
 package nonroot
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
+
 	"github.com/Shopify/kubeaudit"
+	"github.com/Shopify/kubeaudit/pkg/imageconfig"
 	"github.com/Shopify/kubeaudit/pkg/k8s"
 	"github.com/Shopify/kubeaudit/pkg/override"
+	log "github.com/sirupsen/logrus"
 )
 
 const Name = "nonroot"
@@ -21,38 +27,239 @@ const (
 	// RunAsNonRootPSCFalseCSCNil occurs when runAsNonRoot is not set in the container SecurityContext and is set to
 	// false in the PodSecurityContext
 	RunAsNonRootPSCFalseCSCNil = "RunAsNonRootPSCFalseCSCNil"
+	// RunAsUserOutsideAllowedRange occurs when runAsUser is set to a UID outside the allowed UID ranges
+	RunAsUserOutsideAllowedRange = "RunAsUserOutsideAllowedRange"
+	// RunAsGroupCSCRoot occurs when runAsGroup is set to 0 in the container SecurityContext
+	RunAsGroupCSCRoot = "RunAsGroupCSCRoot"
+	// RunAsGroupPSCRoot occurs when runAsGroup is set to 0 in the pod SecurityContext
+	RunAsGroupPSCRoot = "RunAsGroupPSCRoot"
+	// RunAsGroupOutsideAllowedRange occurs when runAsGroup is set to a GID outside the allowed GID ranges
+	RunAsGroupOutsideAllowedRange = "RunAsGroupOutsideAllowedRange"
+	// FSGroupRoot occurs when fsGroup is set to 0 in the pod SecurityContext
+	FSGroupRoot = "FSGroupRoot"
+	// FSGroupOutsideAllowedRange occurs when fsGroup is set to a GID outside the allowed GID ranges
+	FSGroupOutsideAllowedRange = "FSGroupOutsideAllowedRange"
+	// SupplementalGroupsRoot occurs when supplementalGroups contains 0 in the pod SecurityContext
+	SupplementalGroupsRoot = "SupplementalGroupsRoot"
+	// SupplementalGroupsOutsideAllowedRange occurs when supplementalGroups contains a GID outside the allowed GID ranges
+	SupplementalGroupsOutsideAllowedRange = "SupplementalGroupsOutsideAllowedRange"
+	// RunAsNonRootImageUserRoot occurs when runAsNonRoot is true and runAsUser is not set, but the USER of the image is
+	// root. The kubelet refuses to start the container
+	RunAsNonRootImageUserRoot = "RunAsNonRootImageUserRoot"
+	// RunAsNonRootImageUserNonNumeric occurs when runAsNonRoot is true and runAsUser is not set, but the USER of the
+	// image is a username. The kubelet refuses to start the container since it can't verify the user isn't root
+	RunAsNonRootImageUserNonNumeric = "RunAsNonRootImageUserNonNumeric"
 )
 
-const OverrideLabel = "allow-run-as-root"
+// ImageUserMetadataKey is the audit result metadata key holding the USER of the container image
+const ImageUserMetadataKey = "ImageUser"
+
+const (
+	OverrideLabel = "allow-run-as-root"
+	// GroupOverrideLabel overrides the runAsGroup, fsGroup and supplementalGroups rules
+	GroupOverrideLabel = "allow-run-as-root-group"
+)
 
 // RunAsNonRoot implements Auditable
-type RunAsNonRoot struct{}
+type RunAsNonRoot struct {
+	allowedUIDs idRanges
+	allowedGIDs idRanges
+	defaultUID  int64
+	defaultGID  int64
+	// images looks up the USER of container images. It is nil if no image source is configured
+	images imageconfig.Source
+}
 
+// New returns a RunAsNonRoot auditor which allows every UID and GID other than root and doesn't look up image users
 func New() *RunAsNonRoot {
 	return &RunAsNonRoot{}
 }
 
-// Audit checks that runAsNonRoot is set to true in every container's security context
+// NewWithConfig returns a RunAsNonRoot auditor which checks the allowed UID and GID ranges of the config and looks up
+// the USER of container images in the image sources of the config, if any
+func NewWithConfig(config Config) (*RunAsNonRoot, error) {
+	allowedUIDs, err := parseIDRanges(config.GetAllowedUIDs())
+	if err != nil {
+		return nil, fmt.Errorf("error parsing allowed UIDs: %w", err)
+	}
+
+	allowedGIDs, err := parseIDRanges(config.GetAllowedGIDs())
+	if err != nil {
+		return nil, fmt.Errorf("error parsing allowed GIDs: %w", err)
+	}
+
+	defaultUID := config.GetDefaultUID()
+	if defaultUID < 0 || (defaultUID != 0 && !allowedUIDs.contains(defaultUID)) {
+		return nil, fmt.Errorf("default UID %d is not an allowed UID", defaultUID)
+	}
+
+	defaultGID := config.GetDefaultGID()
+	if defaultGID < 0 || (defaultGID != 0 && !allowedGIDs.contains(defaultGID)) {
+		return nil, fmt.Errorf("default GID %d is not an allowed GID", defaultGID)
+	}
+
+	images, err := newImageSource(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RunAsNonRoot{
+		allowedUIDs: allowedUIDs,
+		allowedGIDs: allowedGIDs,
+		defaultUID:  defaultUID,
+		defaultGID:  defaultGID,
+		images:      images,
+	}, nil
+}
+
+func newImageSource(config Config) (imageconfig.Source, error) {
+	var sources imageconfig.Sources
+
+	if dir := config.GetOCILayoutDir(); dir != "" {
+		layout, err := imageconfig.NewOCILayout(dir, config.GetImagePlatform())
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, layout)
+	}
+
+	if file := config.GetImageConfigCache(); file != "" {
+		cache, err := imageconfig.NewCache(file)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, cache)
+	}
+
+	if len(sources) == 0 {
+		return nil, nil
+	}
+	return sources, nil
+}
+
+// Audit checks that runAsNonRoot is set to true in every container's security context, and that the containers don't
+// run with the root group or with UIDs and GIDs outside the allowed ranges
 func (a *RunAsNonRoot) Audit(resource k8s.Resource, _ []k8s.Resource) ([]*kubeaudit.AuditResult, error) {
 	var auditResults []*kubeaudit.AuditResult
 
+	podSpec := k8s.GetPodSpec(resource)
+	if podSpec == nil {
+		return auditResults, nil
+	}
+
+	// fsGroup and supplementalGroups apply to every container of the pod, so they are audited once per pod
+	podGroupAuditResults := a.auditPodGroups(podSpec)
+	hasContainerGroupAuditResults := false
+
 	for _, container := range k8s.GetContainers(resource) {
-		auditResult := auditContainer(container, resource)
+		imageConfig := a.getImageConfig(container)
+
+		auditResult := a.auditContainer(container, podSpec, imageConfig)
 		auditResult = override.ApplyOverride(auditResult, Name, container.Name, resource, OverrideLabel)
 		if auditResult != nil {
 			auditResults = append(auditResults, auditResult)
 		}
+
+		// The pod group override label also overrides fsGroup and supplementalGroups, so it isn't redundant for a
+		// container if they are reported
+		auditResult = a.auditRunAsGroup(container, podSpec)
+		if auditResult != nil || len(podGroupAuditResults) == 0 {
+			auditResult = override.ApplyOverride(auditResult, Name, container.Name, resource, GroupOverrideLabel)
+		}
+		if auditResult != nil {
+			auditResults = append(auditResults, auditResult)
+			hasContainerGroupAuditResults = true
+		}
+	}
+
+	// The pod group override label is only redundant if no container is reported for runAsGroup either
+	if len(podGroupAuditResults) > 0 || !hasContainerGroupAuditResults {
+		auditResults = append(auditResults, override.ApplyOverrides(podGroupAuditResults, Name, "", resource, GroupOverrideLabel)...)
 	}
 
 	return auditResults, nil
 }
 
-func auditContainer(container *k8s.ContainerV1, resource k8s.Resource) *kubeaudit.AuditResult {
-	podSpec := k8s.GetPodSpec(resource)
-	if podSpec == nil {
+// getImageConfig returns the config of the container image, or nil if it is unknown. Images whose config can't be read
+// are logged and treated as unknown so the rest of the audit isn't affected
+func (a *RunAsNonRoot) getImageConfig(container *k8s.ContainerV1) *imageconfig.Config {
+	if a.images == nil || container.Image == "" {
+		return nil
+	}
+	imageConfig, err := a.images.Get(container.Image)
+	if err != nil {
+		log.WithError(err).Warnf("Unable to look up the USER of image %s, it is not checked", container.Image)
+		return nil
+	}
+	return imageConfig
+}
+
+// auditContainer checks the user the container runs as. The image config is used to find the user of containers
+// which don't set runAsUser. It is nil if the image is unknown
+func (a *RunAsNonRoot) auditContainer(container *k8s.ContainerV1, podSpec *k8s.PodSpecV1, imageConfig *imageconfig.Config) *kubeaudit.AuditResult {
+	if auditResult := a.auditRunAsRoot(container, podSpec); auditResult != nil {
+		addImageRootUser(auditResult, container, podSpec, imageConfig)
+		return auditResult
+	}
+	if auditResult := a.auditImageUser(container, podSpec, imageConfig); auditResult != nil {
+		return auditResult
+	}
+	return a.auditRunAsUserRange(container, podSpec, imageConfig)
+}
+
+// auditImageUser checks that a container which requires a non-root user without setting runAsUser uses an image which
+// the kubelet can verify doesn't run as root, otherwise the container fails to start
+func (a *RunAsNonRoot) auditImageUser(container *k8s.ContainerV1, podSpec *k8s.PodSpecV1, imageConfig *imageconfig.Config) *kubeaudit.AuditResult {
+	if imageConfig == nil || !isRunAsNonRootTrue(container, podSpec) {
+		return nil
+	}
+	if uid, _ := getRunAsUser(container, podSpec); uid != nil {
+		return nil
+	}
+
+	auditResult := &kubeaudit.AuditResult{
+		Auditor:  Name,
+		Severity: kubeaudit.Warn,
+		Metadata: kubeaudit.Metadata{
+			"Container":          container.Name,
+			ImageUserMetadataKey: imageConfig.User,
+		},
+	}
+
+	uid, isNumeric := imageConfig.UID()
+	switch {
+	case !isNumeric:
+		auditResult.Rule = RunAsNonRootImageUserNonNumeric
+		auditResult.Message = fmt.Sprintf("runAsNonRoot is set to true but the image %s has the non-numeric USER %q, so the container will fail to start. runAsUser should be set to a value > 0.", container.Image, imageConfig.User)
+	case uid == 0:
+		auditResult.Rule = RunAsNonRootImageUserRoot
+		auditResult.Message = fmt.Sprintf("runAsNonRoot is set to true but the image %s runs as root, so the container will fail to start. runAsUser should be set to a value > 0.", container.Image)
+	default:
 		return nil
 	}
 
+	if a.defaultUID != 0 {
+		auditResult.PendingFix = &fixRunAsUser{container: container, uid: a.defaultUID}
+	}
+	return auditResult
+}
+
+// addImageRootUser explains in the audit result that the container silently runs as root if runAsUser is not set and
+// the USER of the image is root
+func addImageRootUser(auditResult *kubeaudit.AuditResult, container *k8s.ContainerV1, podSpec *k8s.PodSpecV1, imageConfig *imageconfig.Config) {
+	if imageConfig == nil {
+		return
+	}
+	if uid, _ := getRunAsUser(container, podSpec); uid != nil {
+		return
+	}
+	if uid, isNumeric := imageConfig.UID(); (isNumeric && uid == 0) || imageConfig.User == "root" {
+		auditResult.Message += fmt.Sprintf(" The image %s runs as root, so the container runs as root.", container.Image)
+		auditResult.Metadata[ImageUserMetadataKey] = imageConfig.User
+	}
+}
+
+func (a *RunAsNonRoot) auditRunAsRoot(container *k8s.ContainerV1, podSpec *k8s.PodSpecV1) *kubeaudit.AuditResult {
 	if !isContainerRunAsUserNil(container) {
 		if *container.SecurityContext.RunAsUser == 0 {
 			return &kubeaudit.AuditResult{
@@ -61,7 +268,8 @@ func auditContainer(container *k8s.ContainerV1, resource k8s.Resource) *kubeaudi
 				Severity: kubeaudit.Error,
 				Message:  "runAsUser is set to UID 0 (root user) in the container SecurityContext. Either set it to a value > 0 or remove it and set runAsNonRoot to true.",
 				PendingFix: &fixRunAsNonRoot{
-					container: container,
+					container:  container,
+					defaultUID: a.defaultUID,
 				},
 				Metadata: kubeaudit.Metadata{
 					"Container": container.Name,
@@ -94,7 +302,8 @@ func auditContainer(container *k8s.ContainerV1, resource k8s.Resource) *kubeaudi
 				Severity: kubeaudit.Error,
 				Message:  "runAsUser is set to UID 0 (root user) in the PodSecurityContext. Either set it to a value > 0 or remove it and set runAsNonRoot to true.",
 				PendingFix: &fixRunAsNonRoot{
-					container: container,
+					container:  container,
+					defaultUID: a.defaultUID,
 				},
 				Metadata: kubeaudit.Metadata{
 					"Container": container.Name,
@@ -112,7 +321,8 @@ func auditContainer(container *k8s.ContainerV1, resource k8s.Resource) *kubeaudi
 			Severity: kubeaudit.Error,
 			Message:  "runAsNonRoot is set to false in the container SecurityContext. Either set it to true or set runAsUser to a value > 0.",
 			PendingFix: &fixRunAsNonRoot{
-				container: container,
+				container:  container,
+				defaultUID: a.defaultUID,
 			},
 			Metadata: kubeaudit.Metadata{
 				"Container": container.Name,
@@ -128,7 +338,8 @@ func auditContainer(container *k8s.ContainerV1, resource k8s.Resource) *kubeaudi
 				Severity: kubeaudit.Error,
 				Message:  "runAsNonRoot should be set to true or runAsUser should be set to a value > 0 either in the container SecurityContext or PodSecurityContext.",
 				PendingFix: &fixRunAsNonRoot{
-					container: container,
+					container:  container,
+					defaultUID: a.defaultUID,
 				},
 				Metadata: kubeaudit.Metadata{
 					"Container": container.Name,
@@ -143,7 +354,8 @@ func auditContainer(container *k8s.ContainerV1, resource k8s.Resource) *kubeaudi
 				Severity: kubeaudit.Error,
 				Message:  "runAsNonRoot is set to false in the PodSecurityContext. Either set it to true or set runAsUser to a value > 0.",
 				PendingFix: &fixRunAsNonRoot{
-					container: container,
+					container:  container,
+					defaultUID: a.defaultUID,
 				},
 				Metadata: kubeaudit.Metadata{
 					"Container": container.Name,
@@ -155,6 +367,223 @@ func auditContainer(container *k8s.ContainerV1, resource k8s.Resource) *kubeaudi
 	return nil
 }
 
+// auditRunAsUserRange checks that the UID the container runs as is in the allowed UID ranges
+func (a *RunAsNonRoot) auditRunAsUserRange(container *k8s.ContainerV1, podSpec *k8s.PodSpecV1, imageConfig *imageconfig.Config) *kubeaudit.AuditResult {
+	uid, location := getRunAsUser(container, podSpec)
+	message := "runAsUser is set to UID %d in the %s, which is outside the allowed UID ranges (%s). It should be set to an allowed UID."
+
+	// Containers which don't set runAsUser run as the USER of their image
+	if uid == nil && imageConfig != nil {
+		if imageUID, isNumeric := imageConfig.UID(); isNumeric {
+			uid, location = &imageUID, container.Image
+			message = "The container runs as UID %d, the USER of the image %s, which is outside the allowed UID ranges (%s). runAsUser should be set to an allowed UID."
+		}
+	}
+
+	if uid == nil || a.allowedUIDs.contains(*uid) {
+		return nil
+	}
+
+	auditResult := &kubeaudit.AuditResult{
+		Auditor:  Name,
+		Rule:     RunAsUserOutsideAllowedRange,
+		Severity: kubeaudit.Error,
+		Message:  fmt.Sprintf(message, *uid, location, a.allowedUIDs),
+		Metadata: kubeaudit.Metadata{
+			"Container": container.Name,
+			"RunAsUser": strconv.FormatInt(*uid, 10),
+		},
+	}
+	if a.defaultUID != 0 {
+		auditResult.PendingFix = &fixRunAsUser{container: container, uid: a.defaultUID}
+	}
+	return auditResult
+}
+
+// auditPodGroups checks the fsGroup and supplementalGroups of the PodSecurityContext
+func (a *RunAsNonRoot) auditPodGroups(podSpec *k8s.PodSpecV1) []*kubeaudit.AuditResult {
+	var auditResults []*kubeaudit.AuditResult
+	for _, auditResult := range []*kubeaudit.AuditResult{
+		a.auditFSGroup(podSpec),
+		a.auditSupplementalGroups(podSpec),
+	} {
+		if auditResult != nil {
+			auditResults = append(auditResults, auditResult)
+		}
+	}
+	return auditResults
+}
+
+func (a *RunAsNonRoot) auditRunAsGroup(container *k8s.ContainerV1, podSpec *k8s.PodSpecV1) *kubeaudit.AuditResult {
+	if !isContainerRunAsGroupNil(container) {
+		if *container.SecurityContext.RunAsGroup == 0 {
+			return &kubeaudit.AuditResult{
+				Auditor:    Name,
+				Rule:       RunAsGroupCSCRoot,
+				Severity:   kubeaudit.Error,
+				Message:    "runAsGroup is set to GID 0 (root group) in the container SecurityContext. It should be set to a value > 0.",
+				PendingFix: a.newRunAsGroupFix(container),
+				Metadata: kubeaudit.Metadata{
+					"Container": container.Name,
+				},
+			}
+		}
+
+		if !isPodRunAsGroupNil(podSpec) && *podSpec.SecurityContext.RunAsGroup == 0 {
+			return &kubeaudit.AuditResult{
+				Auditor:  Name,
+				Rule:     RunAsGroupPSCRoot,
+				Severity: kubeaudit.Warn,
+				Message:  "runAsGroup is set to GID 0 (root group) in the PodSecurityContext. It should be set to a value > 0.",
+				Metadata: kubeaudit.Metadata{
+					"Container": container.Name,
+				},
+			}
+		}
+	} else if !isPodRunAsGroupNil(podSpec) && *podSpec.SecurityContext.RunAsGroup == 0 {
+		return &kubeaudit.AuditResult{
+			Auditor:    Name,
+			Rule:       RunAsGroupPSCRoot,
+			Severity:   kubeaudit.Error,
+			Message:    "runAsGroup is set to GID 0 (root group) in the PodSecurityContext. It should be set to a value > 0.",
+			PendingFix: a.newRunAsGroupFix(container),
+			Metadata: kubeaudit.Metadata{
+				"Container": container.Name,
+			},
+		}
+	}
+
+	gid, location := getRunAsGroup(container, podSpec)
+	if gid == nil || a.allowedGIDs.contains(*gid) {
+		return nil
+	}
+
+	return &kubeaudit.AuditResult{
+		Auditor:    Name,
+		Rule:       RunAsGroupOutsideAllowedRange,
+		Severity:   kubeaudit.Error,
+		Message:    fmt.Sprintf("runAsGroup is set to GID %d in the %s, which is outside the allowed GID ranges (%s). It should be set to an allowed GID.", *gid, location, a.allowedGIDs),
+		PendingFix: a.newRunAsGroupFix(container),
+		Metadata: kubeaudit.Metadata{
+			"Container":  container.Name,
+			"RunAsGroup": strconv.FormatInt(*gid, 10),
+		},
+	}
+}
+
+// newRunAsGroupFix returns nil if there is no default GID to fix runAsGroup with
+func (a *RunAsNonRoot) newRunAsGroupFix(container *k8s.ContainerV1) kubeaudit.PendingFix {
+	if a.defaultGID == 0 {
+		return nil
+	}
+	return &fixRunAsGroup{container: container, gid: a.defaultGID}
+}
+
+// auditFSGroup checks the pod fsGroup, which owns the volumes of every container in the pod
+func (a *RunAsNonRoot) auditFSGroup(podSpec *k8s.PodSpecV1) *kubeaudit.AuditResult {
+	if podSpec.SecurityContext == nil || podSpec.SecurityContext.FSGroup == nil {
+		return nil
+	}
+
+	auditResult := &kubeaudit.AuditResult{
+		Auditor:  Name,
+		Severity: kubeaudit.Error,
+		Metadata: kubeaudit.Metadata{},
+	}
+
+	fsGroup := *podSpec.SecurityContext.FSGroup
+	switch {
+	case fsGroup == 0:
+		auditResult.Rule = FSGroupRoot
+		auditResult.Message = "fsGroup is set to GID 0 (root group) in the PodSecurityContext. It should be set to a value > 0."
+	case !a.allowedGIDs.contains(fsGroup):
+		auditResult.Rule = FSGroupOutsideAllowedRange
+		auditResult.Message = fmt.Sprintf("fsGroup is set to GID %d in the PodSecurityContext, which is outside the allowed GID ranges (%s). It should be set to an allowed GID.", fsGroup, a.allowedGIDs)
+		auditResult.Metadata["FSGroup"] = strconv.FormatInt(fsGroup, 10)
+	default:
+		return nil
+	}
+
+	if a.defaultGID != 0 {
+		auditResult.PendingFix = &fixFSGroup{gid: a.defaultGID}
+	}
+	return auditResult
+}
+
+// auditSupplementalGroups checks the pod supplementalGroups, which are added to the groups of every container in the
+// pod
+func (a *RunAsNonRoot) auditSupplementalGroups(podSpec *k8s.PodSpecV1) *kubeaudit.AuditResult {
+	if podSpec.SecurityContext == nil {
+		return nil
+	}
+
+	var hasRoot bool
+	var outsideRange []string
+	for _, gid := range podSpec.SecurityContext.SupplementalGroups {
+		if gid == 0 {
+			hasRoot = true
+		} else if !a.allowedGIDs.contains(gid) {
+			outsideRange = append(outsideRange, strconv.FormatInt(gid, 10))
+		}
+	}
+
+	auditResult := &kubeaudit.AuditResult{
+		Auditor:  Name,
+		Severity: kubeaudit.Error,
+		PendingFix: &fixSupplementalGroups{
+			allowedGIDs: a.allowedGIDs,
+		},
+		Metadata: kubeaudit.Metadata{},
+	}
+
+	switch {
+	case hasRoot:
+		auditResult.Rule = SupplementalGroupsRoot
+		auditResult.Message = "supplementalGroups contains GID 0 (root group) in the PodSecurityContext. It should be removed."
+	case len(outsideRange) > 0:
+		auditResult.Rule = SupplementalGroupsOutsideAllowedRange
+		auditResult.Message = fmt.Sprintf("supplementalGroups contains GIDs outside the allowed GID ranges (%s) in the PodSecurityContext. They should be removed.", a.allowedGIDs)
+		auditResult.Metadata["SupplementalGroups"] = strings.Join(outsideRange, ", ")
+	default:
+		return nil
+	}
+
+	return auditResult
+}
+
+// getRunAsUser returns the runAsUser the container runs with and where it is set. The container SecurityContext takes
+// precedence over the PodSecurityContext
+func getRunAsUser(container *k8s.ContainerV1, podSpec *k8s.PodSpecV1) (*int64, string) {
+	if !isContainerRunAsUserNil(container) {
+		return container.SecurityContext.RunAsUser, "container SecurityContext"
+	}
+	if !isPodRunAsUserNil(podSpec) {
+		return podSpec.SecurityContext.RunAsUser, "PodSecurityContext"
+	}
+	return nil, ""
+}
+
+// getRunAsGroup returns the runAsGroup the container runs with and where it is set. The container SecurityContext
+// takes precedence over the PodSecurityContext
+func getRunAsGroup(container *k8s.ContainerV1, podSpec *k8s.PodSpecV1) (*int64, string) {
+	if !isContainerRunAsGroupNil(container) {
+		return container.SecurityContext.RunAsGroup, "container SecurityContext"
+	}
+	if !isPodRunAsGroupNil(podSpec) {
+		return podSpec.SecurityContext.RunAsGroup, "PodSecurityContext"
+	}
+	return nil, ""
+}
+
+// isRunAsNonRootTrue returns true if runAsNonRoot is true for the container, either in the container SecurityContext
+// or the PodSecurityContext. The container SecurityContext takes precedence
+func isRunAsNonRootTrue(container *k8s.ContainerV1, podSpec *k8s.PodSpecV1) bool {
+	if !isContainerRunAsNonRootNil(container) {
+		return *container.SecurityContext.RunAsNonRoot
+	}
+	return !isPodRunAsNonRootNil(podSpec) && *podSpec.SecurityContext.RunAsNonRoot
+}
+
 // returns true if runAsNonRoot is explicitly set to false in the pod's security context. Returns true if the
 // security context is nil even though the default value for runAsNonRoot is false
 func isPodRunAsNonRootFalse(podSpec *k8s.PodSpecV1) bool {
@@ -190,3 +619,11 @@ func isContainerRunAsUserNil(container *k8s.ContainerV1) bool {
 func isPodRunAsUserNil(podSpec *k8s.PodSpecV1) bool {
 	return podSpec.SecurityContext == nil || podSpec.SecurityContext.RunAsUser == nil
 }
+
+func isContainerRunAsGroupNil(container *k8s.ContainerV1) bool {
+	return container.SecurityContext == nil || container.SecurityContext.RunAsGroup == nil
+}
+
+func isPodRunAsGroupNil(podSpec *k8s.PodSpecV1) bool {
+	return podSpec.SecurityContext == nil || podSpec.SecurityContext.RunAsGroup == nil
+}
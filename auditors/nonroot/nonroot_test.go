@@ -7,9 +7,14 @@ import (
 	"github.com/Shopify/kubeaudit"
 	"github.com/Shopify/kubeaudit/internal/test"
 	"github.com/Shopify/kubeaudit/pkg/override"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
-const fixtureDir = "fixtures"
+const (
+	fixtureDir       = "fixtures"
+	imageConfigCache = "fixtures/images/image-config-cache.yml"
+)
 
 func TestAuditRunAsNonRoot(t *testing.T) {
 	cases := []struct {
@@ -63,6 +68,18 @@ func TestAuditRunAsNonRoot(t *testing.T) {
 		{"run-as-user-1-run-as-non-root-false.yml", fixtureDir, []string{}},
 		{"run-as-user-psc-1-run-as-non-root-psc-true.yml", fixtureDir, []string{}},
 		{"run-as-user-psc-1-run-as-non-root-psc-false.yml", fixtureDir, []string{}},
+		{"run-as-group-0.yml", fixtureDir, []string{RunAsGroupCSCRoot}},
+		{"run-as-group-0-allowed.yml", fixtureDir, []string{override.GetOverriddenResultName(RunAsGroupCSCRoot)}},
+		{"run-as-group-psc-0.yml", fixtureDir, []string{RunAsGroupPSCRoot}},
+		{"run-as-group-psc-0-csc-1.yml", fixtureDir, []string{RunAsGroupPSCRoot}},
+		{"fs-group-0.yml", fixtureDir, []string{FSGroupRoot}},
+		{"supplemental-groups-0.yml", fixtureDir, []string{SupplementalGroupsRoot}},
+		{"pod-groups-0-multiple-cont.yml", fixtureDir, []string{FSGroupRoot, SupplementalGroupsRoot}},
+		{"pod-groups-0-allowed-multiple-cont.yml", fixtureDir, []string{
+			override.GetOverriddenResultName(FSGroupRoot), override.GetOverriddenResultName(SupplementalGroupsRoot),
+		}},
+		{"ids-outside-allowed-range.yml", fixtureDir, []string{}},
+		{"image-user.yml", fixtureDir, []string{}},
 	}
 
 	for _, tc := range cases {
@@ -70,8 +87,106 @@ func TestAuditRunAsNonRoot(t *testing.T) {
 		tc := tc
 		t.Run(tc.file, func(t *testing.T) {
 			t.Parallel()
-			test.AuditManifest(t, tc.fixtureDir, tc.file, New(), tc.expectedErrors)
-			test.AuditLocal(t, tc.fixtureDir, tc.file, New(), strings.Split(tc.file, ".")[0], tc.expectedErrors)
+			auditor := New()
+			test.AuditManifest(t, tc.fixtureDir, tc.file, auditor, tc.expectedErrors)
+			test.AuditLocal(t, tc.fixtureDir, tc.file, auditor, strings.Split(tc.file, ".")[0], tc.expectedErrors)
+		})
+	}
+}
+
+func TestAuditPodGroupsOncePerPod(t *testing.T) {
+	report := test.AuditManifest(t, fixtureDir, "pod-groups-0-multiple-cont.yml", New(), []string{FSGroupRoot, SupplementalGroupsRoot})
+
+	var rules []string
+	for _, result := range report.Results() {
+		for _, auditResult := range result.GetAuditResults() {
+			rules = append(rules, auditResult.Rule)
+			assert.NotContains(t, auditResult.Metadata, kubeaudit.ContainerMetadataKey)
+		}
+	}
+	assert.ElementsMatch(t, []string{FSGroupRoot, SupplementalGroupsRoot}, rules)
+}
+
+func TestAuditAllowedIDRanges(t *testing.T) {
+	cases := []struct {
+		description    string
+		config         Config
+		expectedErrors []string
+	}{
+		{"no ranges", Config{}, []string{}},
+		{"ranges exclude the pod IDs", Config{AllowedUIDs: []string{"10000-65535"}, AllowedGIDs: []string{"10000-65535"}}, []string{
+			RunAsUserOutsideAllowedRange, RunAsGroupOutsideAllowedRange, FSGroupOutsideAllowedRange,
+			SupplementalGroupsOutsideAllowedRange,
+		}},
+		{"ranges exclude a supplemental group", Config{AllowedGIDs: []string{"1000", "10000-65535"}}, []string{
+			SupplementalGroupsOutsideAllowedRange,
+		}},
+		{"ranges include every ID", Config{AllowedUIDs: []string{"1000", "10000-65535"}, AllowedGIDs: []string{"1000 - 2000", "10000-65535"}}, []string{}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			auditor, err := NewWithConfig(tc.config)
+			require.NoError(t, err)
+			test.AuditManifest(t, fixtureDir, "ids-outside-allowed-range.yml", auditor, tc.expectedErrors)
+		})
+	}
+
+	for _, config := range []Config{
+		{AllowedUIDs: []string{"abc"}},
+		{AllowedGIDs: []string{"2000-1000"}},
+		{AllowedUIDs: []string{"-1"}},
+		{AllowedUIDs: []string{"10000-65535"}, DefaultUID: 1000},
+		{AllowedGIDs: []string{"10000-65535"}, DefaultGID: 1000},
+	} {
+		_, err := NewWithConfig(config)
+		assert.NotNil(t, err)
+	}
+}
+
+func TestAuditImageUser(t *testing.T) {
+	cases := []struct {
+		file               string
+		config             Config
+		expectedErrors     []string
+		expectedContainers []string
+	}{
+		{"image-user.yml", Config{ImageConfigCache: imageConfigCache}, []string{
+			RunAsNonRootImageUserRoot, RunAsNonRootImageUserNonNumeric,
+		}, []string{"root-image", "named-image"}},
+		{"image-user.yml", Config{ImageConfigCache: imageConfigCache, AllowedUIDs: []string{"10000-65535"}}, []string{
+			RunAsNonRootImageUserRoot, RunAsNonRootImageUserNonNumeric, RunAsUserOutsideAllowedRange,
+		}, []string{"root-image", "named-image", "nonroot-image"}},
+		// The config of app:named can't be read from the layout so it isn't checked
+		{"image-user.yml", Config{OCILayoutDir: "fixtures/images/broken-oci-layout", ImageConfigCache: imageConfigCache}, []string{
+			RunAsNonRootImageUserRoot,
+		}, []string{"root-image"}},
+		{"image-user-run-as-non-root-nil.yml", Config{ImageConfigCache: imageConfigCache}, []string{
+			RunAsNonRootPSCNilCSCNil,
+		}, []string{"container"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.file, func(t *testing.T) {
+			auditor, err := NewWithConfig(tc.config)
+			require.NoError(t, err)
+			report := test.AuditManifest(t, fixtureDir, tc.file, auditor, tc.expectedErrors)
+
+			var containers []string
+			for _, result := range report.Results() {
+				for _, auditResult := range result.GetAuditResults() {
+					containers = append(containers, auditResult.Metadata["Container"])
+					if auditResult.Rule != RunAsUserOutsideAllowedRange {
+						assert.Contains(t, auditResult.Metadata, ImageUserMetadataKey)
+					}
+				}
+			}
+			assert.ElementsMatch(t, tc.expectedContainers, containers)
 		})
 	}
+
+	_, err := NewWithConfig(Config{ImageConfigCache: "fixtures/images/missing.yml"})
+	assert.NotNil(t, err)
+	_, err = NewWithConfig(Config{OCILayoutDir: "fixtures/images"})
+	assert.NotNil(t, err)
 }
@@ -20,6 +20,8 @@ func TestAuditPrivileged(t *testing.T) {
 		{"privileged-nil.yml", fixtureDir, []string{PrivilegedNil}},
 		{"privileged-true.yml", fixtureDir, []string{PrivilegedTrue}},
 		{"privileged-true-allowed.yml", fixtureDir, []string{override.GetOverriddenResultName(PrivilegedTrue)}},
+		{"privileged-true-allowed-annotation.yml", fixtureDir, []string{override.GetOverriddenResultName(PrivilegedTrue)}},
+		{"privileged-true-allowed-expired.yml", fixtureDir, []string{PrivilegedTrue}},
 		{"privileged-redundant-override.yml", fixtureDir, []string{kubeaudit.RedundantAuditorOverride}},
 		{"privileged-true-allowed-multi-containers-multi-labels.yml", fixtureDir, []string{override.GetOverriddenResultName(PrivilegedTrue)}},
 		{"privileged-true-allowed-multi-containers-single-label.yml", fixtureDir, []string{
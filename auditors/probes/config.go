@@ -0,0 +1,53 @@
+package probes
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Shopify/kubeaudit"
+)
+
+// severityOff disables a rule
+const severityOff = "off"
+
+type Config struct {
+	// Severities overrides the severity of rules, by rule name (eg. "LivenessProbeMissing: error"). The severity can be
+	// "error", "warning", "info" or "off", which disables the rule
+	Severities map[string]string `yaml:"severities"`
+}
+
+func (config *Config) GetSeverities() map[string]string {
+	if config == nil {
+		return nil
+	}
+	return config.Severities
+}
+
+// parseSeverities returns the severity of every rule, leaving out the rules which are turned off
+func parseSeverities(severities map[string]string) (map[string]kubeaudit.SeverityLevel, error) {
+	parsed := make(map[string]kubeaudit.SeverityLevel, len(defaultSeverities))
+	for rule, severity := range defaultSeverities {
+		parsed[rule] = severity
+	}
+
+	for rule, severity := range severities {
+		if _, ok := defaultSeverities[rule]; !ok {
+			return nil, fmt.Errorf("unknown probes rule %q", rule)
+		}
+
+		switch strings.ToLower(severity) {
+		case "error":
+			parsed[rule] = kubeaudit.Error
+		case "warn", "warning":
+			parsed[rule] = kubeaudit.Warn
+		case "info":
+			parsed[rule] = kubeaudit.Info
+		case severityOff:
+			delete(parsed, rule)
+		default:
+			return nil, fmt.Errorf("invalid severity %q for probes rule %s", severity, rule)
+		}
+	}
+
+	return parsed, nil
+}
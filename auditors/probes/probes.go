@@ -0,0 +1,200 @@
+package probes
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/Shopify/kubeaudit"
+	"github.com/Shopify/kubeaudit/pkg/k8s"
+	"github.com/Shopify/kubeaudit/pkg/override"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+)
+
+const Name = "probes"
+
+const (
+	// LivenessProbeMissing occurs when a long-running container has no liveness probe
+	LivenessProbeMissing = "LivenessProbeMissing"
+	// ReadinessProbeMissing occurs when a long-running container has no readiness probe
+	ReadinessProbeMissing = "ReadinessProbeMissing"
+	// ProbeExecShell occurs when a probe runs its command with a shell
+	ProbeExecShell = "ProbeExecShell"
+	// LivenessReadinessProbesIdentical occurs when the liveness and readiness probes of a container run the same check
+	LivenessReadinessProbesIdentical = "LivenessReadinessProbesIdentical"
+	// ProbeTimeoutExceedsPeriod occurs when the timeout of a probe is longer than its period
+	ProbeTimeoutExceedsPeriod = "ProbeTimeoutExceedsPeriod"
+)
+
+// defaultSeverities are the severities of the rules unless they are overridden in the config
+var defaultSeverities = map[string]kubeaudit.SeverityLevel{
+	LivenessProbeMissing:             kubeaudit.Warn,
+	ReadinessProbeMissing:            kubeaudit.Warn,
+	ProbeExecShell:                   kubeaudit.Warn,
+	LivenessReadinessProbesIdentical: kubeaudit.Warn,
+	ProbeTimeoutExceedsPeriod:        kubeaudit.Warn,
+}
+
+const OverrideLabel = "allow-probes"
+
+// ProbeMetadataKey is the audit result metadata key holding the kind of probe (liveness, readiness or startup)
+const ProbeMetadataKey = "Probe"
+
+// Probe defaults, see https://kubernetes.io/docs/tasks/configure-pod-container/configure-liveness-readiness-startup-probes/#configure-probes
+const (
+	defaultTimeoutSeconds = 1
+	defaultPeriodSeconds  = 10
+)
+
+// shells are the commands which run their arguments with a shell, without their directory or ".exe" extension
+var shells = map[string]bool{
+	"sh":         true,
+	"ash":        true,
+	"bash":       true,
+	"dash":       true,
+	"ksh":        true,
+	"zsh":        true,
+	"cmd":        true,
+	"powershell": true,
+	"pwsh":       true,
+}
+
+// Probes implements Auditable
+type Probes struct {
+	severities map[string]kubeaudit.SeverityLevel
+}
+
+func New(config Config) (*Probes, error) {
+	severities, err := parseSeverities(config.GetSeverities())
+	if err != nil {
+		return nil, err
+	}
+
+	return &Probes{
+		severities: severities,
+	}, nil
+}
+
+// Audit checks that long-running containers have liveness and readiness probes, and that probes are configured well
+func (a *Probes) Audit(resource k8s.Resource, _ []k8s.Resource) ([]*kubeaudit.AuditResult, error) {
+	var auditResults []*kubeaudit.AuditResult
+
+	podSpec := k8s.GetPodSpec(resource)
+	if podSpec == nil {
+		return nil, nil
+	}
+
+	for _, container := range k8s.GetContainers(resource) {
+		containerType, _ := k8s.GetContainerType(resource, container.Name)
+		containerResults := a.auditContainer(container, containerType, isLongRunning(podSpec))
+		auditResults = append(auditResults, override.ApplyOverrides(containerResults, Name, container.Name, resource, OverrideLabel)...)
+	}
+
+	return auditResults, nil
+}
+
+// isLongRunning returns false for pods which run to completion, such as the pods of Jobs and CronJobs
+func isLongRunning(podSpec *k8s.PodSpecV1) bool {
+	return podSpec.RestartPolicy == "" || podSpec.RestartPolicy == v1.RestartPolicyAlways
+}
+
+func (a *Probes) auditContainer(container *k8s.ContainerV1, containerType k8s.ContainerType, longRunning bool) []*kubeaudit.AuditResult {
+	var auditResults []*kubeaudit.AuditResult
+
+	// Init containers run to completion and ephemeral containers can't have probes, so only regular and sidecar
+	// containers need them
+	if longRunning && (containerType == k8s.ContainerTypeRegular || containerType == k8s.ContainerTypeSidecar) {
+		if container.LivenessProbe == nil {
+			auditResults = a.appendResult(auditResults, container, LivenessProbeMissing,
+				"Container has no liveness probe. It should be added so the container is restarted when it stops working.", nil)
+		}
+		if container.ReadinessProbe == nil {
+			auditResults = a.appendResult(auditResults, container, ReadinessProbeMissing,
+				"Container has no readiness probe. It should be added so the container only receives traffic when it is ready.", nil)
+		}
+	}
+
+	if container.LivenessProbe != nil && container.ReadinessProbe != nil &&
+		equality.Semantic.DeepEqual(container.LivenessProbe.ProbeHandler, container.ReadinessProbe.ProbeHandler) {
+		auditResults = a.appendResult(auditResults, container, LivenessReadinessProbesIdentical,
+			"Liveness and readiness probes run the same check. The liveness probe should only check that the container can recover, otherwise the container is restarted when it is only temporarily unable to serve traffic.", nil)
+	}
+
+	for _, probe := range []struct {
+		name  string
+		probe *v1.Probe
+	}{
+		{"liveness", container.LivenessProbe},
+		{"readiness", container.ReadinessProbe},
+		{"startup", container.StartupProbe},
+	} {
+		if probe.probe == nil {
+			continue
+		}
+
+		if probe.probe.Exec != nil && isShellCommand(probe.probe.Exec.Command) {
+			auditResults = a.appendResult(auditResults, container, ProbeExecShell,
+				fmt.Sprintf("The %s probe runs its command with a shell (%s). The command should be run directly, since a shell is started every period and needs to be in the image.", probe.name, strings.Join(probe.probe.Exec.Command, " ")),
+				kubeaudit.Metadata{ProbeMetadataKey: probe.name})
+		}
+
+		timeoutSeconds, periodSeconds := getTimeoutSeconds(probe.probe), getPeriodSeconds(probe.probe)
+		if timeoutSeconds > periodSeconds {
+			auditResults = a.appendResult(auditResults, container, ProbeTimeoutExceedsPeriod,
+				fmt.Sprintf("The %s probe has a timeoutSeconds of %d, which is longer than its periodSeconds of %d. The timeout should not be longer than the period so probes don't overlap.", probe.name, timeoutSeconds, periodSeconds),
+				kubeaudit.Metadata{ProbeMetadataKey: probe.name})
+		}
+	}
+
+	return auditResults
+}
+
+// appendResult appends an audit result for the rule with its configured severity, unless the rule is turned off
+func (a *Probes) appendResult(auditResults []*kubeaudit.AuditResult, container *k8s.ContainerV1, rule, message string, metadata kubeaudit.Metadata) []*kubeaudit.AuditResult {
+	severity, enabled := a.severities[rule]
+	if !enabled {
+		return auditResults
+	}
+
+	if metadata == nil {
+		metadata = kubeaudit.Metadata{}
+	}
+	metadata["Container"] = container.Name
+
+	return append(auditResults, &kubeaudit.AuditResult{
+		Auditor:  Name,
+		Rule:     rule,
+		Severity: severity,
+		Message:  message,
+		Metadata: metadata,
+	})
+}
+
+// isShellCommand returns true if the command runs a shell, either directly or through env or busybox
+func isShellCommand(command []string) bool {
+	for i, arg := range command {
+		name := strings.ToLower(strings.TrimSuffix(path.Base(strings.ReplaceAll(arg, "\\", "/")), ".exe"))
+		if shells[name] {
+			return true
+		}
+		if i > 0 || (name != "env" && name != "busybox") {
+			return false
+		}
+	}
+	return false
+}
+
+func getTimeoutSeconds(probe *v1.Probe) int32 {
+	if probe.TimeoutSeconds == 0 {
+		return defaultTimeoutSeconds
+	}
+	return probe.TimeoutSeconds
+}
+
+func getPeriodSeconds(probe *v1.Probe) int32 {
+	if probe.PeriodSeconds == 0 {
+		return defaultPeriodSeconds
+	}
+	return probe.PeriodSeconds
+}
@@ -0,0 +1,92 @@
+package probes
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Shopify/kubeaudit"
+	"github.com/Shopify/kubeaudit/internal/test"
+	"github.com/Shopify/kubeaudit/pkg/override"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const fixtureDir = "fixtures"
+
+func TestAuditProbes(t *testing.T) {
+	cases := []struct {
+		file           string
+		config         Config
+		expectedErrors []string
+	}{
+		{"probes-configured.yml", Config{}, []string{}},
+		{"probes-missing.yml", Config{}, []string{LivenessProbeMissing, ReadinessProbeMissing}},
+		{"probes-missing.yml", Config{Severities: map[string]string{LivenessProbeMissing: "off"}}, []string{ReadinessProbeMissing}},
+		{"probes-missing-allowed.yml", Config{}, []string{
+			override.GetOverriddenResultName(LivenessProbeMissing),
+			override.GetOverriddenResultName(ReadinessProbeMissing),
+		}},
+		{"probes-redundant-override.yml", Config{}, []string{kubeaudit.RedundantAuditorOverride}},
+		{"probes-job.yml", Config{}, []string{}},
+		{"probes-sidecar-missing.yml", Config{}, []string{LivenessProbeMissing, ReadinessProbeMissing}},
+		{"probes-misconfigured.yml", Config{}, []string{ProbeExecShell, LivenessReadinessProbesIdentical, ProbeTimeoutExceedsPeriod}},
+	}
+
+	for _, tc := range cases {
+		// This line is needed because of how scopes work with parallel tests (see https://gist.github.com/posener/92a55c4cd441fc5e5e85f27bca008721)
+		tc := tc
+		t.Run(tc.file, func(t *testing.T) {
+			t.Parallel()
+			auditor, err := New(tc.config)
+			require.NoError(t, err)
+			test.AuditManifest(t, fixtureDir, tc.file, auditor, tc.expectedErrors)
+			test.AuditLocal(t, fixtureDir, tc.file, auditor, strings.Split(tc.file, ".")[0], tc.expectedErrors)
+		})
+	}
+}
+
+func TestProbesSeverities(t *testing.T) {
+	auditor, err := New(Config{Severities: map[string]string{
+		LivenessProbeMissing:  "error",
+		ReadinessProbeMissing: "Info",
+	}})
+	require.NoError(t, err)
+	report := test.AuditManifest(t, fixtureDir, "probes-missing.yml", auditor, []string{LivenessProbeMissing, ReadinessProbeMissing})
+
+	severities := map[string]kubeaudit.SeverityLevel{}
+	for _, result := range report.Results() {
+		for _, auditResult := range result.GetAuditResults() {
+			severities[auditResult.Rule] = auditResult.Severity
+		}
+	}
+	assert.Equal(t, map[string]kubeaudit.SeverityLevel{
+		LivenessProbeMissing:  kubeaudit.Error,
+		ReadinessProbeMissing: kubeaudit.Info,
+	}, severities)
+
+	_, err = New(Config{Severities: map[string]string{"ProbeMissing": "error"}})
+	assert.Error(t, err)
+	_, err = New(Config{Severities: map[string]string{LivenessProbeMissing: "critical"}})
+	assert.Error(t, err)
+}
+
+func TestIsShellCommand(t *testing.T) {
+	cases := []struct {
+		command  []string
+		expected bool
+	}{
+		{[]string{"/bin/sh", "-c", "true"}, true},
+		{[]string{"bash", "-c", "true"}, true},
+		{[]string{"/usr/bin/env", "bash", "-c", "true"}, true},
+		{[]string{"busybox", "sh", "-c", "true"}, true},
+		{[]string{"C:\\Windows\\System32\\WindowsPowerShell\\v1.0\\powershell.exe", "-Command", "exit 0"}, true},
+		{[]string{"/bin/grpc_health_probe", "-addr=:8080"}, false},
+		{[]string{"cat", "/tmp/healthy"}, false},
+		{[]string{"env"}, false},
+		{nil, false},
+	}
+
+	for _, tc := range cases {
+		assert.Equal(t, tc.expected, isShellCommand(tc.command), strings.Join(tc.command, " "))
+	}
+}
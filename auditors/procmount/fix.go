@@ -0,0 +1,20 @@
+package procmount
+
+import (
+	"fmt"
+
+	"github.com/Shopify/kubeaudit/pkg/k8s"
+)
+
+type fixProcMountUnmasked struct {
+	container *k8s.ContainerV1
+}
+
+func (f *fixProcMountUnmasked) Plan() string {
+	return fmt.Sprintf("Remove procMount from container SecurityContext for container %s", f.container.Name)
+}
+
+func (f *fixProcMountUnmasked) Apply(resource k8s.Resource) []k8s.Resource {
+	f.container.SecurityContext.ProcMount = nil
+	return nil
+}
@@ -0,0 +1,35 @@
+package procmount
+
+import (
+	"testing"
+
+	"github.com/Shopify/kubeaudit/internal/test"
+	"github.com/Shopify/kubeaudit/pkg/k8s"
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+)
+
+func TestFixProcMount(t *testing.T) {
+	defaultProcMount := apiv1.DefaultProcMount
+	unmaskedProcMount := apiv1.UnmaskedProcMount
+
+	cases := []struct {
+		file              string
+		expectedProcMount *apiv1.ProcMountType
+	}{
+		{"proc-mount-default.yml", &defaultProcMount},
+		{"proc-mount-unmasked.yml", nil},
+		{"proc-mount-unmasked-allowed.yml", &unmaskedProcMount},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.file, func(t *testing.T) {
+			resources, _ := test.FixSetup(t, fixtureDir, tc.file, New())
+			for _, resource := range resources {
+				for _, container := range k8s.GetContainers(resource) {
+					assert.Equal(t, tc.expectedProcMount, container.SecurityContext.ProcMount)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package procmount
+
+import (
+	"fmt"
+
+	"github.com/Shopify/kubeaudit"
+	"github.com/Shopify/kubeaudit/pkg/k8s"
+	"github.com/Shopify/kubeaudit/pkg/override"
+	apiv1 "k8s.io/api/core/v1"
+)
+
+const Name = "procmount"
+
+const (
+	// ProcMountUnmasked occurs when procMount is set to a value other than Default in the container SecurityContext,
+	// which exposes the parts of /proc the container runtime masks by default
+	ProcMountUnmasked = "ProcMountUnmasked"
+)
+
+const OverrideLabel = "allow-unmasked-proc-mount"
+
+// ProcMount implements Auditable
+type ProcMount struct{}
+
+func New() *ProcMount {
+	return &ProcMount{}
+}
+
+// Audit checks that procMount is not set to a value other than Default in every container's security context
+func (a *ProcMount) Audit(resource k8s.Resource, _ []k8s.Resource) ([]*kubeaudit.AuditResult, error) {
+	var auditResults []*kubeaudit.AuditResult
+
+	for _, container := range k8s.GetContainers(resource) {
+		auditResult := auditContainer(container)
+		auditResult = override.ApplyOverride(auditResult, Name, container.Name, resource, OverrideLabel)
+		if auditResult != nil {
+			auditResults = append(auditResults, auditResult)
+		}
+	}
+
+	return auditResults, nil
+}
+
+func auditContainer(container *k8s.ContainerV1) *kubeaudit.AuditResult {
+	if container.SecurityContext == nil || container.SecurityContext.ProcMount == nil {
+		return nil
+	}
+
+	procMount := *container.SecurityContext.ProcMount
+	if procMount == apiv1.DefaultProcMount {
+		return nil
+	}
+
+	return &kubeaudit.AuditResult{
+		Auditor:  Name,
+		Rule:     ProcMountUnmasked,
+		Severity: kubeaudit.Error,
+		Message:  fmt.Sprintf("procMount is set to '%s' in container SecurityContext. It should be set to 'Default' or removed.", procMount),
+		PendingFix: &fixProcMountUnmasked{
+			container: container,
+		},
+		Metadata: kubeaudit.Metadata{
+			"Container": container.Name,
+			"ProcMount": string(procMount),
+		},
+	}
+}
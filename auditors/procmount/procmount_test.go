@@ -0,0 +1,34 @@
+package procmount
+
+import (
+	"testing"
+
+	"github.com/Shopify/kubeaudit"
+	"github.com/Shopify/kubeaudit/internal/test"
+	"github.com/Shopify/kubeaudit/pkg/override"
+)
+
+const fixtureDir = "fixtures"
+
+func TestAuditProcMount(t *testing.T) {
+	cases := []struct {
+		file           string
+		expectedErrors []string
+	}{
+		{"proc-mount-default.yml", []string{}},
+		{"proc-mount-unmasked.yml", []string{ProcMountUnmasked}},
+		{"proc-mount-unmasked-allowed.yml", []string{override.GetOverriddenResultName(ProcMountUnmasked)}},
+		{"proc-mount-redundant-override.yml", []string{kubeaudit.RedundantAuditorOverride}},
+	}
+
+	for _, tc := range cases {
+		// This line is needed because of how scopes work with parallel tests (see https://gist.github.com/posener/92a55c4cd441fc5e5e85f27bca008721)
+		tc := tc
+		t.Run(tc.file, func(t *testing.T) {
+			t.Parallel()
+			// procMount is only kept by the API server if the ProcMountType feature gate is enabled, so the fixtures are
+			// not tested in local mode
+			test.AuditManifest(t, fixtureDir, tc.file, New(), tc.expectedErrors)
+		})
+	}
+}
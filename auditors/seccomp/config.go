@@ -0,0 +1,56 @@
+package seccomp
+
+type Config struct {
+	// AllowedLocalhostProfiles are the approved localhost profiles, relative to the kubelet seccomp profile root. They
+	// can be glob patterns (eg. "profiles/*.json"). If it is empty, every localhost profile is allowed
+	AllowedLocalhostProfiles []string `yaml:"allowedLocalhostProfiles"`
+	// LocalhostProfileDir is a local copy of the kubelet seccomp profile root (/var/lib/kubelet/seccomp by default). If
+	// it is set, the localhost profiles are read from this directory and analyzed
+	LocalhostProfileDir string `yaml:"localhostProfileDir"`
+	// DangerousSyscalls are the syscalls which localhost profiles shouldn't allow. Defaults to DefaultDangerousSyscalls
+	DangerousSyscalls []string `yaml:"dangerousSyscalls"`
+}
+
+// DefaultDangerousSyscalls are syscalls which can be used to escape a container or to tamper with the host
+var DefaultDangerousSyscalls = []string{
+	"add_key",
+	"bpf",
+	"delete_module",
+	"finit_module",
+	"init_module",
+	"kexec_file_load",
+	"kexec_load",
+	"keyctl",
+	"mount",
+	"open_by_handle_at",
+	"perf_event_open",
+	"pivot_root",
+	"ptrace",
+	"reboot",
+	"request_key",
+	"setns",
+	"umount2",
+	"unshare",
+	"userfaultfd",
+}
+
+func (config *Config) GetAllowedLocalhostProfiles() []string {
+	if config == nil {
+		return nil
+	}
+	return config.AllowedLocalhostProfiles
+}
+
+func (config *Config) GetLocalhostProfileDir() string {
+	if config == nil {
+		return ""
+	}
+	return config.LocalhostProfileDir
+}
+
+func (config *Config) GetDangerousSyscalls() []string {
+	if config == nil || len(config.DangerousSyscalls) == 0 {
+		return DefaultDangerousSyscalls
+	}
+	return config.DangerousSyscalls
+}
@@ -34,7 +34,8 @@ func TestFixSeccomp(t *testing.T) {
 		// This line is needed because of how scopes work with parallel tests (see https://gist.github.com/posener/92a55c4cd441fc5e5e85f27bca008721)
 		tc := tc
 		t.Run(tc.file, func(t *testing.T) {
-			resources, _ := test.FixSetup(t, fixtureDir, tc.file, New())
+			auditor := New()
+			resources, _ := test.FixSetup(t, fixtureDir, tc.file, auditor)
 			require.Len(t, resources, 1)
 			resource := resources[0]
 
@@ -0,0 +1,122 @@
+package seccomp
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// Seccomp actions which let a syscall run
+const (
+	actionAllow = "SCMP_ACT_ALLOW"
+	actionLog   = "SCMP_ACT_LOG"
+)
+
+// profileFile is the part of a seccomp profile file in the format used by the container runtimes which is analyzed
+type profileFile struct {
+	DefaultAction string           `json:"defaultAction"`
+	Syscalls      []profileSyscall `json:"syscalls"`
+}
+
+type profileSyscall struct {
+	Names []string `json:"names"`
+	// Name is used by profiles in the older Docker format instead of Names
+	Name   string            `json:"name"`
+	Action string            `json:"action"`
+	Args   []json.RawMessage `json:"args"`
+}
+
+// profileAnalysis is the result of analyzing a localhost profile
+type profileAnalysis struct {
+	// err is set if the profile couldn't be read or parsed
+	err error
+	// permissiveDefaultAction is the default action of the profile if it lets syscalls run
+	permissiveDefaultAction string
+	// dangerousSyscalls are the dangerous syscalls the profile lets run unconditionally
+	dangerousSyscalls []string
+}
+
+// profileAnalyzer reads and analyzes the localhost profiles in a directory. Profiles are only analyzed once
+type profileAnalyzer struct {
+	dir               string
+	dangerousSyscalls map[string]bool
+
+	mu       sync.Mutex
+	analyses map[string]*profileAnalysis
+}
+
+func newProfileAnalyzer(dir string, dangerousSyscalls []string) *profileAnalyzer {
+	analyzer := &profileAnalyzer{
+		dir:               dir,
+		dangerousSyscalls: map[string]bool{},
+		analyses:          map[string]*profileAnalysis{},
+	}
+	for _, syscall := range dangerousSyscalls {
+		analyzer.dangerousSyscalls[syscall] = true
+	}
+	return analyzer
+}
+
+func (analyzer *profileAnalyzer) analyze(localhostProfile string) *profileAnalysis {
+	analyzer.mu.Lock()
+	defer analyzer.mu.Unlock()
+
+	if analysis, ok := analyzer.analyses[localhostProfile]; ok {
+		return analysis
+	}
+	analysis := analyzer.analyzeFile(localhostProfile)
+	analyzer.analyses[localhostProfile] = analysis
+	return analysis
+}
+
+func (analyzer *profileAnalyzer) analyzeFile(localhostProfile string) *profileAnalysis {
+	// Localhost profiles are relative to the profile root, which they can't leave
+	if !filepath.IsLocal(localhostProfile) {
+		return &profileAnalysis{err: fmt.Errorf("the path is not in the localhost profile directory")}
+	}
+
+	data, err := os.ReadFile(filepath.Join(analyzer.dir, localhostProfile))
+	if err != nil {
+		return &profileAnalysis{err: err}
+	}
+
+	profile := profileFile{}
+	if err := json.Unmarshal(data, &profile); err != nil {
+		return &profileAnalysis{err: fmt.Errorf("error parsing the profile: %w", err)}
+	}
+
+	analysis := &profileAnalysis{}
+	if isAllowAction(profile.DefaultAction) {
+		analysis.permissiveDefaultAction = profile.DefaultAction
+	}
+
+	dangerousSyscalls := map[string]bool{}
+	for _, syscall := range profile.Syscalls {
+		// Syscalls allowed only for some arguments are not reported
+		if !isAllowAction(syscall.Action) || len(syscall.Args) > 0 {
+			continue
+		}
+		names := syscall.Names
+		if syscall.Name != "" {
+			names = append(names, syscall.Name)
+		}
+		for _, name := range names {
+			if analyzer.dangerousSyscalls[name] {
+				dangerousSyscalls[name] = true
+			}
+		}
+	}
+	for name := range dangerousSyscalls {
+		analysis.dangerousSyscalls = append(analysis.dangerousSyscalls, name)
+	}
+	sort.Strings(analysis.dangerousSyscalls)
+
+	return analysis
+}
+
+func isAllowAction(action string) bool {
+	return action == actionAllow || action == actionLog
+}
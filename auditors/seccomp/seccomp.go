@@ -2,6 +2,8 @@ package seccomp
 
 import (
 	"fmt"
+	"os"
+	"path"
 	"strings"
 
 	"github.com/Shopify/kubeaudit"
@@ -21,6 +23,17 @@ const (
 	SeccompDisabledPod = "SeccompDisabledPod"
 	// SeccompDisabledContainer occurs when the container-level seccomp profile is set to a value which disables seccomp
 	SeccompDisabledContainer = "SeccompDisabledContainer"
+	// SeccompLocalhostProfileNotAllowed occurs when a localhost seccomp profile is not one of the allowed localhost
+	// profiles
+	SeccompLocalhostProfileNotAllowed = "SeccompLocalhostProfileNotAllowed"
+	// SeccompLocalhostProfilePermissive occurs when the default action of a localhost seccomp profile lets syscalls run
+	SeccompLocalhostProfilePermissive = "SeccompLocalhostProfilePermissive"
+	// SeccompLocalhostProfileAllowsDangerousSyscalls occurs when a localhost seccomp profile lets dangerous syscalls
+	// run, such as ptrace, mount or unshare
+	SeccompLocalhostProfileAllowsDangerousSyscalls = "SeccompLocalhostProfileAllowsDangerousSyscalls"
+	// SeccompLocalhostProfileInvalid occurs when a localhost seccomp profile can't be read from the localhost profile
+	// directory or parsed
+	SeccompLocalhostProfileInvalid = "SeccompLocalhostProfileInvalid"
 )
 
 const (
@@ -35,13 +48,38 @@ const (
 )
 
 // Seccomp implements Auditable
-type Seccomp struct{}
+type Seccomp struct {
+	allowedLocalhostProfiles []string
+	// profiles analyzes the localhost profiles. It is nil if there is no localhost profile directory
+	profiles *profileAnalyzer
+}
 
+// New returns a Seccomp auditor which allows every localhost profile without analyzing it
 func New() *Seccomp {
 	return &Seccomp{}
 }
 
-// Audit checks that Seccomp is enabled for all containers
+// NewWithConfig returns a Seccomp auditor which only allows the localhost profiles of the config, and analyzes them if
+// the config has a localhost profile directory
+func NewWithConfig(config Config) (*Seccomp, error) {
+	for _, pattern := range config.GetAllowedLocalhostProfiles() {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("error creating Seccomp auditor: error parsing allowed localhost profile %q: %w", pattern, err)
+		}
+	}
+
+	seccomp := &Seccomp{allowedLocalhostProfiles: config.GetAllowedLocalhostProfiles()}
+	if dir := config.GetLocalhostProfileDir(); dir != "" {
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			return nil, fmt.Errorf("error creating Seccomp auditor: %q is not a localhost profile directory", dir)
+		}
+		seccomp.profiles = newProfileAnalyzer(dir, config.GetDangerousSyscalls())
+	}
+	return seccomp, nil
+}
+
+// Audit checks that Seccomp is enabled for all containers, and that the localhost profiles are allowed and don't let
+// dangerous syscalls run
 func (a *Seccomp) Audit(resource k8s.Resource, _ []k8s.Resource) ([]*kubeaudit.AuditResult, error) {
 	var auditResults []*kubeaudit.AuditResult
 
@@ -51,9 +89,20 @@ func (a *Seccomp) Audit(resource k8s.Resource, _ []k8s.Resource) ([]*kubeaudit.A
 	auditResult := auditPod(resource)
 	auditResults = appendNotNil(auditResults, auditResult)
 
+	if podSpec := k8s.GetPodSpec(resource); podSpec != nil && !isPodSeccompProfileMissing(podSpec.SecurityContext) {
+		pendingFix := &BySettingSeccompProfile{seccompProfileType: ProfileRuntimeDefault}
+		auditResults = append(auditResults, a.auditLocalhostProfile(podSpec.SecurityContext.SeccompProfile, kubeaudit.Metadata{}, pendingFix)...)
+	}
+
 	for _, container := range k8s.GetContainers(resource) {
 		auditResult := auditContainer(container, resource)
 		auditResults = appendNotNil(auditResults, auditResult)
+
+		if !isContainerSeccompProfileMissing(container.SecurityContext) {
+			pendingFix := &BySettingSeccompProfileInContainer{container: container, seccompProfileType: ProfileRuntimeDefault}
+			metadata := kubeaudit.Metadata{"Container": container.Name}
+			auditResults = append(auditResults, a.auditLocalhostProfile(container.SecurityContext.SeccompProfile, metadata, pendingFix)...)
+		}
 	}
 
 	return auditResults, nil
@@ -176,6 +225,70 @@ func auditContainer(container *k8s.ContainerV1, resource k8s.Resource) *kubeaudi
 	return nil
 }
 
+// auditLocalhostProfile checks that a localhost profile is allowed and, if there is a localhost profile directory, that
+// it doesn't let dangerous syscalls run. The results about the pod profile have no container metadata
+func (a *Seccomp) auditLocalhostProfile(profile *apiv1.SeccompProfile, metadata kubeaudit.Metadata, pendingFix kubeaudit.PendingFix) []*kubeaudit.AuditResult {
+	if !isSeccompProfileLocalhost(profile.Type) || profile.LocalhostProfile == nil {
+		return nil
+	}
+	localhostProfile := *profile.LocalhostProfile
+
+	newAuditResult := func(rule string, severity kubeaudit.SeverityLevel, message string, resultFix kubeaudit.PendingFix) *kubeaudit.AuditResult {
+		auditResult := &kubeaudit.AuditResult{
+			Auditor:    Name,
+			Rule:       rule,
+			Severity:   severity,
+			Message:    message,
+			PendingFix: resultFix,
+			Metadata:   kubeaudit.Metadata{"LocalhostProfile": localhostProfile},
+		}
+		for k, v := range metadata {
+			auditResult.Metadata[k] = v
+		}
+		return auditResult
+	}
+
+	if len(a.allowedLocalhostProfiles) > 0 && !matchesAny(a.allowedLocalhostProfiles, localhostProfile) {
+		return []*kubeaudit.AuditResult{newAuditResult(SeccompLocalhostProfileNotAllowed, kubeaudit.Error,
+			fmt.Sprintf("Seccomp localhost profile %s is not an allowed localhost profile. It should be set to an allowed localhost profile or `%s`.", localhostProfile, ProfileRuntimeDefault),
+			pendingFix)}
+	}
+
+	if a.profiles == nil {
+		return nil
+	}
+
+	analysis := a.profiles.analyze(localhostProfile)
+	switch {
+	case analysis.err != nil:
+		return []*kubeaudit.AuditResult{newAuditResult(SeccompLocalhostProfileInvalid, kubeaudit.Warn,
+			fmt.Sprintf("Seccomp localhost profile %s could not be analyzed: %s.", localhostProfile, analysis.err), nil)}
+	case analysis.permissiveDefaultAction != "":
+		auditResult := newAuditResult(SeccompLocalhostProfilePermissive, kubeaudit.Error,
+			fmt.Sprintf("Seccomp localhost profile %s has the default action %s which lets every syscall run unless it is denied. The default action should deny syscalls, or the profile should be set to `%s`.", localhostProfile, analysis.permissiveDefaultAction, ProfileRuntimeDefault),
+			pendingFix)
+		auditResult.Metadata["DefaultAction"] = analysis.permissiveDefaultAction
+		return []*kubeaudit.AuditResult{auditResult}
+	case len(analysis.dangerousSyscalls) > 0:
+		auditResult := newAuditResult(SeccompLocalhostProfileAllowsDangerousSyscalls, kubeaudit.Warn,
+			fmt.Sprintf("Seccomp localhost profile %s lets the dangerous syscalls %s run. They should be removed from the profile unless they are needed.", localhostProfile, strings.Join(analysis.dangerousSyscalls, ", ")),
+			nil)
+		auditResult.Metadata["Syscalls"] = strings.Join(analysis.dangerousSyscalls, ", ")
+		return []*kubeaudit.AuditResult{auditResult}
+	}
+
+	return nil
+}
+
+func matchesAny(patterns []string, value string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, value); matched {
+			return true
+		}
+	}
+	return false
+}
+
 func isPodSeccompProfileMissing(securityContext *apiv1.PodSecurityContext) bool {
 	return securityContext == nil || securityContext.SeccompProfile == nil
 }
@@ -1,10 +1,13 @@
 package seccomp
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 
 	"github.com/Shopify/kubeaudit/internal/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAuditSeccomp(t *testing.T) {
@@ -28,10 +31,60 @@ func TestAuditSeccomp(t *testing.T) {
 		tc := tc
 		t.Run(tc.file, func(t *testing.T) {
 			t.Parallel()
-			test.AuditManifest(t, fixtureDir, tc.file, New(), tc.expectedErrors)
+			auditor := New()
+			test.AuditManifest(t, fixtureDir, tc.file, auditor, tc.expectedErrors)
 			if tc.testLocalMode {
-				test.AuditLocal(t, fixtureDir, tc.file, New(), strings.Split(tc.file, ".")[0], tc.expectedErrors)
+				test.AuditLocal(t, fixtureDir, tc.file, auditor, strings.Split(tc.file, ".")[0], tc.expectedErrors)
 			}
 		})
 	}
 }
+
+func TestAuditSeccompLocalhostProfiles(t *testing.T) {
+	cases := []struct {
+		file           string
+		config         Config
+		expectedErrors []string
+	}{
+		{"seccomp-localhost.yml", Config{}, nil},
+		{"seccomp-localhost.yml", Config{AllowedLocalhostProfiles: []string{"profiles/restricted.json"}}, []string{SeccompLocalhostProfileNotAllowed}},
+		{"seccomp-localhost.yml", Config{AllowedLocalhostProfiles: []string{"profiles/*.json"}}, nil},
+		{"seccomp-localhost.yml", Config{LocalhostProfileDir: fixtureDir}, []string{SeccompLocalhostProfileAllowsDangerousSyscalls}},
+		// Syscalls allowed only for some arguments are not reported
+		{"seccomp-localhost.yml", Config{LocalhostProfileDir: fixtureDir, DangerousSyscalls: []string{"mount", "setns"}}, nil},
+		{"seccomp-localhost-permissive.yml", Config{LocalhostProfileDir: fixtureDir}, []string{SeccompLocalhostProfilePermissive}},
+		{"seccomp-localhost-missing.yml", Config{LocalhostProfileDir: fixtureDir}, []string{SeccompLocalhostProfileInvalid}},
+	}
+
+	for _, tc := range cases {
+		// This line is needed because of how scopes work with parallel tests (see https://gist.github.com/posener/92a55c4cd441fc5e5e85f27bca008721)
+		tc := tc
+		t.Run(fmt.Sprintf("%s %+v", tc.file, tc.config), func(t *testing.T) {
+			t.Parallel()
+			auditor, err := NewWithConfig(tc.config)
+			require.NoError(t, err)
+			test.AuditManifest(t, fixtureDir, tc.file, auditor, tc.expectedErrors)
+		})
+	}
+
+	t.Run("Dangerous syscalls metadata", func(t *testing.T) {
+		auditor, err := NewWithConfig(Config{LocalhostProfileDir: fixtureDir})
+		require.NoError(t, err)
+		report := test.AuditManifest(t, fixtureDir, "seccomp-localhost.yml", auditor, []string{SeccompLocalhostProfileAllowsDangerousSyscalls})
+		for _, result := range report.Results() {
+			for _, auditResult := range result.GetAuditResults() {
+				assert.Equal(t, "container2", auditResult.Metadata["Container"])
+				assert.Equal(t, "profiles/dangerous.json", auditResult.Metadata["LocalhostProfile"])
+				assert.Equal(t, "ptrace, unshare", auditResult.Metadata["Syscalls"])
+			}
+		}
+	})
+
+	t.Run("Bad arguments", func(t *testing.T) {
+		_, err := NewWithConfig(Config{AllowedLocalhostProfiles: []string{"[profiles"}})
+		assert.NotNil(t, err)
+
+		_, err = NewWithConfig(Config{LocalhostProfileDir: "missing"})
+		assert.NotNil(t, err)
+	})
+}
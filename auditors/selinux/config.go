@@ -0,0 +1,23 @@
+package selinux
+
+type Config struct {
+	// AllowedTypes are SELinux types which containers can use, in addition to the default container types
+	AllowedTypes []string `yaml:"allowedTypes"`
+}
+
+// DefaultAllowedTypes are the SELinux types the Pod Security Standards baseline policy allows. An empty type uses the
+// default type of the container runtime
+var DefaultAllowedTypes = []string{
+	"",
+	"container_t",
+	"container_init_t",
+	"container_kvm_t",
+	"container_engine_t",
+}
+
+func (config *Config) GetAllowedTypes() []string {
+	if config == nil {
+		return nil
+	}
+	return config.AllowedTypes
+}
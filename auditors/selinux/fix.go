@@ -0,0 +1,44 @@
+package selinux
+
+import (
+	"fmt"
+
+	"github.com/Shopify/kubeaudit/pkg/k8s"
+)
+
+// fixSELinuxOptions removes a field from the SELinux options of a container. If the container uses the options of the
+// pod, they are copied to the container SecurityContext first so the other containers are not affected
+type fixSELinuxOptions struct {
+	container *k8s.ContainerV1
+	field     string
+	value     string
+}
+
+func (f *fixSELinuxOptions) Plan() string {
+	return fmt.Sprintf("Remove SELinux %s '%s' from seLinuxOptions in container SecurityContext for container %s", f.field, f.value, f.container.Name)
+}
+
+func (f *fixSELinuxOptions) Apply(resource k8s.Resource) []k8s.Resource {
+	if f.container.SecurityContext == nil {
+		f.container.SecurityContext = &k8s.SecurityContextV1{}
+	}
+	if f.container.SecurityContext.SELinuxOptions == nil {
+		podSpec := k8s.GetPodSpec(resource)
+		if podSpec.SecurityContext == nil || podSpec.SecurityContext.SELinuxOptions == nil {
+			return nil
+		}
+		options := *podSpec.SecurityContext.SELinuxOptions
+		f.container.SecurityContext.SELinuxOptions = &options
+	}
+
+	options := f.container.SecurityContext.SELinuxOptions
+	switch f.field {
+	case "type":
+		options.Type = ""
+	case "user":
+		options.User = ""
+	case "role":
+		options.Role = ""
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package selinux
+
+import (
+	"testing"
+
+	"github.com/Shopify/kubeaudit/internal/test"
+	"github.com/Shopify/kubeaudit/pkg/k8s"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+)
+
+func TestFixSELinux(t *testing.T) {
+	resources, report := test.FixSetup(t, fixtureDir, "selinux-options-custom.yml", New(Config{}))
+	require.Len(t, resources, 1)
+	assert.Empty(t, report.Results())
+
+	for _, container := range k8s.GetContainers(resources[0]) {
+		assert.Equal(t, &apiv1.SELinuxOptions{}, container.SecurityContext.SELinuxOptions)
+	}
+
+	// The options of the pod are fixed in the container SecurityContext, so they are kept for the containers for
+	// which they are overridden
+	resources, _ = test.FixSetup(t, fixtureDir, "selinux-options-custom-allowed.yml", New(Config{}))
+	require.Len(t, resources, 1)
+	assert.Equal(t, "spc_t", k8s.GetPodSpec(resources[0]).SecurityContext.SELinuxOptions.Type)
+	for _, container := range k8s.GetContainers(resources[0]) {
+		switch container.Name {
+		case "container":
+			assert.Equal(t, &apiv1.SELinuxOptions{}, container.SecurityContext.SELinuxOptions)
+		case "container2":
+			assert.Nil(t, container.SecurityContext)
+		}
+	}
+}
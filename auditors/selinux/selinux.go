@@ -0,0 +1,130 @@
+package selinux
+
+import (
+	"fmt"
+
+	"github.com/Shopify/kubeaudit"
+	"github.com/Shopify/kubeaudit/pkg/k8s"
+	"github.com/Shopify/kubeaudit/pkg/override"
+	apiv1 "k8s.io/api/core/v1"
+)
+
+const Name = "selinux"
+
+const (
+	// SELinuxTypeNotAllowed occurs when the SELinux type of a container is not one of the allowed types
+	SELinuxTypeNotAllowed = "SELinuxTypeNotAllowed"
+	// SELinuxUserSet occurs when a custom SELinux user is set for a container
+	SELinuxUserSet = "SELinuxUserSet"
+	// SELinuxRoleSet occurs when a custom SELinux role is set for a container
+	SELinuxRoleSet = "SELinuxRoleSet"
+)
+
+const OverrideLabel = "allow-selinux-options"
+
+// SELinux implements Auditable
+type SELinux struct {
+	allowedTypes map[string]bool
+}
+
+func New(config Config) *SELinux {
+	allowedTypes := map[string]bool{}
+	for _, seLinuxType := range append(append([]string{}, DefaultAllowedTypes...), config.GetAllowedTypes()...) {
+		allowedTypes[seLinuxType] = true
+	}
+	return &SELinux{
+		allowedTypes: allowedTypes,
+	}
+}
+
+// Audit checks that containers don't use custom SELinux users or roles, or SELinux types which are not allowed
+func (a *SELinux) Audit(resource k8s.Resource, _ []k8s.Resource) ([]*kubeaudit.AuditResult, error) {
+	var auditResults []*kubeaudit.AuditResult
+
+	podSpec := k8s.GetPodSpec(resource)
+	if podSpec == nil {
+		return nil, nil
+	}
+
+	for _, container := range k8s.GetContainers(resource) {
+		containerResults := a.auditContainer(container, podSpec)
+		auditResults = append(auditResults, override.ApplyOverrides(containerResults, Name, container.Name, resource, OverrideLabel)...)
+	}
+
+	return auditResults, nil
+}
+
+func (a *SELinux) auditContainer(container *k8s.ContainerV1, podSpec *k8s.PodSpecV1) []*kubeaudit.AuditResult {
+	var auditResults []*kubeaudit.AuditResult
+
+	options, location := getSELinuxOptions(container, podSpec)
+	if options != nil {
+		if !a.allowedTypes[options.Type] {
+			auditResults = append(auditResults, &kubeaudit.AuditResult{
+				Auditor:  Name,
+				Rule:     SELinuxTypeNotAllowed,
+				Severity: kubeaudit.Error,
+				Message:  fmt.Sprintf("SELinux type '%s' is set in the %s. It is not an allowed type so it should be removed.", options.Type, location),
+				PendingFix: &fixSELinuxOptions{
+					container: container,
+					field:     "type",
+					value:     options.Type,
+				},
+				Metadata: kubeaudit.Metadata{
+					"Container":   container.Name,
+					"SELinuxType": options.Type,
+				},
+			})
+		}
+
+		if options.User != "" {
+			auditResults = append(auditResults, &kubeaudit.AuditResult{
+				Auditor:  Name,
+				Rule:     SELinuxUserSet,
+				Severity: kubeaudit.Error,
+				Message:  fmt.Sprintf("SELinux user '%s' is set in the %s. Custom SELinux users should not be used so it should be removed.", options.User, location),
+				PendingFix: &fixSELinuxOptions{
+					container: container,
+					field:     "user",
+					value:     options.User,
+				},
+				Metadata: kubeaudit.Metadata{
+					"Container":   container.Name,
+					"SELinuxUser": options.User,
+				},
+			})
+		}
+
+		if options.Role != "" {
+			auditResults = append(auditResults, &kubeaudit.AuditResult{
+				Auditor:  Name,
+				Rule:     SELinuxRoleSet,
+				Severity: kubeaudit.Error,
+				Message:  fmt.Sprintf("SELinux role '%s' is set in the %s. Custom SELinux roles should not be used so it should be removed.", options.Role, location),
+				PendingFix: &fixSELinuxOptions{
+					container: container,
+					field:     "role",
+					value:     options.Role,
+				},
+				Metadata: kubeaudit.Metadata{
+					"Container":   container.Name,
+					"SELinuxRole": options.Role,
+				},
+			})
+		}
+	}
+
+	return auditResults
+}
+
+// getSELinuxOptions returns the SELinux options of the container and where they are set. The options in the container
+// SecurityContext take precedence over the options in the PodSecurityContext
+func getSELinuxOptions(container *k8s.ContainerV1, podSpec *k8s.PodSpecV1) (*apiv1.SELinuxOptions, string) {
+	if container.SecurityContext != nil && container.SecurityContext.SELinuxOptions != nil {
+		return container.SecurityContext.SELinuxOptions, "container SecurityContext"
+	}
+	if podSpec.SecurityContext != nil && podSpec.SecurityContext.SELinuxOptions != nil {
+		return podSpec.SecurityContext.SELinuxOptions, "PodSecurityContext"
+	}
+	return nil, ""
+}
@@ -0,0 +1,38 @@
+package selinux
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Shopify/kubeaudit"
+	"github.com/Shopify/kubeaudit/internal/test"
+	"github.com/Shopify/kubeaudit/pkg/override"
+)
+
+const fixtureDir = "fixtures"
+
+func TestAuditSELinux(t *testing.T) {
+	cases := []struct {
+		file           string
+		config         Config
+		expectedErrors []string
+	}{
+		{"selinux-options-allowed-type.yml", Config{}, []string{}},
+		{"selinux-options-custom.yml", Config{}, []string{SELinuxTypeNotAllowed, SELinuxUserSet, SELinuxRoleSet}},
+		{"selinux-options-custom.yml", Config{AllowedTypes: []string{"spc_t"}}, []string{SELinuxUserSet, SELinuxRoleSet}},
+		{"selinux-options-custom-allowed.yml", Config{}, []string{
+			SELinuxTypeNotAllowed, override.GetOverriddenResultName(SELinuxTypeNotAllowed),
+		}},
+		{"selinux-options-redundant-override.yml", Config{}, []string{kubeaudit.RedundantAuditorOverride}},
+	}
+
+	for _, tc := range cases {
+		// This line is needed because of how scopes work with parallel tests (see https://gist.github.com/posener/92a55c4cd441fc5e5e85f27bca008721)
+		tc := tc
+		t.Run(tc.file, func(t *testing.T) {
+			t.Parallel()
+			test.AuditManifest(t, fixtureDir, tc.file, New(tc.config), tc.expectedErrors)
+			test.AuditLocal(t, fixtureDir, tc.file, New(tc.config), strings.Split(tc.file, ".")[0], tc.expectedErrors)
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package serviceaccounts
+
+// DefaultMaxTokenExpirationSeconds is the default longest lifetime of projected service account tokens (24 hours)
+const DefaultMaxTokenExpirationSeconds = 24 * 60 * 60
+
+type Config struct {
+	// MaxTokenExpirationSeconds is the longest expirationSeconds allowed for projected service account tokens. If it
+	// is 0, DefaultMaxTokenExpirationSeconds is used
+	MaxTokenExpirationSeconds int64 `yaml:"maxTokenExpirationSeconds"`
+	// RequireServiceAccounts reports workloads whose ServiceAccount isn't one of the audited resources
+	RequireServiceAccounts bool `yaml:"requireServiceAccounts"`
+	// RequireSecrets reports ServiceAccounts referencing Secrets which aren't one of the audited resources
+	RequireSecrets bool `yaml:"requireSecrets"`
+}
+
+func (config *Config) GetMaxTokenExpirationSeconds() int64 {
+	if config == nil || config.MaxTokenExpirationSeconds == 0 {
+		return DefaultMaxTokenExpirationSeconds
+	}
+	return config.MaxTokenExpirationSeconds
+}
+
+func (config *Config) GetRequireServiceAccounts() bool {
+	return config != nil && config.RequireServiceAccounts
+}
+
+func (config *Config) GetRequireSecrets() bool {
+	return config != nil && config.RequireSecrets
+}
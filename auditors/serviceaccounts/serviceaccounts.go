@@ -0,0 +1,244 @@
+package serviceaccounts
+
+import (
+	"fmt"
+
+	"github.com/Shopify/kubeaudit"
+	"github.com/Shopify/kubeaudit/pkg/k8s"
+	"github.com/Shopify/kubeaudit/pkg/override"
+	v1 "k8s.io/api/core/v1"
+)
+
+const Name = "serviceaccounts"
+
+const (
+	// LegacyServiceAccountTokenSecret occurs when a Secret of type kubernetes.io/service-account-token holds a
+	// long-lived service account token
+	LegacyServiceAccountTokenSecret = "LegacyServiceAccountTokenSecret"
+	// ProjectedTokenExpirationTooLong occurs when a projected service account token volume has an expirationSeconds
+	// longer than the max token expiration
+	ProjectedTokenExpirationTooLong = "ProjectedTokenExpirationTooLong"
+	// ProjectedTokenAudienceMissing occurs when a projected service account token volume has no audience, so the
+	// token is valid for the API server
+	ProjectedTokenAudienceMissing = "ProjectedTokenAudienceMissing"
+	// ServiceAccountSecretMissing occurs when a ServiceAccount references a Secret, in secrets or imagePullSecrets,
+	// which is not in the audited resources. It is only reported if Secrets are required
+	ServiceAccountSecretMissing = "ServiceAccountSecretMissing"
+	// ServiceAccountMissing occurs when a workload uses a ServiceAccount which is not in the audited resources. It is
+	// only reported if ServiceAccounts are required
+	ServiceAccountMissing = "ServiceAccountMissing"
+)
+
+// Override labels of the rules
+const (
+	LegacyTokenSecretOverrideLabel     = "allow-legacy-service-account-token"
+	ProjectedTokenOverrideLabel        = "allow-projected-service-account-token"
+	MissingSecretOverrideLabel         = "allow-missing-secret"
+	MissingServiceAccountOverrideLabel = "allow-missing-service-account"
+)
+
+const (
+	SecretMetadataKey         = "Secret"
+	ServiceAccountMetadataKey = "ServiceAccount"
+	VolumeMetadataKey         = "Volume"
+)
+
+// ServiceAccounts implements Auditable
+type ServiceAccounts struct {
+	maxTokenExpirationSeconds int64
+	requireServiceAccounts    bool
+	requireSecrets            bool
+}
+
+func New(config Config) *ServiceAccounts {
+	return &ServiceAccounts{
+		maxTokenExpirationSeconds: config.GetMaxTokenExpirationSeconds(),
+		requireServiceAccounts:    config.GetRequireServiceAccounts(),
+		requireSecrets:            config.GetRequireSecrets(),
+	}
+}
+
+// Audit checks that service account tokens are short-lived and bound to an audience, and that the ServiceAccounts and
+// Secrets which are referenced exist
+func (a *ServiceAccounts) Audit(resource k8s.Resource, resources []k8s.Resource) ([]*kubeaudit.AuditResult, error) {
+	var auditResults []*kubeaudit.AuditResult
+
+	switch kubeType := resource.(type) {
+	case *k8s.SecretV1:
+		auditResult := auditSecret(kubeType)
+		auditResults = append(auditResults, override.ApplyOverrides([]*kubeaudit.AuditResult{auditResult}, Name, "", resource, LegacyTokenSecretOverrideLabel)...)
+	case *k8s.ServiceAccountV1:
+		// Secrets are rarely defined along with the ServiceAccounts referencing them, so they are only required to be
+		// audited along with them if requested
+		if a.requireSecrets {
+			auditResults = append(auditResults, override.ApplyOverrides(auditServiceAccountSecrets(kubeType, resources), Name, "", resource, MissingSecretOverrideLabel)...)
+		}
+	}
+
+	podSpec := k8s.GetPodSpec(resource)
+	if podSpec == nil {
+		return auditResults, nil
+	}
+
+	auditResults = append(auditResults, override.ApplyOverrides(a.auditProjectedTokens(podSpec), Name, "", resource, ProjectedTokenOverrideLabel)...)
+
+	// ServiceAccounts are often defined apart from the workloads using them (eg. in another manifest), so they are
+	// only required to be audited along with them if requested
+	if a.requireServiceAccounts {
+		auditResult := auditServiceAccountMissing(resource, podSpec, resources)
+		auditResults = append(auditResults, override.ApplyOverrides([]*kubeaudit.AuditResult{auditResult}, Name, "", resource, MissingServiceAccountOverrideLabel)...)
+	}
+
+	return auditResults, nil
+}
+
+func auditSecret(secret *k8s.SecretV1) *kubeaudit.AuditResult {
+	if secret.Type != v1.SecretTypeServiceAccountToken {
+		return nil
+	}
+
+	return &kubeaudit.AuditResult{
+		Auditor:  Name,
+		Rule:     LegacyServiceAccountTokenSecret,
+		Severity: kubeaudit.Warn,
+		Message:  fmt.Sprintf("Secret %s holds a long-lived service account token, which never expires. It should be removed and a projected service account token or the TokenRequest API should be used instead.", secret.Name),
+		Metadata: kubeaudit.Metadata{
+			SecretMetadataKey:         secret.Name,
+			ServiceAccountMetadataKey: secret.Annotations[v1.ServiceAccountNameKey],
+		},
+	}
+}
+
+func (a *ServiceAccounts) auditProjectedTokens(podSpec *k8s.PodSpecV1) []*kubeaudit.AuditResult {
+	var auditResults []*kubeaudit.AuditResult
+
+	for _, volume := range podSpec.Volumes {
+		if volume.Projected == nil || isAPIAccessVolume(volume.Projected) {
+			continue
+		}
+		for _, source := range volume.Projected.Sources {
+			token := source.ServiceAccountToken
+			if token == nil {
+				continue
+			}
+
+			if token.ExpirationSeconds != nil && *token.ExpirationSeconds > a.maxTokenExpirationSeconds {
+				auditResults = append(auditResults, &kubeaudit.AuditResult{
+					Auditor:  Name,
+					Rule:     ProjectedTokenExpirationTooLong,
+					Severity: kubeaudit.Warn,
+					Message:  fmt.Sprintf("The service account token projected in volume %s expires after %d seconds, which is longer than the max of %d seconds. expirationSeconds should be lowered.", volume.Name, *token.ExpirationSeconds, a.maxTokenExpirationSeconds),
+					Metadata: kubeaudit.Metadata{
+						VolumeMetadataKey:   volume.Name,
+						"ExpirationSeconds": fmt.Sprintf("%d", *token.ExpirationSeconds),
+					},
+				})
+			}
+
+			if token.Audience == "" {
+				auditResults = append(auditResults, &kubeaudit.AuditResult{
+					Auditor:  Name,
+					Rule:     ProjectedTokenAudienceMissing,
+					Severity: kubeaudit.Warn,
+					Message:  fmt.Sprintf("The service account token projected in volume %s has no audience, so it can be used against the API server. The audience of the service the token is sent to should be set.", volume.Name),
+					Metadata: kubeaudit.Metadata{
+						VolumeMetadataKey: volume.Name,
+					},
+				})
+			}
+		}
+	}
+
+	return auditResults
+}
+
+// isAPIAccessVolume returns true if the projected volume is the kube-api-access volume Kubernetes injects into pods
+// which automount their service account token. Its token is meant for the API server, so it has no audience
+func isAPIAccessVolume(projected *v1.ProjectedVolumeSource) bool {
+	var hasAPIServerToken, hasRootCA bool
+	for _, source := range projected.Sources {
+		if token := source.ServiceAccountToken; token != nil {
+			if token.Audience != "" || token.Path != "token" {
+				return false
+			}
+			hasAPIServerToken = true
+		}
+		if source.ConfigMap != nil && source.ConfigMap.Name == "kube-root-ca.crt" {
+			hasRootCA = true
+		}
+	}
+	return hasAPIServerToken && hasRootCA
+}
+
+func auditServiceAccountSecrets(serviceAccount *k8s.ServiceAccountV1, resources []k8s.Resource) []*kubeaudit.AuditResult {
+	var missingSecrets []string
+
+	for _, secret := range serviceAccount.Secrets {
+		namespace := secret.Namespace
+		if namespace == "" {
+			namespace = serviceAccount.Namespace
+		}
+		if !hasSecret(resources, namespace, secret.Name) {
+			missingSecrets = append(missingSecrets, secret.Name)
+		}
+	}
+	for _, secret := range serviceAccount.ImagePullSecrets {
+		if !hasSecret(resources, serviceAccount.Namespace, secret.Name) {
+			missingSecrets = append(missingSecrets, secret.Name)
+		}
+	}
+
+	var auditResults []*kubeaudit.AuditResult
+	for _, secret := range missingSecrets {
+		auditResults = append(auditResults, &kubeaudit.AuditResult{
+			Auditor:  Name,
+			Rule:     ServiceAccountSecretMissing,
+			Severity: kubeaudit.Warn,
+			Message:  fmt.Sprintf("ServiceAccount %s references the Secret %s, which doesn't exist. The reference should be removed or the Secret created.", serviceAccount.Name, secret),
+			Metadata: kubeaudit.Metadata{
+				ServiceAccountMetadataKey: serviceAccount.Name,
+				SecretMetadataKey:         secret,
+			},
+		})
+	}
+	return auditResults
+}
+
+func auditServiceAccountMissing(resource k8s.Resource, podSpec *k8s.PodSpecV1, resources []k8s.Resource) *kubeaudit.AuditResult {
+	serviceAccountName := podSpec.ServiceAccountName
+	if serviceAccountName == "" {
+		serviceAccountName = podSpec.DeprecatedServiceAccount
+	}
+
+	// The default ServiceAccount is created in every namespace
+	if serviceAccountName == "" || serviceAccountName == "default" {
+		return nil
+	}
+
+	namespace := k8s.GetObjectMeta(resource).GetNamespace()
+	for _, r := range resources {
+		if serviceAccount, ok := r.(*k8s.ServiceAccountV1); ok &&
+			serviceAccount.Namespace == namespace && serviceAccount.Name == serviceAccountName {
+			return nil
+		}
+	}
+
+	return &kubeaudit.AuditResult{
+		Auditor:  Name,
+		Rule:     ServiceAccountMissing,
+		Severity: kubeaudit.Warn,
+		Message:  fmt.Sprintf("ServiceAccount %s doesn't exist, so pods can't be created until it is. It should be created or serviceAccountName should be fixed.", serviceAccountName),
+		Metadata: kubeaudit.Metadata{
+			ServiceAccountMetadataKey: serviceAccountName,
+		},
+	}
+}
+
+func hasSecret(resources []k8s.Resource, namespace, name string) bool {
+	for _, resource := range resources {
+		if secret, ok := resource.(*k8s.SecretV1); ok && secret.Namespace == namespace && secret.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+package serviceaccounts
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Shopify/kubeaudit"
+	"github.com/Shopify/kubeaudit/internal/test"
+	"github.com/Shopify/kubeaudit/pkg/override"
+	"github.com/stretchr/testify/assert"
+)
+
+const fixtureDir = "fixtures"
+
+func TestAuditServiceAccounts(t *testing.T) {
+	cases := []struct {
+		file           string
+		config         Config
+		expectedErrors []string
+	}{
+		{"legacy-token-secret.yml", Config{}, []string{LegacyServiceAccountTokenSecret}},
+		{"legacy-token-secret-allowed.yml", Config{}, []string{override.GetOverriddenResultName(LegacyServiceAccountTokenSecret)}},
+		{"projected-token-long-expiration.yml", Config{}, []string{ProjectedTokenExpirationTooLong}},
+		{"projected-token-long-expiration.yml", Config{MaxTokenExpirationSeconds: 604800}, []string{}},
+		{"projected-token-no-audience.yml", Config{}, []string{ProjectedTokenAudienceMissing}},
+		{"projected-token-valid.yml", Config{}, []string{}},
+		{"projected-token-api-access.yml", Config{}, []string{}},
+		{"projected-token-api-access.yml", Config{MaxTokenExpirationSeconds: 600}, []string{}},
+		{"projected-token-valid.yml", Config{MaxTokenExpirationSeconds: 600}, []string{ProjectedTokenExpirationTooLong}},
+		{"projected-token-allowed.yml", Config{}, []string{
+			override.GetOverriddenResultName(ProjectedTokenExpirationTooLong),
+			override.GetOverriddenResultName(ProjectedTokenAudienceMissing),
+		}},
+		{"service-account-secret-missing.yml", Config{}, []string{}},
+		{"service-account-secret-missing.yml", Config{RequireSecrets: true}, []string{ServiceAccountSecretMissing}},
+		{"service-account-secret-missing-allowed.yml", Config{RequireSecrets: true}, []string{override.GetOverriddenResultName(ServiceAccountSecretMissing)}},
+		{"service-account-missing.yml", Config{}, []string{}},
+		{"service-account-missing.yml", Config{RequireServiceAccounts: true}, []string{ServiceAccountMissing}},
+		{"service-account-present.yml", Config{RequireServiceAccounts: true}, []string{}},
+		{"service-account-redundant-override.yml", Config{RequireServiceAccounts: true}, []string{kubeaudit.RedundantAuditorOverride}},
+	}
+
+	for _, tc := range cases {
+		// This line is needed because of how scopes work with parallel tests (see https://gist.github.com/posener/92a55c4cd441fc5e5e85f27bca008721)
+		tc := tc
+		t.Run(tc.file, func(t *testing.T) {
+			t.Parallel()
+			test.AuditManifest(t, fixtureDir, tc.file, New(tc.config), tc.expectedErrors)
+			test.AuditLocal(t, fixtureDir, tc.file, New(tc.config), strings.Split(tc.file, ".")[0], tc.expectedErrors)
+		})
+	}
+}
+
+func TestAuditServiceAccountSecretsMetadata(t *testing.T) {
+	report := test.AuditManifest(t, fixtureDir, "service-account-secret-missing.yml", New(Config{RequireSecrets: true}), []string{ServiceAccountSecretMissing})
+
+	var secrets []string
+	for _, result := range report.Results() {
+		for _, auditResult := range result.GetAuditResults() {
+			secrets = append(secrets, auditResult.Metadata[SecretMetadataKey])
+		}
+	}
+	assert.Equal(t, []string{"build-robot-credentials", "registry-credentials"}, secrets)
+}
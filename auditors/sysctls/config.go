@@ -0,0 +1,29 @@
+package sysctls
+
+type Config struct {
+	// AllowedSysctls are unsafe sysctls which pods can set, in addition to the safe sysctls. A sysctl ending with "*"
+	// allows every sysctl with that prefix (eg. "kernel.msg*")
+	AllowedSysctls []string `yaml:"allowedSysctls"`
+}
+
+// SafeSysctls are the sysctls which are namespaced and isolated between pods on the same node, so the Pod Security
+// Standards baseline policy allows them
+var SafeSysctls = []string{
+	"kernel.shm_rmid_forced",
+	"net.ipv4.ip_local_port_range",
+	"net.ipv4.ip_local_reserved_ports",
+	"net.ipv4.ip_unprivileged_port_start",
+	"net.ipv4.ping_group_range",
+	"net.ipv4.tcp_fin_timeout",
+	"net.ipv4.tcp_keepalive_intvl",
+	"net.ipv4.tcp_keepalive_probes",
+	"net.ipv4.tcp_keepalive_time",
+	"net.ipv4.tcp_syncookies",
+}
+
+func (config *Config) GetAllowedSysctls() []string {
+	if config == nil {
+		return nil
+	}
+	return config.AllowedSysctls
+}
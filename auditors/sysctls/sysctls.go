@@ -0,0 +1,82 @@
+package sysctls
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Shopify/kubeaudit"
+	"github.com/Shopify/kubeaudit/pkg/k8s"
+	"github.com/Shopify/kubeaudit/pkg/override"
+)
+
+const Name = "sysctls"
+
+const (
+	// UnsafeSysctlSet occurs when a sysctl which is neither safe nor allowed is set in the PodSecurityContext
+	UnsafeSysctlSet = "UnsafeSysctlSet"
+)
+
+const OverrideLabel = "allow-unsafe-sysctls"
+
+// SysctlMetadataKey is the audit result metadata key holding the name of the sysctl
+const SysctlMetadataKey = "Sysctl"
+
+// Sysctls implements Auditable
+type Sysctls struct {
+	allowedSysctls []string
+}
+
+func New(config Config) *Sysctls {
+	return &Sysctls{
+		allowedSysctls: append(append([]string{}, SafeSysctls...), config.GetAllowedSysctls()...),
+	}
+}
+
+// Audit checks that the pod only sets safe or allowed sysctls
+func (a *Sysctls) Audit(resource k8s.Resource, _ []k8s.Resource) ([]*kubeaudit.AuditResult, error) {
+	var auditResults []*kubeaudit.AuditResult
+
+	podSpec := k8s.GetPodSpec(resource)
+	if podSpec == nil {
+		return nil, nil
+	}
+
+	for _, sysctl := range getSysctls(podSpec) {
+		if a.isAllowed(sysctl.Name) {
+			continue
+		}
+		auditResults = append(auditResults, &kubeaudit.AuditResult{
+			Auditor:  Name,
+			Rule:     UnsafeSysctlSet,
+			Severity: kubeaudit.Error,
+			Message:  fmt.Sprintf("The unsafe sysctl %s is set in the PodSecurityContext. Unsafe sysctls can affect other pods and the node, so it should be removed.", sysctl.Name),
+			Metadata: kubeaudit.Metadata{
+				SysctlMetadataKey: sysctl.Name,
+			},
+		})
+	}
+
+	return override.ApplyOverrides(auditResults, Name, "", resource, OverrideLabel), nil
+}
+
+func getSysctls(podSpec *k8s.PodSpecV1) []k8s.SysctlV1 {
+	if podSpec.SecurityContext == nil {
+		return nil
+	}
+	return podSpec.SecurityContext.Sysctls
+}
+
+func (a *Sysctls) isAllowed(name string) bool {
+	// Sysctls can use either dots or slashes as separators
+	name = strings.ReplaceAll(name, "/", ".")
+	for _, allowed := range a.allowedSysctls {
+		if prefix, isPattern := strings.CutSuffix(allowed, "*"); isPattern {
+			if strings.HasPrefix(name, prefix) {
+				return true
+			}
+		} else if name == allowed {
+			return true
+		}
+	}
+	return false
+}
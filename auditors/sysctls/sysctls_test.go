@@ -0,0 +1,49 @@
+package sysctls
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Shopify/kubeaudit"
+	"github.com/Shopify/kubeaudit/internal/test"
+	"github.com/Shopify/kubeaudit/pkg/override"
+	"github.com/stretchr/testify/assert"
+)
+
+const fixtureDir = "fixtures"
+
+func TestAuditSysctls(t *testing.T) {
+	cases := []struct {
+		file           string
+		config         Config
+		expectedErrors []string
+	}{
+		{"sysctls-safe.yml", Config{}, []string{}},
+		{"sysctls-unsafe.yml", Config{}, []string{UnsafeSysctlSet}},
+		{"sysctls-unsafe.yml", Config{AllowedSysctls: []string{"kernel.msg*", "net.core.somaxconn"}}, []string{}},
+		{"sysctls-unsafe-allowed.yml", Config{}, []string{override.GetOverriddenResultName(UnsafeSysctlSet)}},
+		{"sysctls-redundant-override.yml", Config{}, []string{kubeaudit.RedundantAuditorOverride}},
+	}
+
+	for _, tc := range cases {
+		// This line is needed because of how scopes work with parallel tests (see https://gist.github.com/posener/92a55c4cd441fc5e5e85f27bca008721)
+		tc := tc
+		t.Run(tc.file, func(t *testing.T) {
+			t.Parallel()
+			test.AuditManifest(t, fixtureDir, tc.file, New(tc.config), tc.expectedErrors)
+			test.AuditLocal(t, fixtureDir, tc.file, New(tc.config), strings.Split(tc.file, ".")[0], tc.expectedErrors)
+		})
+	}
+}
+
+func TestAuditSysctlsMetadata(t *testing.T) {
+	report := test.AuditManifest(t, fixtureDir, "sysctls-unsafe.yml", New(Config{AllowedSysctls: []string{"net.*"}}), []string{UnsafeSysctlSet})
+
+	var sysctls []string
+	for _, result := range report.Results() {
+		for _, auditResult := range result.GetAuditResults() {
+			sysctls = append(sysctls, auditResult.Metadata[SysctlMetadataKey])
+		}
+	}
+	assert.Equal(t, []string{"kernel.msgmax"}, sysctls)
+}
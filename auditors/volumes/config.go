@@ -0,0 +1,26 @@
+package volumes
+
+type Config struct {
+	// AllowedTypes are the volume types pods can use, named like their VolumeSource field (eg. "configMap"). If it is
+	// empty, DefaultAllowedTypes are used
+	AllowedTypes []string `yaml:"allowedTypes"`
+}
+
+// DefaultAllowedTypes are the volume types the Pod Security Standards restricted policy allows
+var DefaultAllowedTypes = []string{
+	"configMap",
+	"csi",
+	"downwardAPI",
+	"emptyDir",
+	"ephemeral",
+	"persistentVolumeClaim",
+	"projected",
+	"secret",
+}
+
+func (config *Config) GetAllowedTypes() []string {
+	if config == nil || len(config.AllowedTypes) == 0 {
+		return DefaultAllowedTypes
+	}
+	return config.AllowedTypes
+}
@@ -0,0 +1,218 @@
+package volumes
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/Shopify/kubeaudit"
+	"github.com/Shopify/kubeaudit/pkg/k8s"
+	"github.com/Shopify/kubeaudit/pkg/override"
+	v1 "k8s.io/api/core/v1"
+)
+
+const Name = "volumes"
+
+const (
+	// VolumeTypeNotAllowed occurs when a pod has a volume whose type is not one of the allowed volume types
+	VolumeTypeNotAllowed = "VolumeTypeNotAllowed"
+	// EmptyDirSizeLimitMissing occurs when an emptyDir volume has no sizeLimit
+	EmptyDirSizeLimitMissing = "EmptyDirSizeLimitMissing"
+	// EmptyDirMemoryUnlimited occurs when a memory-backed emptyDir volume has no sizeLimit and is mounted by a
+	// container without a memory limit, so it can use up the memory of the node
+	EmptyDirMemoryUnlimited = "EmptyDirMemoryUnlimited"
+	// HostPathSubPathMounted occurs when a container mounts a subPath of a hostPath volume
+	HostPathSubPathMounted = "HostPathSubPathMounted"
+)
+
+const overrideLabelPrefix = "allow-volume-"
+
+const (
+	VolumeNameMetadataKey = "Volume"
+	VolumeTypeMetadataKey = "VolumeType"
+	SubPathMetadataKey    = "SubPath"
+)
+
+// volumeTypes are the JSON names of the VolumeSource fields, which are the volume types, in field order
+var volumeTypes = getVolumeTypes()
+
+// Volumes implements Auditable
+type Volumes struct {
+	allowedTypes map[string]bool
+}
+
+// New creates a volumes auditor. Volume types are named like their VolumeSource field in manifests (eg. "hostPath")
+func New(config Config) (*Volumes, error) {
+	knownTypes := map[string]bool{}
+	for _, volumeType := range volumeTypes {
+		knownTypes[volumeType] = true
+	}
+
+	allowedTypes := map[string]bool{}
+	for _, volumeType := range config.GetAllowedTypes() {
+		if !knownTypes[volumeType] {
+			return nil, fmt.Errorf("unknown volume type %q", volumeType)
+		}
+		allowedTypes[volumeType] = true
+	}
+
+	return &Volumes{
+		allowedTypes: allowedTypes,
+	}, nil
+}
+
+// Audit checks that pods only use allowed volume types, that emptyDir volumes are bounded and that containers don't
+// mount subPaths of hostPath volumes
+func (a *Volumes) Audit(resource k8s.Resource, _ []k8s.Resource) ([]*kubeaudit.AuditResult, error) {
+	var auditResults []*kubeaudit.AuditResult
+
+	podSpec := k8s.GetPodSpec(resource)
+	if podSpec == nil {
+		return nil, nil
+	}
+
+	containers := k8s.GetContainers(resource)
+
+	for i := range podSpec.Volumes {
+		volume := &podSpec.Volumes[i]
+		for _, auditResult := range a.auditVolume(volume, containers) {
+			auditResult = override.ApplyOverride(auditResult, Name, "", resource, getOverrideLabel(volume.Name))
+			if auditResult != nil {
+				auditResults = append(auditResults, auditResult)
+			}
+		}
+	}
+
+	hostPathVolumes := map[string]bool{}
+	for _, volume := range podSpec.Volumes {
+		if volume.HostPath != nil {
+			hostPathVolumes[volume.Name] = true
+		}
+	}
+
+	for _, container := range containers {
+		for _, mount := range container.VolumeMounts {
+			if !hostPathVolumes[mount.Name] {
+				continue
+			}
+			auditResult := auditSubPath(container, mount)
+			if auditResult == nil {
+				continue
+			}
+			auditResult = override.ApplyOverride(auditResult, Name, container.Name, resource, getOverrideLabel(mount.Name))
+			if auditResult != nil {
+				auditResults = append(auditResults, auditResult)
+			}
+		}
+	}
+
+	return auditResults, nil
+}
+
+func (a *Volumes) auditVolume(volume *v1.Volume, containers []*k8s.ContainerV1) []*kubeaudit.AuditResult {
+	var auditResults []*kubeaudit.AuditResult
+
+	volumeType := getVolumeType(volume)
+	if volumeType != "" && !a.allowedTypes[volumeType] {
+		auditResults = append(auditResults, &kubeaudit.AuditResult{
+			Auditor:  Name,
+			Rule:     VolumeTypeNotAllowed,
+			Severity: kubeaudit.Error,
+			Message:  fmt.Sprintf("Volume %s has the type %s, which is not an allowed volume type. It should be replaced with a volume of an allowed type.", volume.Name, volumeType),
+			Metadata: kubeaudit.Metadata{
+				VolumeNameMetadataKey: volume.Name,
+				VolumeTypeMetadataKey: volumeType,
+			},
+		})
+	}
+
+	if volume.EmptyDir == nil || volume.EmptyDir.SizeLimit != nil {
+		return auditResults
+	}
+
+	// Memory-backed emptyDir volumes are sized to the memory limits of the pod, so they are only unbounded if a
+	// container mounting them has no memory limit
+	if volume.EmptyDir.Medium == v1.StorageMediumMemory {
+		container := findUnlimitedContainer(volume.Name, containers)
+		if container == nil {
+			return auditResults
+		}
+		return append(auditResults, &kubeaudit.AuditResult{
+			Auditor:  Name,
+			Rule:     EmptyDirMemoryUnlimited,
+			Severity: kubeaudit.Error,
+			Message:  fmt.Sprintf("Memory-backed emptyDir volume %s has no sizeLimit and is mounted by container %s, which has no memory limit. It can use up the memory of the node, so a sizeLimit should be set.", volume.Name, container.Name),
+			Metadata: kubeaudit.Metadata{
+				VolumeNameMetadataKey: volume.Name,
+				"Container":           container.Name,
+			},
+		})
+	}
+
+	return append(auditResults, &kubeaudit.AuditResult{
+		Auditor:  Name,
+		Rule:     EmptyDirSizeLimitMissing,
+		Severity: kubeaudit.Warn,
+		Message:  fmt.Sprintf("emptyDir volume %s has no sizeLimit. It should be set so the volume can't fill up the node.", volume.Name),
+		Metadata: kubeaudit.Metadata{
+			VolumeNameMetadataKey: volume.Name,
+		},
+	})
+}
+
+func auditSubPath(container *k8s.ContainerV1, mount v1.VolumeMount) *kubeaudit.AuditResult {
+	subPath := mount.SubPath
+	if subPath == "" {
+		subPath = mount.SubPathExpr
+	}
+	if subPath == "" {
+		return nil
+	}
+
+	return &kubeaudit.AuditResult{
+		Auditor:  Name,
+		Rule:     HostPathSubPathMounted,
+		Severity: kubeaudit.Error,
+		Message:  fmt.Sprintf("Container %s mounts the subPath %s of hostPath volume %s. SubPaths of hostPath volumes can be used to escape to the host filesystem, so the subPath should be removed.", container.Name, subPath, mount.Name),
+		Metadata: kubeaudit.Metadata{
+			"Container":           container.Name,
+			VolumeNameMetadataKey: mount.Name,
+			SubPathMetadataKey:    subPath,
+		},
+	}
+}
+
+func findUnlimitedContainer(volumeName string, containers []*k8s.ContainerV1) *k8s.ContainerV1 {
+	for _, container := range containers {
+		for _, mount := range container.VolumeMounts {
+			if mount.Name == volumeName && container.Resources.Limits.Memory().IsZero() {
+				return container
+			}
+		}
+	}
+	return nil
+}
+
+func getOverrideLabel(volumeName string) string {
+	return overrideLabelPrefix + volumeName
+}
+
+// getVolumeType returns the name of the VolumeSource field which is set, or an empty string if none is
+func getVolumeType(volume *v1.Volume) string {
+	source := reflect.ValueOf(volume.VolumeSource)
+	for i := 0; i < source.NumField(); i++ {
+		if !source.Field(i).IsNil() {
+			return volumeTypes[i]
+		}
+	}
+	return ""
+}
+
+func getVolumeTypes() []string {
+	sourceType := reflect.TypeOf(v1.VolumeSource{})
+	types := make([]string, sourceType.NumField())
+	for i := range types {
+		types[i], _, _ = strings.Cut(sourceType.Field(i).Tag.Get("json"), ",")
+	}
+	return types
+}
@@ -0,0 +1,69 @@
+package volumes
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Shopify/kubeaudit/internal/test"
+	"github.com/Shopify/kubeaudit/pkg/override"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const fixtureDir = "fixtures"
+
+func TestAuditVolumes(t *testing.T) {
+	cases := []struct {
+		file           string
+		config         Config
+		expectedErrors []string
+	}{
+		{"volumes-allowed.yml", Config{}, []string{}},
+		{"volumes-allowed.yml", Config{AllowedTypes: []string{"emptyDir"}}, []string{VolumeTypeNotAllowed}},
+		{"volumes-host-path.yml", Config{}, []string{VolumeTypeNotAllowed, HostPathSubPathMounted}},
+		{"volumes-host-path.yml", Config{AllowedTypes: []string{"hostPath"}}, []string{HostPathSubPathMounted}},
+		{"volumes-host-path-allowed.yml", Config{}, []string{override.GetOverriddenResultName(VolumeTypeNotAllowed)}},
+		{"volumes-host-path-sub-path-allowed.yml", Config{AllowedTypes: []string{"hostPath"}}, []string{override.GetOverriddenResultName(HostPathSubPathMounted)}},
+		{"volumes-empty-dir-unbounded.yml", Config{}, []string{EmptyDirSizeLimitMissing, EmptyDirMemoryUnlimited}},
+	}
+
+	for _, tc := range cases {
+		// This line is needed because of how scopes work with parallel tests (see https://gist.github.com/posener/92a55c4cd441fc5e5e85f27bca008721)
+		tc := tc
+		t.Run(tc.file, func(t *testing.T) {
+			t.Parallel()
+			auditor, err := New(tc.config)
+			require.NoError(t, err)
+			test.AuditManifest(t, fixtureDir, tc.file, auditor, tc.expectedErrors)
+			test.AuditLocal(t, fixtureDir, tc.file, auditor, strings.Split(tc.file, ".")[0], tc.expectedErrors)
+		})
+	}
+}
+
+func TestAuditEmptyDirMetadata(t *testing.T) {
+	auditor, err := New(Config{})
+	require.NoError(t, err)
+	report := test.AuditManifest(t, fixtureDir, "volumes-empty-dir-unbounded.yml", auditor, []string{EmptyDirSizeLimitMissing, EmptyDirMemoryUnlimited})
+
+	rules := map[string][]string{}
+	for _, result := range report.Results() {
+		for _, auditResult := range result.GetAuditResults() {
+			volumeName := auditResult.Metadata[VolumeNameMetadataKey]
+			rules[volumeName] = append(rules[volumeName], auditResult.Rule)
+		}
+	}
+
+	// The tmp volume is memory-backed and mounted by a container with a memory limit, which bounds it
+	assert.Equal(t, map[string][]string{
+		"cache": {EmptyDirSizeLimitMissing},
+		"shm":   {EmptyDirMemoryUnlimited},
+	}, rules)
+}
+
+func TestNewVolumes(t *testing.T) {
+	_, err := New(Config{AllowedTypes: []string{"configMap", "hostPath"}})
+	assert.NoError(t, err)
+
+	_, err = New(Config{AllowedTypes: []string{"configmap"}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,32 @@
+package webhooks
+
+// DefaultSecurityWebhooks are the webhook name patterns of common policy engines and security webhooks
+var DefaultSecurityWebhooks = []string{"*gatekeeper*", "*kyverno*", "*kubewarden*", "*polic*", "*security*"}
+
+// DefaultMaxTimeoutSeconds is the default longest timeout allowed for webhooks, which is also the default timeout of
+// webhooks
+const DefaultMaxTimeoutSeconds = 10
+
+type Config struct {
+	// SecurityWebhooks are glob patterns (eg. "*gatekeeper*") matched against the names of webhooks and of their
+	// configurations to find the webhooks which enforce security policies. If it is empty, DefaultSecurityWebhooks is
+	// used
+	SecurityWebhooks []string `yaml:"securityWebhooks"`
+	// MaxTimeoutSeconds is the longest timeoutSeconds allowed for webhooks. If it is 0, DefaultMaxTimeoutSeconds is
+	// used
+	MaxTimeoutSeconds int32 `yaml:"maxTimeoutSeconds"`
+}
+
+func (config *Config) GetSecurityWebhooks() []string {
+	if config == nil || len(config.SecurityWebhooks) == 0 {
+		return DefaultSecurityWebhooks
+	}
+	return config.SecurityWebhooks
+}
+
+func (config *Config) GetMaxTimeoutSeconds() int32 {
+	if config == nil || config.MaxTimeoutSeconds == 0 {
+		return DefaultMaxTimeoutSeconds
+	}
+	return config.MaxTimeoutSeconds
+}
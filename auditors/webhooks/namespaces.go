@@ -0,0 +1,113 @@
+package webhooks
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Shopify/kubeaudit"
+	"github.com/Shopify/kubeaudit/pkg/k8s"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+)
+
+// getKubeSystemLabels returns the labels of the kube-system namespace if it is one of the audited resources, otherwise
+// the label every namespace has
+func getKubeSystemLabels(resources []k8s.Resource) labels.Set {
+	for _, resource := range resources {
+		if namespace, ok := resource.(*k8s.NamespaceV1); ok && namespace.Name == kubeSystemNamespace {
+			kubeSystemLabels := labels.Set{namespaceNameLabel: kubeSystemNamespace}
+			for key, value := range namespace.Labels {
+				kubeSystemLabels[key] = value
+			}
+			return kubeSystemLabels
+		}
+	}
+	return labels.Set{namespaceNameLabel: kubeSystemNamespace}
+}
+
+func auditKubeSystemExcluded(webhook webhook, kubeSystemLabels labels.Set) *kubeaudit.AuditResult {
+	if getSelector(webhook.namespaceSelector).Matches(kubeSystemLabels) {
+		return nil
+	}
+
+	return &kubeaudit.AuditResult{
+		Auditor:  Name,
+		Rule:     WebhookKubeSystemExcluded,
+		Severity: kubeaudit.Warn,
+		Message:  fmt.Sprintf("The namespaceSelector of security webhook %s doesn't match kube-system, so resources in kube-system bypass the webhook. Workloads in kube-system should be checked by the webhook, or exempted by policy instead.", webhook.name),
+		Metadata: kubeaudit.Metadata{
+			WebhookMetadataKey:  webhook.name,
+			"NamespaceSelector": metav1.FormatLabelSelector(webhook.namespaceSelector),
+		},
+	}
+}
+
+func auditNamespacesExcluded(webhook webhook) *kubeaudit.AuditResult {
+	var exclusions []string
+	for _, requirement := range getRequirements(webhook.namespaceSelector) {
+		if exclusion := getExclusion(requirement); exclusion != "" {
+			exclusions = append(exclusions, exclusion)
+		}
+	}
+
+	if len(exclusions) == 0 {
+		return nil
+	}
+
+	return &kubeaudit.AuditResult{
+		Auditor:  Name,
+		Rule:     WebhookNamespacesExcluded,
+		Severity: kubeaudit.Warn,
+		Message:  fmt.Sprintf("The namespaceSelector of security webhook %s excludes namespaces (%s), so resources in the excluded namespaces bypass the webhook. Namespaces should be exempted by policy instead.", webhook.name, strings.Join(exclusions, ",")),
+		Metadata: kubeaudit.Metadata{
+			WebhookMetadataKey:  webhook.name,
+			"NamespaceSelector": strings.Join(exclusions, ","),
+		},
+	}
+}
+
+// getSelector returns the selector of the webhook. Webhooks without a namespaceSelector match every namespace
+func getSelector(labelSelector *metav1.LabelSelector) labels.Selector {
+	if labelSelector == nil {
+		return labels.Everything()
+	}
+	selector, err := metav1.LabelSelectorAsSelector(labelSelector)
+	if err != nil {
+		// Invalid selectors are rejected by the API server
+		return labels.Everything()
+	}
+	return selector
+}
+
+func getRequirements(labelSelector *metav1.LabelSelector) labels.Requirements {
+	requirements, _ := getSelector(labelSelector).Requirements()
+	return requirements
+}
+
+// getExclusion returns the requirement if it excludes namespaces, or an empty string if it doesn't. Requirements which
+// select namespaces (eg. In or matchLabels) opt namespaces in rather than excluding them. Excluding kube-system by name
+// is reported by WebhookKubeSystemExcluded, so kube-system is removed from the excluded names
+func getExclusion(requirement labels.Requirement) string {
+	switch requirement.Operator() {
+	case selection.DoesNotExist:
+		return requirement.String()
+	case selection.NotIn:
+		if requirement.Key() != namespaceNameLabel {
+			return requirement.String()
+		}
+	default:
+		return ""
+	}
+
+	values := requirement.Values()
+	values.Delete(kubeSystemNamespace)
+	if values.Len() == 0 {
+		return ""
+	}
+	exclusion, err := labels.NewRequirement(requirement.Key(), selection.NotIn, values.List())
+	if err != nil {
+		return requirement.String()
+	}
+	return exclusion.String()
+}
@@ -0,0 +1,236 @@
+package webhooks
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+
+	"github.com/Shopify/kubeaudit"
+	"github.com/Shopify/kubeaudit/pkg/k8s"
+	"github.com/Shopify/kubeaudit/pkg/override"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const Name = "webhooks"
+
+const (
+	// WebhookFailurePolicyIgnore occurs when a security webhook has failurePolicy Ignore, so requests are admitted
+	// without being checked when the webhook is unavailable
+	WebhookFailurePolicyIgnore = "WebhookFailurePolicyIgnore"
+	// WebhookKubeSystemExcluded occurs when the namespaceSelector of a security webhook doesn't match kube-system
+	WebhookKubeSystemExcluded = "WebhookKubeSystemExcluded"
+	// WebhookNamespacesExcluded occurs when the namespaceSelector of a security webhook excludes namespaces, other
+	// than kube-system, with a NotIn or DoesNotExist requirement
+	WebhookNamespacesExcluded = "WebhookNamespacesExcluded"
+	// WebhookTimeoutTooLong occurs when a webhook has a timeoutSeconds longer than the max timeout
+	WebhookTimeoutTooLong = "WebhookTimeoutTooLong"
+	// WebhookMatchesAllResources occurs when a webhook rule matches every resource of every API group and the webhook
+	// has no objectSelector
+	WebhookMatchesAllResources = "WebhookMatchesAllResources"
+)
+
+// Override labels of the rules
+const (
+	FailurePolicyIgnoreOverrideLabel = "allow-webhook-failure-policy-ignore"
+	NamespacesExcludedOverrideLabel  = "allow-webhook-excluded-namespaces"
+	TimeoutOverrideLabel             = "allow-webhook-timeout"
+	MatchesAllResourcesOverrideLabel = "allow-webhook-matching-all-resources"
+)
+
+// WebhookMetadataKey is the audit result metadata key holding the name of the webhook
+const WebhookMetadataKey = "Webhook"
+
+const (
+	kubeSystemNamespace = "kube-system"
+	// namespaceNameLabel is set on every namespace to the name of the namespace
+	namespaceNameLabel = "kubernetes.io/metadata.name"
+	// defaultTimeoutSeconds is the timeout of webhooks which don't set timeoutSeconds
+	defaultTimeoutSeconds = 10
+)
+
+// Webhooks implements Auditable
+type Webhooks struct {
+	securityWebhooks  []string
+	maxTimeoutSeconds int32
+}
+
+func New(config Config) (*Webhooks, error) {
+	securityWebhooks := config.GetSecurityWebhooks()
+	for _, pattern := range securityWebhooks {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid security webhook pattern %q: %w", pattern, err)
+		}
+	}
+
+	return &Webhooks{
+		securityWebhooks:  securityWebhooks,
+		maxTimeoutSeconds: config.GetMaxTimeoutSeconds(),
+	}, nil
+}
+
+// webhook holds the fields shared by validating and mutating webhooks
+type webhook struct {
+	name              string
+	failurePolicy     *admissionregistrationv1.FailurePolicyType
+	namespaceSelector *metav1.LabelSelector
+	objectSelector    *metav1.LabelSelector
+	rules             []admissionregistrationv1.RuleWithOperations
+	timeoutSeconds    *int32
+}
+
+// Audit checks that security webhooks fail closed and cover every namespace, and that webhooks have short timeouts
+// and don't intercept every resource
+func (a *Webhooks) Audit(resource k8s.Resource, resources []k8s.Resource) ([]*kubeaudit.AuditResult, error) {
+	webhooks := getWebhooks(resource)
+	if webhooks == nil {
+		return nil, nil
+	}
+
+	configurationName := k8s.GetObjectMeta(resource).GetName()
+	kubeSystemLabels := getKubeSystemLabels(resources)
+
+	var failurePolicyResults, namespaceResults, timeoutResults, matchAllResults []*kubeaudit.AuditResult
+	for _, webhook := range webhooks {
+		if a.isSecurityWebhook(configurationName, webhook.name) {
+			failurePolicyResults = appendResult(failurePolicyResults, auditFailurePolicy(webhook))
+			namespaceResults = appendResult(namespaceResults, auditKubeSystemExcluded(webhook, kubeSystemLabels))
+			namespaceResults = appendResult(namespaceResults, auditNamespacesExcluded(webhook))
+		}
+		timeoutResults = appendResult(timeoutResults, a.auditTimeout(webhook))
+		matchAllResults = appendResult(matchAllResults, auditMatchesAllResources(webhook))
+	}
+
+	var auditResults []*kubeaudit.AuditResult
+	auditResults = append(auditResults, override.ApplyOverrides(failurePolicyResults, Name, "", resource, FailurePolicyIgnoreOverrideLabel)...)
+	auditResults = append(auditResults, override.ApplyOverrides(namespaceResults, Name, "", resource, NamespacesExcludedOverrideLabel)...)
+	auditResults = append(auditResults, override.ApplyOverrides(timeoutResults, Name, "", resource, TimeoutOverrideLabel)...)
+	auditResults = append(auditResults, override.ApplyOverrides(matchAllResults, Name, "", resource, MatchesAllResourcesOverrideLabel)...)
+
+	return auditResults, nil
+}
+
+func getWebhooks(resource k8s.Resource) []webhook {
+	webhooks := []webhook{}
+
+	switch kubeType := resource.(type) {
+	case *k8s.ValidatingWebhookConfigurationV1:
+		for _, w := range kubeType.Webhooks {
+			webhooks = append(webhooks, webhook{
+				name:              w.Name,
+				failurePolicy:     w.FailurePolicy,
+				namespaceSelector: w.NamespaceSelector,
+				objectSelector:    w.ObjectSelector,
+				rules:             w.Rules,
+				timeoutSeconds:    w.TimeoutSeconds,
+			})
+		}
+	case *k8s.MutatingWebhookConfigurationV1:
+		for _, w := range kubeType.Webhooks {
+			webhooks = append(webhooks, webhook{
+				name:              w.Name,
+				failurePolicy:     w.FailurePolicy,
+				namespaceSelector: w.NamespaceSelector,
+				objectSelector:    w.ObjectSelector,
+				rules:             w.Rules,
+				timeoutSeconds:    w.TimeoutSeconds,
+			})
+		}
+	default:
+		return nil
+	}
+
+	return webhooks
+}
+
+func (a *Webhooks) isSecurityWebhook(configurationName, webhookName string) bool {
+	for _, pattern := range a.securityWebhooks {
+		if matched, _ := path.Match(pattern, webhookName); matched {
+			return true
+		}
+		if matched, _ := path.Match(pattern, configurationName); matched {
+			return true
+		}
+	}
+	return false
+}
+
+func appendResult(auditResults []*kubeaudit.AuditResult, auditResult *kubeaudit.AuditResult) []*kubeaudit.AuditResult {
+	if auditResult == nil {
+		return auditResults
+	}
+	return append(auditResults, auditResult)
+}
+
+func auditFailurePolicy(webhook webhook) *kubeaudit.AuditResult {
+	// The failure policy defaults to Fail
+	if webhook.failurePolicy == nil || *webhook.failurePolicy != admissionregistrationv1.Ignore {
+		return nil
+	}
+
+	return &kubeaudit.AuditResult{
+		Auditor:  Name,
+		Rule:     WebhookFailurePolicyIgnore,
+		Severity: kubeaudit.Error,
+		Message:  fmt.Sprintf("Security webhook %s has failurePolicy Ignore, so requests are admitted without being checked when the webhook is unavailable. failurePolicy should be set to Fail.", webhook.name),
+		Metadata: kubeaudit.Metadata{
+			WebhookMetadataKey: webhook.name,
+		},
+	}
+}
+
+func (a *Webhooks) auditTimeout(webhook webhook) *kubeaudit.AuditResult {
+	timeoutSeconds := int32(defaultTimeoutSeconds)
+	if webhook.timeoutSeconds != nil {
+		timeoutSeconds = *webhook.timeoutSeconds
+	}
+	if timeoutSeconds <= a.maxTimeoutSeconds {
+		return nil
+	}
+
+	return &kubeaudit.AuditResult{
+		Auditor:  Name,
+		Rule:     WebhookTimeoutTooLong,
+		Severity: kubeaudit.Warn,
+		Message:  fmt.Sprintf("Webhook %s has a timeout of %d seconds, which is longer than the max of %d seconds. Slow webhooks delay every request they match, so timeoutSeconds should be lowered.", webhook.name, timeoutSeconds, a.maxTimeoutSeconds),
+		Metadata: kubeaudit.Metadata{
+			WebhookMetadataKey: webhook.name,
+			"TimeoutSeconds":   strconv.Itoa(int(timeoutSeconds)),
+		},
+	}
+}
+
+func auditMatchesAllResources(webhook webhook) *kubeaudit.AuditResult {
+	if !isEmptySelector(webhook.objectSelector) {
+		return nil
+	}
+
+	for _, rule := range webhook.rules {
+		if contains(rule.APIGroups, "*") && (contains(rule.Resources, "*") || contains(rule.Resources, "*/*")) {
+			return &kubeaudit.AuditResult{
+				Auditor:  Name,
+				Rule:     WebhookMatchesAllResources,
+				Severity: kubeaudit.Warn,
+				Message:  fmt.Sprintf("Webhook %s matches every resource and has no objectSelector, so it intercepts requests for resources the cluster needs to recover, including its own. The rules should be limited to the resources the webhook needs or an objectSelector should be added.", webhook.name),
+				Metadata: kubeaudit.Metadata{
+					WebhookMetadataKey: webhook.name,
+				},
+			}
+		}
+	}
+
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func isEmptySelector(selector *metav1.LabelSelector) bool {
+	return selector == nil || (len(selector.MatchLabels) == 0 && len(selector.MatchExpressions) == 0)
+}
@@ -0,0 +1,81 @@
+package webhooks
+
+import (
+	"testing"
+
+	"github.com/Shopify/kubeaudit"
+	"github.com/Shopify/kubeaudit/internal/test"
+	"github.com/Shopify/kubeaudit/pkg/override"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const fixtureDir = "fixtures"
+
+func TestAuditWebhooks(t *testing.T) {
+	cases := []struct {
+		file           string
+		config         Config
+		expectedErrors []string
+	}{
+		{"webhooks-secure.yml", Config{}, []string{}},
+		{"webhooks-failure-policy-ignore.yml", Config{}, []string{WebhookFailurePolicyIgnore}},
+		{"webhooks-failure-policy-ignore.yml", Config{SecurityWebhooks: []string{"*.example.com"}}, []string{WebhookFailurePolicyIgnore}},
+		{"webhooks-failure-policy-ignore.yml", Config{SecurityWebhooks: []string{"opa-*"}}, []string{}},
+		{"webhooks-failure-policy-ignore-allowed.yml", Config{}, []string{override.GetOverriddenResultName(WebhookFailurePolicyIgnore)}},
+		{"webhooks-kube-system-excluded.yml", Config{}, []string{WebhookKubeSystemExcluded}},
+		{"webhooks-namespaces-excluded.yml", Config{}, []string{WebhookKubeSystemExcluded, WebhookNamespacesExcluded}},
+		{"webhooks-kube-public-excluded.yml", Config{}, []string{WebhookKubeSystemExcluded, WebhookNamespacesExcluded}},
+		{"webhooks-namespaces-opt-in.yml", Config{}, []string{WebhookKubeSystemExcluded}},
+		{"webhooks-timeout.yml", Config{}, []string{WebhookTimeoutTooLong}},
+		{"webhooks-timeout.yml", Config{MaxTimeoutSeconds: 30}, []string{}},
+		{"webhooks-matches-all-resources.yml", Config{}, []string{WebhookMatchesAllResources}},
+		{"webhooks-redundant-override.yml", Config{}, []string{kubeaudit.RedundantAuditorOverride}},
+	}
+
+	for _, tc := range cases {
+		// This line is needed because of how scopes work with parallel tests (see https://gist.github.com/posener/92a55c4cd441fc5e5e85f27bca008721)
+		tc := tc
+		t.Run(tc.file, func(t *testing.T) {
+			t.Parallel()
+			auditor, err := New(tc.config)
+			require.NoError(t, err)
+			// Webhook configurations are cluster-scoped, so they aren't audited in local mode which audits a namespace
+			test.AuditManifest(t, fixtureDir, tc.file, auditor, tc.expectedErrors)
+		})
+	}
+}
+
+func TestAuditWebhooksMetadata(t *testing.T) {
+	auditor, err := New(Config{SecurityWebhooks: []string{"*.example.com"}})
+	require.NoError(t, err)
+	report := test.AuditManifest(t, fixtureDir, "webhooks-failure-policy-ignore.yml", auditor, []string{WebhookFailurePolicyIgnore})
+
+	var webhooks []string
+	for _, result := range report.Results() {
+		for _, auditResult := range result.GetAuditResults() {
+			webhooks = append(webhooks, auditResult.Metadata[WebhookMetadataKey])
+		}
+	}
+	assert.Equal(t, []string{"sidecar-injector.example.com"}, webhooks)
+}
+
+func TestAuditNamespacesExcludedMetadata(t *testing.T) {
+	auditor, err := New(Config{})
+	require.NoError(t, err)
+	report := test.AuditManifest(t, fixtureDir, "webhooks-kube-public-excluded.yml", auditor, []string{WebhookKubeSystemExcluded, WebhookNamespacesExcluded})
+
+	// kube-system is only reported by WebhookKubeSystemExcluded
+	for _, result := range report.Results() {
+		for _, auditResult := range result.GetAuditResults() {
+			if auditResult.Rule == WebhookNamespacesExcluded {
+				assert.Equal(t, "kubernetes.io/metadata.name notin (kube-public)", auditResult.Metadata["NamespaceSelector"])
+			}
+		}
+	}
+}
+
+func TestNewWebhooks(t *testing.T) {
+	_, err := New(Config{SecurityWebhooks: []string{"[gatekeeper"}})
+	assert.Error(t, err)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/Shopify/kubeaudit/config"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 var auditAllConfig struct {
@@ -19,6 +20,9 @@ func auditAll(cmd *cobra.Command, args []string) {
 	// Config options set via flags override the config file
 	conf = setConfigFromFlags(cmd, conf)
 
+	customResources = conf.GetCustomResources()
+	overrideConfig = conf.GetOverrides()
+
 	auditors, err := all.Auditors(conf)
 	if err != nil {
 		log.WithError(err).Fatal("Error creating auditors")
@@ -27,31 +31,161 @@ func auditAll(cmd *cobra.Command, args []string) {
 	runAudit(auditors...)(cmd, args)
 }
 
+// setConfigFromFlags sets the auditor configs from the flags which were set. Profiles are layered over the top-level
+// auditor configs when the config file is loaded, so the flags are set in the auditor configs of every profile as well
 func setConfigFromFlags(cmd *cobra.Command, conf config.KubeauditConfig) config.KubeauditConfig {
-	flagset := cmd.Flags()
+	setAuditorConfigFromFlags(cmd.Flags(), &conf.AuditorConfig)
+
+	profiles := make([]config.Profile, len(conf.Profiles))
+	for i, profile := range conf.Profiles {
+		setAuditorConfigFromFlags(cmd.Flags(), &profile.AuditorConfig)
+		profiles[i] = profile
+	}
+	conf.Profiles = profiles
+
+	return conf
+}
+
+func setAuditorConfigFromFlags(flagset *pflag.FlagSet, auditorConfig *config.AuditorConfig) {
 	for _, item := range []struct {
 		flag      string
 		flagVal   string
 		configVal *string
 	}{
-		{imageFlagName, imageConfig.Image, &conf.AuditorConfig.Image.Image},
-		{limitCpuFlagName, limitsConfig.CPU, &conf.AuditorConfig.Limits.CPU},
-		{limitMemoryFlagName, limitsConfig.Memory, &conf.AuditorConfig.Limits.Memory},
+		{imageFlagName, imageConfig.Image, &auditorConfig.Image.Image},
+		{limitCpuFlagName, limitsConfig.CPU, &auditorConfig.Limits.CPU},
+		{limitMemoryFlagName, limitsConfig.Memory, &auditorConfig.Limits.Memory},
+		{limitEphemeralStorageFlagName, limitsConfig.EphemeralStorage, &auditorConfig.Limits.EphemeralStorage},
+		{minCPURequestFlagName, limitsConfig.MinCPURequest, &auditorConfig.Limits.MinCPURequest},
+		{maxCPURequestFlagName, limitsConfig.MaxCPURequest, &auditorConfig.Limits.MaxCPURequest},
+		{minMemoryRequestFlagName, limitsConfig.MinMemoryRequest, &auditorConfig.Limits.MinMemoryRequest},
+		{maxMemoryRequestFlagName, limitsConfig.MaxMemoryRequest, &auditorConfig.Limits.MaxMemoryRequest},
+		{qosClassFlagName, limitsConfig.QoSClass, &auditorConfig.Limits.QoSClass},
 	} {
 		if flagset.Changed(item.flag) {
 			*item.configVal = item.flagVal
 		}
 	}
 
+	if flagset.Changed(maxCPUOvercommitFlagName) {
+		auditorConfig.Limits.MaxCPUOvercommitRatio = limitsConfig.MaxCPUOvercommitRatio
+	}
+
+	if flagset.Changed(maxMemoryOvercommitFlagName) {
+		auditorConfig.Limits.MaxMemoryOvercommitRatio = limitsConfig.MaxMemoryOvercommitRatio
+	}
+
+	if flagset.Changed(requireRequestsFlagName) {
+		auditorConfig.Limits.RequireRequests = limitsConfig.RequireRequests
+	}
+
+	if flagset.Changed(requireLimitRangeFlagName) {
+		auditorConfig.Limits.RequireLimitRange = limitsConfig.RequireLimitRange
+	}
+
+	if flagset.Changed(requireResourceQuotaFlagName) {
+		auditorConfig.Limits.RequireResourceQuota = limitsConfig.RequireResourceQuota
+	}
+
 	if flagset.Changed(capsAddFlagName) {
-		conf.AuditorConfig.Capabilities.AllowAddList = capabilitiesConfig.AllowAddList
+		auditorConfig.Capabilities.AllowAddList = capabilitiesConfig.AllowAddList
 	}
 
 	if flagset.Changed(sensitivePathsFlagName) {
-		conf.AuditorConfig.Mounts.SensitivePaths = mountsConfig.SensitivePaths
+		auditorConfig.Mounts.SensitivePaths = mountsConfig.SensitivePaths
 	}
 
-	return conf
+	if flagset.Changed(allowedPathsFlagName) {
+		auditorConfig.Mounts.AllowedPaths = mountsConfig.AllowedPaths
+	}
+
+	if flagset.Changed(allowedUIDsFlagName) {
+		auditorConfig.NonRoot.AllowedUIDs = nonRootConfig.AllowedUIDs
+	}
+
+	if flagset.Changed(allowedGIDsFlagName) {
+		auditorConfig.NonRoot.AllowedGIDs = nonRootConfig.AllowedGIDs
+	}
+
+	if flagset.Changed(defaultUIDFlagName) {
+		auditorConfig.NonRoot.DefaultUID = nonRootConfig.DefaultUID
+	}
+
+	if flagset.Changed(defaultGIDFlagName) {
+		auditorConfig.NonRoot.DefaultGID = nonRootConfig.DefaultGID
+	}
+
+	if flagset.Changed(ociLayoutDirFlagName) {
+		auditorConfig.NonRoot.OCILayoutDir = nonRootConfig.OCILayoutDir
+	}
+
+	if flagset.Changed(imageConfigCacheFlagName) {
+		auditorConfig.NonRoot.ImageConfigCache = nonRootConfig.ImageConfigCache
+	}
+
+	if flagset.Changed(imagePlatformFlagName) {
+		auditorConfig.NonRoot.ImagePlatform = nonRootConfig.ImagePlatform
+	}
+
+	if flagset.Changed(allowedAppArmorProfilesFlagName) {
+		auditorConfig.AppArmor.AllowedLocalhostProfiles = appArmorConfig.AllowedLocalhostProfiles
+	}
+
+	if flagset.Changed(appArmorSecurityContextFlagName) {
+		auditorConfig.AppArmor.UseSecurityContext = appArmorConfig.UseSecurityContext
+	}
+
+	if flagset.Changed(allowedSeccompProfilesFlagName) {
+		auditorConfig.Seccomp.AllowedLocalhostProfiles = seccompConfig.AllowedLocalhostProfiles
+	}
+
+	if flagset.Changed(seccompProfileDirFlagName) {
+		auditorConfig.Seccomp.LocalhostProfileDir = seccompConfig.LocalhostProfileDir
+	}
+
+	if flagset.Changed(allowedHostPortsFlagName) {
+		auditorConfig.HostPorts.AllowedPorts = hostPortsConfig.AllowedPorts
+	}
+
+	if flagset.Changed(allowedSysctlsFlagName) {
+		auditorConfig.Sysctls.AllowedSysctls = sysctlsConfig.AllowedSysctls
+	}
+
+	if flagset.Changed(allowedSELinuxTypesFlagName) {
+		auditorConfig.SELinux.AllowedTypes = seLinuxConfig.AllowedTypes
+	}
+
+	if flagset.Changed(maxTokenExpirationFlagName) {
+		auditorConfig.ServiceAccounts.MaxTokenExpirationSeconds = serviceAccountsConfig.MaxTokenExpirationSeconds
+	}
+
+	if flagset.Changed(requireServiceAccountsFlagName) {
+		auditorConfig.ServiceAccounts.RequireServiceAccounts = serviceAccountsConfig.RequireServiceAccounts
+	}
+
+	if flagset.Changed(requireSecretsFlagName) {
+		auditorConfig.ServiceAccounts.RequireSecrets = serviceAccountsConfig.RequireSecrets
+	}
+
+	if flagset.Changed(allowedVolumeTypesFlagName) {
+		auditorConfig.Volumes.AllowedTypes = volumesConfig.AllowedTypes
+	}
+
+	if flagset.Changed(securityWebhooksFlagName) {
+		auditorConfig.Webhooks.SecurityWebhooks = webhooksConfig.SecurityWebhooks
+	}
+
+	if flagset.Changed(maxWebhookTimeoutFlagName) {
+		auditorConfig.Webhooks.MaxTimeoutSeconds = webhooksConfig.MaxTimeoutSeconds
+	}
+
+	if flagset.Changed(probeSeveritiesFlagName) {
+		auditorConfig.Probes.Severities = probesConfig.Severities
+	}
+
+	if flagset.Changed(productionNamespacesFlagName) {
+		auditorConfig.Availability.ProductionNamespaces = availabilityConfig.ProductionNamespaces
+	}
 }
 
 func loadKubeAuditConfigFromFile(configFile string) config.KubeauditConfig {
@@ -93,4 +227,15 @@ func init() {
 	setLimitsFlags(auditAllCmd)
 	setCapabilitiesFlags(auditAllCmd)
 	setPathsFlags(auditAllCmd)
+	setAppArmorFlags(auditAllCmd)
+	setSeccompFlags(auditAllCmd)
+	setNonRootFlags(auditAllCmd)
+	setHostPortsFlags(auditAllCmd)
+	setSysctlsFlags(auditAllCmd)
+	setSELinuxFlags(auditAllCmd)
+	setServiceAccountsFlags(auditAllCmd)
+	setVolumesFlags(auditAllCmd)
+	setWebhooksFlags(auditAllCmd)
+	setProbesFlags(auditAllCmd)
+	setAvailabilityFlags(auditAllCmd)
 }
@@ -2,21 +2,54 @@ package commands
 
 import (
 	"github.com/Shopify/kubeaudit/auditors/apparmor"
+	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
 
+const (
+	allowedAppArmorProfilesFlagName = "allowed-apparmor-profiles"
+	appArmorSecurityContextFlagName = "apparmor-security-context"
+)
+
+var appArmorConfig apparmor.Config
+
 var appArmorCmd = &cobra.Command{
 	Use:   "apparmor",
 	Short: "Audit containers running without AppArmor",
 	Long: `This command determines which containers are running without AppArmor enabled.
 
-An ERROR result is generated when a container has AppArmor disabled or misconfigured.
+An ERROR result is generated when a container has AppArmor disabled or misconfigured, or uses a localhost profile which
+doesn't match the '--allowed-apparmor-profiles' argument. Profile names can be glob patterns.
+
+A WARN result is generated when deprecated apparmor annotations are used instead of the AppArmor profile in the
+container SecurityContext.
+
+Missing AppArmor profiles are fixed by adding apparmor annotations. With '--apparmor-security-context', they are fixed
+by setting the AppArmor profile in the pod SecurityContext instead and deprecated annotations are migrated to the
+container SecurityContext. This requires Kubernetes 1.30 or later. The autofix command reads the 'useSecurityContext'
+option of the apparmor auditor config instead.
 
 Example usage:
-kubeaudit apparmor`,
-	Run: runAudit(apparmor.New()),
+kubeaudit apparmor
+kubeaudit apparmor --allowed-apparmor-profiles "k8s-*"
+kubeaudit apparmor --apparmor-security-context`,
+	Run: func(cmd *cobra.Command, args []string) {
+		auditor, err := apparmor.NewWithConfig(appArmorConfig)
+		if err != nil {
+			log.WithError(err).Fatal("failed to create apparmor auditor")
+		}
+		runAudit(auditor)(cmd, args)
+	},
 }
 
 func init() {
 	RootCmd.AddCommand(appArmorCmd)
+	setAppArmorFlags(appArmorCmd)
+}
+
+func setAppArmorFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVar(&appArmorConfig.AllowedLocalhostProfiles, allowedAppArmorProfilesFlagName, nil,
+		"List of approved localhost AppArmor profiles. If it is empty, every localhost profile is allowed")
+	cmd.Flags().BoolVar(&appArmorConfig.UseSecurityContext, appArmorSecurityContextFlagName, false,
+		"Fix AppArmor profiles in the SecurityContext instead of with annotations (requires Kubernetes 1.30 or later)")
 }
@@ -4,6 +4,7 @@ import (
 	"io"
 	"os"
 
+	"github.com/Shopify/kubeaudit"
 	"github.com/Shopify/kubeaudit/auditors/all"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -19,6 +20,9 @@ func autofix(cmd *cobra.Command, args []string) {
 
 	conf = setConfigFromFlags(cmd, conf)
 
+	customResources = conf.GetCustomResources()
+	overrideConfig = conf.GetOverrides()
+
 	auditors, err := all.Auditors(conf)
 
 	if err != nil {
@@ -27,9 +31,16 @@ func autofix(cmd *cobra.Command, args []string) {
 
 	report := getReport(auditors...)
 
+	writeFixedManifest(report, autofixConfig.outFile)
+}
+
+// writeFixedManifest fixes the manifest being audited and writes it to outFile, or modifies the manifest in-place if
+// outFile is empty
+func writeFixedManifest(report *kubeaudit.Report, outFile string) {
 	var f io.Writer
-	if autofixConfig.outFile != "" {
-		f, err = os.Create(autofixConfig.outFile)
+	var err error
+	if outFile != "" {
+		f, err = os.Create(outFile)
 		if err != nil {
 			log.WithError(err).Fatal("Error opening out file")
 		}
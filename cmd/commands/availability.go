@@ -0,0 +1,48 @@
+package commands
+
+import (
+	"github.com/Shopify/kubeaudit/auditors/availability"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+const productionNamespacesFlagName = "production-namespaces"
+
+var availabilityConfig availability.Config
+
+var availabilityCmd = &cobra.Command{
+	Use:   "availability",
+	Short: "Audit workloads which can become unavailable during disruptions",
+	Long: `This command determines which Deployments and StatefulSets can become unavailable when a pod or node is lost, and
+which PodDisruptionBudgets are misconfigured.
+
+A WARN result is generated when a workload in a namespace specified with the '--production-namespaces' argument has a
+single replica. Namespaces can be glob patterns. If no namespaces are provided, no namespace is checked. Workloads
+scaled by a HorizontalPodAutoscaler are skipped.
+
+A WARN result is generated when a workload with multiple replicas isn't selected by a PodDisruptionBudget, or has
+neither topology spread constraints nor pod anti-affinity.
+
+A WARN result is generated when a PodDisruptionBudget doesn't allow any disruption or doesn't select any pods.
+
+Example usage:
+kubeaudit availability
+kubeaudit availability --production-namespaces "prod-*,default"`,
+	Run: func(cmd *cobra.Command, args []string) {
+		auditor, err := availability.New(availabilityConfig)
+		if err != nil {
+			log.WithError(err).Fatal("failed to create availability auditor")
+		}
+		runAudit(auditor)(cmd, args)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(availabilityCmd)
+	setAvailabilityFlags(availabilityCmd)
+}
+
+func setAvailabilityFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVar(&availabilityConfig.ProductionNamespaces, productionNamespacesFlagName, nil,
+		"List of namespaces in which workloads need more than one replica. If it is empty, no namespace is checked")
+}
@@ -0,0 +1,28 @@
+package commands
+
+import (
+	"github.com/Shopify/kubeaudit/auditors/exposure"
+	"github.com/spf13/cobra"
+)
+
+var exposureCmd = &cobra.Command{
+	Use:   "exposure",
+	Short: "Audit workloads exposed outside of the cluster",
+	Long: `This command determines which workloads are exposed outside of the cluster through LoadBalancer, NodePort or
+externalIPs Services, Ingresses, Gateway API routes, host ports or the host network.
+
+A WARN result is generated when:
+- an exposed workload isn't selected by a NetworkPolicy restricting its ingress traffic
+- a LoadBalancer Service doesn't set loadBalancerSourceRanges
+- an Ingress doesn't configure TLS
+
+An INFO result is generated when an exposed workload is protected by a NetworkPolicy.
+
+Example usage:
+kubeaudit exposure`,
+	Run: runAudit(exposure.New()),
+}
+
+func init() {
+	RootCmd.AddCommand(exposureCmd)
+}
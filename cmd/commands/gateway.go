@@ -0,0 +1,27 @@
+package commands
+
+import (
+	"github.com/Shopify/kubeaudit/auditors/gateway"
+	"github.com/spf13/cobra"
+)
+
+var gatewayCmd = &cobra.Command{
+	Use:   "gateway",
+	Short: "Audit Ingresses, Gateways and HTTPRoutes",
+	Long: `This command audits networking.k8s.io/v1 Ingresses and gateway.networking.k8s.io/v1beta1 Gateways and HTTPRoutes.
+
+A WARN result is generated when:
+- an Ingress host isn't covered by a tls entry
+- a Gateway listener serves HTTP traffic without TLS
+- an Ingress, Gateway listener or HTTPRoute uses a wildcard hostname
+- a Gateway listener accepts routes from every namespace
+- a TLS Secret or backend Service isn't part of the audited resources
+
+Example usage:
+kubeaudit gateway`,
+	Run: runAudit(gateway.New()),
+}
+
+func init() {
+	RootCmd.AddCommand(gatewayCmd)
+}
@@ -0,0 +1,36 @@
+package commands
+
+import (
+	"github.com/Shopify/kubeaudit/auditors/hostports"
+	"github.com/spf13/cobra"
+)
+
+const allowedHostPortsFlagName = "allowed-host-ports"
+
+var hostPortsConfig hostports.Config
+
+var hostPortsCmd = &cobra.Command{
+	Use:   "hostports",
+	Short: "Audit containers which bind ports to the host",
+	Long: `This command determines which containers bind container ports to a port of the host.
+
+An ERROR result is generated when a container port has 'hostPort' set to a port which isn't specified with the
+'--allowed-host-ports' argument.
+
+Example usage:
+kubeaudit hostports
+kubeaudit hostports --allowed-host-ports 80,443`,
+	Run: func(cmd *cobra.Command, args []string) {
+		runAudit(hostports.New(hostPortsConfig))(cmd, args)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(hostPortsCmd)
+	setHostPortsFlags(hostPortsCmd)
+}
+
+func setHostPortsFlags(cmd *cobra.Command) {
+	cmd.Flags().Int32SliceVar(&hostPortsConfig.AllowedPorts, allowedHostPortsFlagName, nil,
+		"List of host ports containers are allowed to use")
+}
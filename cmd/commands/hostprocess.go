@@ -0,0 +1,23 @@
+package commands
+
+import (
+	"github.com/Shopify/kubeaudit/auditors/hostprocess"
+	"github.com/spf13/cobra"
+)
+
+var hostProcessCmd = &cobra.Command{
+	Use:   "hostprocess",
+	Short: "Audit Windows containers running as HostProcess containers",
+	Long: `This command determines which Windows containers run as HostProcess containers.
+
+An ERROR result is generated when a container has 'windowsOptions.hostProcess = true' in its SecurityContext or
+  inherits it from its pod SecurityContext.
+
+Example usage:
+kubeaudit hostprocess`,
+	Run: runAudit(hostprocess.New()),
+}
+
+func init() {
+	RootCmd.AddCommand(hostProcessCmd)
+}
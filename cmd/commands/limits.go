@@ -9,26 +9,46 @@ import (
 var limitsConfig limits.Config
 
 const (
-	limitMemoryFlagName = "memory"
-	limitCpuFlagName    = "cpu"
+	limitMemoryFlagName           = "memory"
+	limitCpuFlagName              = "cpu"
+	limitEphemeralStorageFlagName = "ephemeral-storage"
+	minCPURequestFlagName         = "min-cpu-request"
+	maxCPURequestFlagName         = "max-cpu-request"
+	minMemoryRequestFlagName      = "min-memory-request"
+	maxMemoryRequestFlagName      = "max-memory-request"
+	requireRequestsFlagName       = "require-requests"
+	requireLimitRangeFlagName     = "require-limitrange"
+	requireResourceQuotaFlagName  = "require-resourcequota"
+	qosClassFlagName              = "qos-class"
+	maxCPUOvercommitFlagName      = "max-cpu-overcommit-ratio"
+	maxMemoryOvercommitFlagName   = "max-memory-overcommit-ratio"
 )
 
 var limitsCmd = &cobra.Command{
 	Use:   "limits",
-	Short: "Audit containers exceeding a specified CPU or memory limit",
-	Long: `This command determines which containers exceed the specified CPU and memory limits, or have no limits configured.
+	Short: "Audit containers exceeding a specified CPU or memory limit or request",
+	Long: `This command determines which containers exceed the specified CPU and memory limits and requests, or have no
+limits or requests configured.
 
 A WARN result is generated for each of the following cases:
   - The CPU limit is unset or exceeds the specified CPU limit
   - The memory limit is unset or exceeds the specified memory limit
+  - The ephemeral-storage limit is unset or exceeds the specified ephemeral-storage limit (if one is specified)
+  - The CPU or memory request is outside of the specified min and max requests, or is unset (if requests are required)
+  - The ratio of the CPU or memory limit to the request exceeds the specified max overcommit ratio
+  - The pod QoS class is lower than the specified QoS class
+  - The namespace has no LimitRange or ResourceQuota (if they are required)
 
 Example usage:
 kubeaudit limits
-kubeaudit limits --cpu 500m --memory 256Mi`,
+kubeaudit limits --cpu 500m --memory 256Mi
+kubeaudit limits --min-cpu-request 100m --max-memory-overcommit-ratio 2
+kubeaudit limits --require-requests --require-limitrange --require-resourcequota
+kubeaudit limits --qos-class Guaranteed`,
 	Run: func(cmd *cobra.Command, args []string) {
 		auditor, err := limits.New(limitsConfig)
 		if err != nil {
-			log.Fatal("failed to create limits auditor")
+			log.WithError(err).Fatal("failed to create limits auditor")
 		}
 		runAudit(auditor)(cmd, args)
 	},
@@ -37,6 +57,17 @@ kubeaudit limits --cpu 500m --memory 256Mi`,
 func setLimitsFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVar(&limitsConfig.CPU, limitCpuFlagName, "", "Max CPU limit")
 	cmd.Flags().StringVar(&limitsConfig.Memory, limitMemoryFlagName, "", "Max memory limit")
+	cmd.Flags().StringVar(&limitsConfig.EphemeralStorage, limitEphemeralStorageFlagName, "", "Max ephemeral-storage limit")
+	cmd.Flags().StringVar(&limitsConfig.MinCPURequest, minCPURequestFlagName, "", "Min CPU request")
+	cmd.Flags().StringVar(&limitsConfig.MaxCPURequest, maxCPURequestFlagName, "", "Max CPU request")
+	cmd.Flags().StringVar(&limitsConfig.MinMemoryRequest, minMemoryRequestFlagName, "", "Min memory request")
+	cmd.Flags().StringVar(&limitsConfig.MaxMemoryRequest, maxMemoryRequestFlagName, "", "Max memory request")
+	cmd.Flags().BoolVar(&limitsConfig.RequireRequests, requireRequestsFlagName, false, "Report containers which don't set their CPU or memory requests")
+	cmd.Flags().BoolVar(&limitsConfig.RequireLimitRange, requireLimitRangeFlagName, false, "Report namespaces without a LimitRange")
+	cmd.Flags().BoolVar(&limitsConfig.RequireResourceQuota, requireResourceQuotaFlagName, false, "Report namespaces without a ResourceQuota")
+	cmd.Flags().StringVar(&limitsConfig.QoSClass, qosClassFlagName, "", "Lowest allowed pod QoS class (Burstable or Guaranteed)")
+	cmd.Flags().Float64Var(&limitsConfig.MaxCPUOvercommitRatio, maxCPUOvercommitFlagName, 0, "Max ratio of the CPU limit to the CPU request")
+	cmd.Flags().Float64Var(&limitsConfig.MaxMemoryOvercommitRatio, maxMemoryOvercommitFlagName, 0, "Max ratio of the memory limit to the memory request")
 }
 
 func init() {
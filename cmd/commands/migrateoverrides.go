@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"os"
+
+	"github.com/Shopify/kubeaudit/pkg/override"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var migrateOverridesConfig struct {
+	outFile string
+	plan    bool
+}
+
+func migrateOverrides(cmd *cobra.Command, args []string) {
+	if rootConfig.manifest == "" || rootConfig.manifest == "-" {
+		log.Fatal("migrate-overrides requires a manifest file set with the -f flag")
+	}
+
+	report := getReport(override.NewMigration())
+
+	if migrateOverridesConfig.plan {
+		report.PrintPlan(os.Stdout)
+		return
+	}
+
+	writeFixedManifest(report, migrateOverridesConfig.outFile)
+}
+
+var migrateOverridesCmd = &cobra.Command{
+	Use:   "migrate-overrides",
+	Short: "Migrate deprecated kubernetes.io override labels to kubeaudit.io",
+	Long: `This command renames the deprecated container.audit.kubernetes.io/, audit.kubernetes.io/pod. and
+audit.kubernetes.io/namespace. override labels in a manifest to their kubeaudit.io equivalents. If no output file is
+specified using the -o flag, the source manifest will be modified. Use the --plan flag to list the labels which would
+be renamed without modifying any files.
+
+Example usage:
+kubeaudit migrate-overrides -f /path/to/yaml
+kubeaudit migrate-overrides -f /path/to/yaml -o /path/for/migrated/yaml
+kubeaudit migrate-overrides -f /path/to/yaml --plan
+`,
+	Run: migrateOverrides,
+}
+
+func init() {
+	RootCmd.AddCommand(migrateOverridesCmd)
+	migrateOverridesCmd.Flags().StringVarP(&migrateOverridesConfig.outFile, "outfile", "o", "", "File to write migrated manifest to")
+	migrateOverridesCmd.Flags().BoolVar(&migrateOverridesConfig.plan, "plan", false, "List the labels which would be renamed without modifying the manifest")
+}
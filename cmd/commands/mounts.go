@@ -3,12 +3,17 @@ package commands
 import (
 	"bytes"
 	"fmt"
+	"strings"
+
 	"github.com/Shopify/kubeaudit/auditors/mounts"
+	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
-	"strings"
 )
 
-const sensitivePathsFlagName = "denyPathsList"
+const (
+	sensitivePathsFlagName = "denyPathsList"
+	allowedPathsFlagName   = "allowPathsList"
+)
 
 var mountsConfig mounts.Config
 
@@ -19,12 +24,18 @@ var mountsCmd = &cobra.Command{
 paths are used:
 %s
 
-A WARN result is generated when a container mounts one or more paths specified with the '--denyPathsList' argument.
+An ERROR result is generated when a container mounts one or more paths specified with the '--denyPathsList' argument,
+or a path under one of them. Paths can be glob patterns. Read-only mounts generate a WARN result instead. Paths
+specified with the '--allowPathsList' argument, and the paths under them, are never reported.
 
 Example usage:
 kubeaudit mounts --denyPathsList "%s"`, formatPathsList(), strings.Join(mounts.DefaultSensitivePaths[:3], ",")),
 	Run: func(cmd *cobra.Command, args []string) {
-		runAudit(mounts.New(mountsConfig))(cmd, args)
+		auditor, err := mounts.New(mountsConfig)
+		if err != nil {
+			log.WithError(err).Fatal("failed to create mounts auditor")
+		}
+		runAudit(auditor)(cmd, args)
 	},
 }
 
@@ -36,6 +47,8 @@ func init() {
 func setPathsFlags(cmd *cobra.Command) {
 	cmd.Flags().StringSliceVarP(&mountsConfig.SensitivePaths, sensitivePathsFlagName, "d", mounts.DefaultSensitivePaths,
 		"List of sensitive paths that shouldn't be mounted")
+	cmd.Flags().StringSliceVarP(&mountsConfig.AllowedPaths, allowedPathsFlagName, "a", nil,
+		"List of safe paths which can be mounted even if they are under a sensitive path")
 }
 
 func formatPathsList() string {
@@ -1,15 +1,71 @@
 package commands
 
 import (
+	"os"
+
+	"github.com/Shopify/kubeaudit"
 	"github.com/Shopify/kubeaudit/auditors/netpols"
+	"github.com/Shopify/kubeaudit/pkg/k8s"
+	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
 
+var netpolsConfig struct {
+	graphFile   string
+	graphFormat string
+}
+
+const (
+	graphFileFlagName   = "graph"
+	graphFormatFlagName = "graph-format"
+)
+
+func auditNetworkPolicies(cmd *cobra.Command, args []string) {
+	if netpolsConfig.graphFormat != "dot" && netpolsConfig.graphFormat != "json" {
+		log.Fatalf("Invalid graph format %q. It should be one of \"dot\" or \"json\"", netpolsConfig.graphFormat)
+	}
+
+	report := getReport(netpols.New())
+
+	if netpolsConfig.graphFile != "" {
+		writeNetworkGraph(report, netpolsConfig.graphFile, netpolsConfig.graphFormat)
+	}
+
+	printAuditReport(report)
+}
+
+// writeNetworkGraph writes the graph of the network flows allowed between the audited resources
+func writeNetworkGraph(report *kubeaudit.Report, graphFile, graphFormat string) {
+	var resources []k8s.Resource
+	for _, result := range report.RawResults() {
+		if resource := result.GetResource().Object(); resource != nil {
+			resources = append(resources, resource)
+		}
+	}
+	graph := netpols.NewGraph(resources)
+
+	f, err := os.Create(graphFile)
+	if err != nil {
+		log.WithError(err).Fatal("Error opening graph file")
+	}
+	defer f.Close()
+
+	if graphFormat == "json" {
+		err = graph.WriteJSON(f)
+	} else {
+		err = graph.WriteDOT(f)
+	}
+	if err != nil {
+		log.WithError(err).Fatal("Error writing graph file")
+	}
+}
+
 var netpolsCmd = &cobra.Command{
 	Use:     "netpols",
 	Aliases: []string{"np"},
-	Short:   "Audit namespaces that do not have a default deny network policy",
-	Long: `This command determines which namespaces do not have a default deny NetworkPolicy.
+	Short:   "Audit namespaces that do not have a default deny network policy and workloads reachable by too many peers",
+	Long: `This command determines which namespaces do not have a default deny NetworkPolicy, and evaluates the
+NetworkPolicies against the audited pods and namespaces to find which workloads can talk to which.
 
 An ERROR result is generated for each of the followign cases:
   - A namespace does not have a default deny-all-ingress NetworkPolicy
@@ -18,13 +74,22 @@ An ERROR result is generated for each of the followign cases:
 A WARN result is generated for each of the following cases:
   - A namespace has a default allow-all-ingress NetworkPolicy
   - A namespace has a default allow-all-egress NetworkPolicy
+  - A workload accepts ingress traffic from every namespace
+  - A workload accepts ingress traffic from any IP address (0.0.0.0/0)
+  - A workload's egress traffic isn't restricted
+  - A NetworkPolicy doesn't select any of the audited pods in its namespace
 
+Use the --graph flag to export the graph of allowed flows between workloads and ipBlocks as DOT or JSON.
 
 Example usage:
-kubeaudit netpols`,
-	Run: runAudit(netpols.New()),
+kubeaudit netpols
+kubeaudit netpols -f /path/to/yaml --graph flows.dot
+kubeaudit netpols --graph flows.json --graph-format json`,
+	Run: auditNetworkPolicies,
 }
 
 func init() {
 	RootCmd.AddCommand(netpolsCmd)
+	netpolsCmd.Flags().StringVar(&netpolsConfig.graphFile, graphFileFlagName, "", "File to write the graph of allowed network flows to")
+	netpolsCmd.Flags().StringVar(&netpolsConfig.graphFormat, graphFormatFlagName, "dot", "Format of the graph of allowed network flows (one of \"dot\", \"json\")")
 }
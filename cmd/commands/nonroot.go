@@ -2,22 +2,73 @@ package commands
 
 import (
 	"github.com/Shopify/kubeaudit/auditors/nonroot"
+	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
 
+const (
+	allowedUIDsFlagName = "allowed-uids"
+	allowedGIDsFlagName = "allowed-gids"
+	defaultUIDFlagName  = "default-uid"
+	defaultGIDFlagName  = "default-gid"
+
+	ociLayoutDirFlagName     = "oci-layout-dir"
+	imageConfigCacheFlagName = "image-config-cache"
+	imagePlatformFlagName    = "image-platform"
+)
+
+var nonRootConfig nonroot.Config
+
 var runAsNonRootCmd = &cobra.Command{
 	Use:   "nonroot",
 	Short: "Audit containers allowing for root user",
-	Long: `This command determines which containers are allowed to run as root (uid=0).
+	Long: `This command determines which containers are allowed to run as root (uid=0) or with the root group (gid=0).
 
 An ERROR result is generated when container does not have 'runAsNonRoot = true' or if a root user (UID 0) is explicitly 
   set using 'runAsUser' in either its container SecurityContext or its pod SecurityContext.
 
+An ERROR result is generated when the root group (GID 0) is set using 'runAsGroup', 'fsGroup' or 'supplementalGroups'.
+
+An ERROR result is generated when a UID or GID is outside the ranges specified with the '--allowed-uids' and
+'--allowed-gids' arguments. Ranges have the form 'min-max' or are a single ID.
+
+Autofix sets 'runAsUser' and 'runAsGroup' to the IDs specified with the '--default-uid' and '--default-gid' arguments.
+
+If '--oci-layout-dir' or '--image-config-cache' is set, the USER of the images of containers which don't set
+'runAsUser' is checked. A WARN result is generated when 'runAsNonRoot = true' is set but the image runs as root or has
+a non-numeric USER, since the container will fail to start. The '--image-platform' argument sets the platform picked
+from multi-platform images (eg. "linux/arm64"). Images whose config can't be read are logged and not checked.
+
 Example usage:
-kubeaudit nonroot`,
-	Run: runAudit(nonroot.New()),
+kubeaudit nonroot
+kubeaudit nonroot --allowed-uids "10000-65535" --default-uid 10000`,
+	Run: func(cmd *cobra.Command, args []string) {
+		auditor, err := nonroot.NewWithConfig(nonRootConfig)
+		if err != nil {
+			log.WithError(err).Fatal("failed to create nonroot auditor")
+		}
+		runAudit(auditor)(cmd, args)
+	},
 }
 
 func init() {
 	RootCmd.AddCommand(runAsNonRootCmd)
+	setNonRootFlags(runAsNonRootCmd)
+}
+
+func setNonRootFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVar(&nonRootConfig.AllowedUIDs, allowedUIDsFlagName, nil,
+		"List of allowed UID ranges (eg. 10000-65535). If it is empty, every non-root UID is allowed")
+	cmd.Flags().StringSliceVar(&nonRootConfig.AllowedGIDs, allowedGIDsFlagName, nil,
+		"List of allowed GID ranges (eg. 10000-65535). If it is empty, every non-root GID is allowed")
+	cmd.Flags().Int64Var(&nonRootConfig.DefaultUID, defaultUIDFlagName, 0,
+		"UID autofix sets runAsUser to")
+	cmd.Flags().Int64Var(&nonRootConfig.DefaultGID, defaultGIDFlagName, 0,
+		"GID autofix sets runAsGroup and fsGroup to")
+	cmd.Flags().StringVar(&nonRootConfig.OCILayoutDir, ociLayoutDirFlagName, "",
+		"OCI image layout directory used to look up the USER of container images")
+	cmd.Flags().StringVar(&nonRootConfig.ImageConfigCache, imageConfigCacheFlagName, "",
+		"YAML or JSON file mapping image references to their OCI image config, used to look up the USER of container images")
+	cmd.Flags().StringVar(&nonRootConfig.ImagePlatform, imagePlatformFlagName, "",
+		"Platform whose image is picked from multi-platform images in the OCI image layout (default is linux/amd64)")
 }
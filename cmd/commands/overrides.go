@@ -0,0 +1,54 @@
+package commands
+
+import (
+	"github.com/Shopify/kubeaudit/auditors/all"
+	"github.com/Shopify/kubeaudit/pkg/override"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var overridesConfig struct {
+	configFile string
+}
+
+func listOverrides(cmd *cobra.Command, args []string) {
+	conf := loadKubeAuditConfigFromFile(overridesConfig.configFile)
+
+	customResources = conf.GetCustomResources()
+	overrideConfig = conf.GetOverrides()
+
+	auditors, err := all.Auditors(conf)
+	if err != nil {
+		log.WithError(err).Fatal("Error creating auditors")
+	}
+
+	report := getReport(auditors...)
+
+	printReport(override.Inventory(report))
+}
+
+var overridesCmd = &cobra.Command{
+	Use:   "overrides",
+	Short: "List override labels and annotations",
+	Long: `This command lists every kubeaudit.io and deprecated kubernetes.io override label and annotation along with
+the auditor it disables, the container it applies to, its reason and whether it is still needed. Overrides are
+reported with one of the following results:
+
+ActiveOverride: the override disables an auditor which found security issues (info)
+RedundantAuditorOverride: the auditor found no security issues so the override should be removed (warning)
+IgnoredOverride: the override is expired, invalid or lacks a required justification (warning)
+UnknownOverride: none of the enabled auditors use the override (warning)
+
+Use the -k flag followed by the path to the kubeaudit config file to choose the enabled auditors.
+
+Example usage:
+kubeaudit overrides
+kubeaudit overrides -f /path/to/yaml
+kubeaudit overrides -f /path/to/yaml -p json`,
+	Run: listOverrides,
+}
+
+func init() {
+	RootCmd.AddCommand(overridesCmd)
+	overridesCmd.Flags().StringVarP(&overridesConfig.configFile, "kconfig", "k", "", "Path to kubeaudit config")
+}
@@ -0,0 +1,47 @@
+package commands
+
+import (
+	"github.com/Shopify/kubeaudit/auditors/probes"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+const probeSeveritiesFlagName = "probe-severities"
+
+var probesConfig probes.Config
+
+var probesCmd = &cobra.Command{
+	Use:   "probes",
+	Short: "Audit containers without liveness or readiness probes",
+	Long: `This command determines which long-running containers have no liveness or readiness probes, and which probes are
+misconfigured. Init containers and the containers of pods which run to completion, such as Jobs, are not required to
+have probes.
+
+A WARN result is generated when a container has no liveness or readiness probe, when a probe runs its command with a
+shell, when the liveness and readiness probes of a container run the same check, and when the timeout of a probe is
+longer than its period.
+
+The severity of each rule can be changed with the '--probe-severities' argument, to one of "error", "warning", "info"
+or "off", which disables the rule.
+
+Example usage:
+kubeaudit probes
+kubeaudit probes --probe-severities "LivenessProbeMissing=error,ProbeExecShell=off"`,
+	Run: func(cmd *cobra.Command, args []string) {
+		auditor, err := probes.New(probesConfig)
+		if err != nil {
+			log.WithError(err).Fatal("failed to create probes auditor")
+		}
+		runAudit(auditor)(cmd, args)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(probesCmd)
+	setProbesFlags(probesCmd)
+}
+
+func setProbesFlags(cmd *cobra.Command) {
+	cmd.Flags().StringToStringVar(&probesConfig.Severities, probeSeveritiesFlagName, nil,
+		"Severity of each rule (eg. LivenessProbeMissing=error). The severity can be error, warning, info or off")
+}
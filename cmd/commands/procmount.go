@@ -0,0 +1,22 @@
+package commands
+
+import (
+	"github.com/Shopify/kubeaudit/auditors/procmount"
+	"github.com/spf13/cobra"
+)
+
+var procMountCmd = &cobra.Command{
+	Use:   "procmount",
+	Short: "Audit containers with an unmasked /proc mount",
+	Long: `This command determines which containers have an unmasked /proc mount.
+
+An ERROR result is generated when a container has 'procMount = Unmasked' in its SecurityContext.
+
+Example usage:
+kubeaudit procmount`,
+	Run: runAudit(procmount.New()),
+}
+
+func init() {
+	RootCmd.AddCommand(procMountCmd)
+}
@@ -15,6 +15,8 @@ import (
 	"github.com/Shopify/kubeaudit/internal/color"
 	"github.com/Shopify/kubeaudit/internal/k8sinternal"
 	"github.com/Shopify/kubeaudit/internal/sarif"
+	"github.com/Shopify/kubeaudit/pkg/k8s"
+	"github.com/Shopify/kubeaudit/pkg/override"
 )
 
 var rootConfig rootFlags
@@ -29,8 +31,20 @@ type rootFlags struct {
 	exitCode         int
 	includeGenerated bool
 	noColor          bool
+	exceptionsFile   string
+	strict           bool
 }
 
+// exceptions are loaded from the exceptions file, if any, when kubeaudit is initialized
+var exceptions *override.Exceptions
+
+// customResources are set from the config file by the commands which load one
+var customResources []k8s.CustomResourceConfig
+
+// overrideConfig is set from the config file by the commands which load one, so exceptions are validated like
+// override labels and annotations
+var overrideConfig override.Config
+
 // RootCmd defines the shell command usage for kubeaudit.
 var RootCmd = &cobra.Command{
 	Use:   "kubeaudit",
@@ -60,6 +74,8 @@ func init() {
 	RootCmd.PersistentFlags().BoolVarP(&rootConfig.includeGenerated, "includegenerated", "g", false, "Include generated resources in scan  (eg. pods generated by deployments).")
 	RootCmd.PersistentFlags().BoolVar(&rootConfig.noColor, "no-color", false, "Don't produce colored output.")
 	RootCmd.PersistentFlags().StringVarP(&rootConfig.manifest, "manifest", "f", "", "Path to the yaml configuration to audit. Only used in manifest mode.")
+	RootCmd.PersistentFlags().StringVar(&rootConfig.exceptionsFile, "exceptions", "", "Path to an exceptions file listing audit results to override for resources which can't be labelled")
+	RootCmd.PersistentFlags().BoolVar(&rootConfig.strict, "strict", false, "Exit with the exit code if some resources could not be audited (eg. unknown kinds or forbidden API groups)")
 	RootCmd.PersistentFlags().IntVarP(&rootConfig.exitCode, "exitcode", "e", 2, "Exit code to use if there are results with severity of \"error\". Conventionally, 0 is used for success and all non-zero codes for an error.")
 }
 
@@ -73,40 +89,65 @@ var KubeauditLogLevels = map[string]kubeaudit.SeverityLevel{
 
 func runAudit(auditable ...kubeaudit.Auditable) func(cmd *cobra.Command, args []string) {
 	return func(cmd *cobra.Command, args []string) {
-		report := getReport(auditable...)
+		printAuditReport(getReport(auditable...))
+	}
+}
 
-		fmt.Fprintln(os.Stderr, color.Yellow("\n[WARNING]: kubernetes.io for override labels will soon be deprecated. Please, update them to use kubeaudit.io instead."))
+// printAuditReport prints the warnings about deprecated override labels and unused exceptions before printing the
+// report
+func printAuditReport(report *kubeaudit.Report) {
+	fmt.Fprintln(os.Stderr, color.Yellow("\n[WARNING]: kubernetes.io for override labels will soon be deprecated. Please, update them to use kubeaudit.io instead. Manifests can be migrated with kubeaudit migrate-overrides -f /path/to/yaml"))
 
-		printOptions := []kubeaudit.PrintOption{
-			kubeaudit.WithMinSeverity(KubeauditLogLevels[strings.ToLower(rootConfig.minSeverity)]),
-			kubeaudit.WithColor(!rootConfig.noColor),
+	if exceptions != nil {
+		for _, exception := range exceptions.Unmatched() {
+			fmt.Fprintln(os.Stderr, color.Yellow(fmt.Sprintf("[WARNING]: Exception %s did not match any audit results. It should be removed from the exceptions file.", exception)))
 		}
+	}
 
-		switch rootConfig.format {
-		case "sarif":
-			sarifReport, err := sarif.Create(report)
-			if err != nil {
-				log.WithError(err).Fatal("Error generating the SARIF output")
-			}
-			if err := sarifReport.PrettyWrite(os.Stdout); err != nil {
-				log.WithError(err).Fatal("Error executing SARIF PrettyWrite")
-			}
+	printReport(report)
+}
 
-			if report.HasErrors() {
-				os.Exit(rootConfig.exitCode)
-			}
-			return
-		case "json":
-			printOptions = append(printOptions, kubeaudit.WithFormatter(&log.JSONFormatter{}))
-		case "logrus":
-			printOptions = append(printOptions, kubeaudit.WithFormatter(&log.TextFormatter{}))
+// printReport prints the report in the format set by the root flags and exits with the configured exit code if the
+// report has errors
+func printReport(report *kubeaudit.Report) {
+	printOptions := []kubeaudit.PrintOption{
+		kubeaudit.WithMinSeverity(KubeauditLogLevels[strings.ToLower(rootConfig.minSeverity)]),
+		kubeaudit.WithColor(!rootConfig.noColor),
+	}
+
+	switch rootConfig.format {
+	case "sarif":
+		sarifReport, err := sarif.Create(report)
+		if err != nil {
+			log.WithError(err).Fatal("Error generating the SARIF output")
+		}
+		if err := sarifReport.PrettyWrite(os.Stdout); err != nil {
+			log.WithError(err).Fatal("Error executing SARIF PrettyWrite")
 		}
 
-		report.PrintResults(printOptions...)
+		exitOnReportFailure(report)
+		return
+	case "json":
+		printOptions = append(printOptions, kubeaudit.WithFormatter(&log.JSONFormatter{}))
+	case "logrus":
+		printOptions = append(printOptions, kubeaudit.WithFormatter(&log.TextFormatter{}))
+	}
 
-		if report.HasErrors() {
-			os.Exit(rootConfig.exitCode)
-		}
+	report.PrintResults(printOptions...)
+
+	exitOnReportFailure(report)
+}
+
+// exitOnReportFailure exits with the configured exit code if the report has errors, or if some resources could not be
+// audited in strict mode
+func exitOnReportFailure(report *kubeaudit.Report) {
+	if report.HasErrors() {
+		os.Exit(rootConfig.exitCode)
+	}
+
+	if rootConfig.strict && !report.IsCoverageComplete() {
+		fmt.Fprintln(os.Stderr, color.Red(fmt.Sprintf("[ERROR]: %d resources or API groups could not be audited (strict mode)", len(report.Coverage()))))
+		os.Exit(rootConfig.exitCode)
 	}
 }
 
@@ -158,10 +199,32 @@ func initKubeaudit(auditable ...kubeaudit.Auditable) *kubeaudit.Kubeaudit {
 		auditable = allAuditors
 	}
 
-	auditor, err := kubeaudit.New(auditable)
+	if rootConfig.exceptionsFile != "" {
+		exceptions = loadExceptionsFromFile(rootConfig.exceptionsFile)
+		for i, auditor := range auditable {
+			auditable[i] = exceptions.Auditor(auditor)
+		}
+	}
+
+	auditor, err := kubeaudit.New(auditable, kubeaudit.WithCustomResources(customResources))
 	if err != nil {
 		log.WithError(err).Fatal("Error creating auditor")
 	}
 
 	return auditor
 }
+
+func loadExceptionsFromFile(exceptionsFile string) *override.Exceptions {
+	reader, err := os.Open(exceptionsFile)
+	if err != nil {
+		log.WithError(err).Fatal("Unable to open exceptions file ", exceptionsFile)
+	}
+	defer reader.Close()
+
+	exceptions, err := override.NewExceptions(reader, overrideConfig)
+	if err != nil {
+		log.WithError(err).Fatal("Error parsing exceptions file ", exceptionsFile)
+	}
+
+	return exceptions
+}
@@ -2,21 +2,49 @@ package commands
 
 import (
 	"github.com/Shopify/kubeaudit/auditors/seccomp"
+	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
 
+const (
+	allowedSeccompProfilesFlagName = "allowed-seccomp-profiles"
+	seccompProfileDirFlagName      = "seccomp-profile-dir"
+)
+
+var seccompConfig seccomp.Config
+
 var seccompCmd = &cobra.Command{
 	Use:   "seccomp",
 	Short: "Audit containers running without Seccomp",
 	Long: `This command determines which containers are running without Seccomp enabled.
 
-An ERROR result is generated when a container has Seccomp disabled or misconfigured.
+An ERROR result is generated when a container has Seccomp disabled or misconfigured, or uses a localhost profile which
+doesn't match the '--allowed-seccomp-profiles' argument. Profile paths can be glob patterns.
+
+If '--seccomp-profile-dir' is set to a local copy of the kubelet seccomp profile root, the localhost profiles are read
+from it. An ERROR result is generated when a profile allows every syscall by default, and a WARN result is generated
+when it allows dangerous syscalls or can't be read.
 
 Example usage:
-kubeaudit seccomp`,
-	Run: runAudit(seccomp.New()),
+kubeaudit seccomp
+kubeaudit seccomp --allowed-seccomp-profiles "profiles/*.json" --seccomp-profile-dir /var/lib/kubelet/seccomp`,
+	Run: func(cmd *cobra.Command, args []string) {
+		auditor, err := seccomp.NewWithConfig(seccompConfig)
+		if err != nil {
+			log.WithError(err).Fatal("failed to create seccomp auditor")
+		}
+		runAudit(auditor)(cmd, args)
+	},
 }
 
 func init() {
 	RootCmd.AddCommand(seccompCmd)
+	setSeccompFlags(seccompCmd)
+}
+
+func setSeccompFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVar(&seccompConfig.AllowedLocalhostProfiles, allowedSeccompProfilesFlagName, nil,
+		"List of approved localhost seccomp profiles. If it is empty, every localhost profile is allowed")
+	cmd.Flags().StringVar(&seccompConfig.LocalhostProfileDir, seccompProfileDirFlagName, "",
+		"Local copy of the kubelet seccomp profile root used to analyze localhost profiles")
 }
@@ -0,0 +1,38 @@
+package commands
+
+import (
+	"github.com/Shopify/kubeaudit/auditors/selinux"
+	"github.com/spf13/cobra"
+)
+
+const allowedSELinuxTypesFlagName = "allowed-selinux-types"
+
+var seLinuxConfig selinux.Config
+
+var seLinuxCmd = &cobra.Command{
+	Use:   "selinux",
+	Short: "Audit containers with custom SELinux options",
+	Long: `This command determines which containers use custom SELinux options.
+
+An ERROR result is generated when a container has a custom SELinux user or role, or an SELinux type which is neither
+one of the container types allowed by the Pod Security Standards baseline policy nor specified with the
+'--allowed-selinux-types' argument. The SELinux options of the pod SecurityContext apply to containers which don't set
+their own.
+
+Example usage:
+kubeaudit selinux
+kubeaudit selinux --allowed-selinux-types "spc_t"`,
+	Run: func(cmd *cobra.Command, args []string) {
+		runAudit(selinux.New(seLinuxConfig))(cmd, args)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(seLinuxCmd)
+	setSELinuxFlags(seLinuxCmd)
+}
+
+func setSELinuxFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVar(&seLinuxConfig.AllowedTypes, allowedSELinuxTypesFlagName, nil,
+		"List of SELinux types containers are allowed to use, in addition to the default container types")
+}
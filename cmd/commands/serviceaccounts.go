@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/Shopify/kubeaudit/auditors/serviceaccounts"
+	"github.com/spf13/cobra"
+)
+
+const (
+	maxTokenExpirationFlagName     = "max-token-expiration"
+	requireServiceAccountsFlagName = "require-serviceaccounts"
+	requireSecretsFlagName         = "require-secrets"
+)
+
+var serviceAccountsConfig serviceaccounts.Config
+
+var serviceAccountsCmd = &cobra.Command{
+	Use:   "serviceaccounts",
+	Short: "Audit service account tokens and references to ServiceAccounts and Secrets",
+	Long: fmt.Sprintf(`This command determines which service account tokens are long-lived or can be used against the API server, and
+which ServiceAccounts and Secrets are referenced but missing.
+
+A WARN result is generated when a Secret of type 'kubernetes.io/service-account-token' holds a legacy long-lived
+service account token.
+
+A WARN result is generated when a projected service account token volume has an 'expirationSeconds' longer than the
+'--max-token-expiration' argument (%d seconds by default), or has no 'audience'.
+
+If the '--require-secrets' flag is set, a WARN result is generated when a ServiceAccount references a Secret in
+'secrets' or 'imagePullSecrets' which isn't one of the audited resources.
+
+If the '--require-serviceaccounts' flag is set, a WARN result is generated when a workload uses a ServiceAccount,
+other than 'default', which isn't one of the audited resources.
+
+Example usage:
+kubeaudit serviceaccounts
+kubeaudit serviceaccounts --max-token-expiration 3600
+kubeaudit serviceaccounts --require-serviceaccounts --require-secrets`, serviceaccounts.DefaultMaxTokenExpirationSeconds),
+	Run: func(cmd *cobra.Command, args []string) {
+		runAudit(serviceaccounts.New(serviceAccountsConfig))(cmd, args)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(serviceAccountsCmd)
+	setServiceAccountsFlags(serviceAccountsCmd)
+}
+
+func setServiceAccountsFlags(cmd *cobra.Command) {
+	cmd.Flags().Int64Var(&serviceAccountsConfig.MaxTokenExpirationSeconds, maxTokenExpirationFlagName, 0,
+		"Longest expirationSeconds, in seconds, allowed for projected service account tokens (default 86400)")
+	cmd.Flags().BoolVar(&serviceAccountsConfig.RequireServiceAccounts, requireServiceAccountsFlagName, false,
+		"Report workloads whose ServiceAccount isn't one of the audited resources")
+	cmd.Flags().BoolVar(&serviceAccountsConfig.RequireSecrets, requireSecretsFlagName, false,
+		"Report ServiceAccounts referencing Secrets which aren't one of the audited resources")
+}
@@ -0,0 +1,37 @@
+package commands
+
+import (
+	"github.com/Shopify/kubeaudit/auditors/sysctls"
+	"github.com/spf13/cobra"
+)
+
+const allowedSysctlsFlagName = "allowed-sysctls"
+
+var sysctlsConfig sysctls.Config
+
+var sysctlsCmd = &cobra.Command{
+	Use:   "sysctls",
+	Short: "Audit pods which set unsafe sysctls",
+	Long: `This command determines which pods set unsafe sysctls.
+
+An ERROR result is generated when a pod sets a sysctl which is neither one of the safe sysctls allowed by the Pod
+Security Standards baseline policy nor specified with the '--allowed-sysctls' argument. Sysctls ending with '*' allow
+every sysctl with that prefix.
+
+Example usage:
+kubeaudit sysctls
+kubeaudit sysctls --allowed-sysctls "kernel.msg*,net.core.somaxconn"`,
+	Run: func(cmd *cobra.Command, args []string) {
+		runAudit(sysctls.New(sysctlsConfig))(cmd, args)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(sysctlsCmd)
+	setSysctlsFlags(sysctlsCmd)
+}
+
+func setSysctlsFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVar(&sysctlsConfig.AllowedSysctls, allowedSysctlsFlagName, nil,
+		"List of unsafe sysctls pods are allowed to set, in addition to the safe sysctls")
+}
@@ -0,0 +1,50 @@
+package commands
+
+import (
+	"strings"
+
+	"github.com/Shopify/kubeaudit/auditors/volumes"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+const allowedVolumeTypesFlagName = "allowed-volume-types"
+
+var volumesConfig volumes.Config
+
+var volumesCmd = &cobra.Command{
+	Use:   "volumes",
+	Short: "Audit pods using volume types which are not allowed",
+	Long: `This command determines which pods use volume types which are not allowed, emptyDir volumes without a size
+limit and subPath mounts of hostPath volumes.
+
+An ERROR result is generated when a pod has a volume whose type isn't specified with the '--allowed-volume-types'
+argument. If no volume types are provided, the volume types allowed by the Pod Security Standards restricted policy
+are used: ` + strings.Join(volumes.DefaultAllowedTypes, ", ") + `.
+
+A WARN result is generated when an emptyDir volume has no 'sizeLimit'. An ERROR result is generated instead if the
+emptyDir volume is memory-backed and mounted by a container without a memory limit.
+
+An ERROR result is generated when a container mounts a 'subPath' or 'subPathExpr' of a hostPath volume.
+
+Example usage:
+kubeaudit volumes
+kubeaudit volumes --allowed-volume-types "configMap,secret,emptyDir,hostPath"`,
+	Run: func(cmd *cobra.Command, args []string) {
+		auditor, err := volumes.New(volumesConfig)
+		if err != nil {
+			log.WithError(err).Fatal("failed to create volumes auditor")
+		}
+		runAudit(auditor)(cmd, args)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(volumesCmd)
+	setVolumesFlags(volumesCmd)
+}
+
+func setVolumesFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVar(&volumesConfig.AllowedTypes, allowedVolumeTypesFlagName, nil,
+		"List of volume types pods are allowed to use (eg. configMap). If it is empty, the restricted volume types are allowed")
+}
@@ -0,0 +1,59 @@
+package commands
+
+import (
+	"strings"
+
+	"github.com/Shopify/kubeaudit/auditors/webhooks"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+const (
+	securityWebhooksFlagName  = "security-webhooks"
+	maxWebhookTimeoutFlagName = "max-webhook-timeout"
+)
+
+var webhooksConfig webhooks.Config
+
+var webhooksCmd = &cobra.Command{
+	Use:   "webhooks",
+	Short: "Audit misconfigured admission webhooks",
+	Long: `This command determines which ValidatingWebhookConfigurations and MutatingWebhookConfigurations have
+misconfigured webhooks.
+
+Security webhooks are the webhooks whose name, or the name of their configuration, matches one of the patterns
+specified with the '--security-webhooks' argument. If no patterns are provided, these patterns are used: ` + strings.Join(webhooks.DefaultSecurityWebhooks, ", ") + `.
+
+An ERROR result is generated when a security webhook has 'failurePolicy: Ignore'.
+
+A WARN result is generated when the namespaceSelector of a security webhook excludes kube-system or other namespaces.
+
+A WARN result is generated when a webhook has a 'timeoutSeconds' longer than the '--max-webhook-timeout' argument
+(10 seconds by default).
+
+A WARN result is generated when a webhook rule matches every resource of every API group and the webhook has no
+objectSelector.
+
+Example usage:
+kubeaudit webhooks
+kubeaudit webhooks --security-webhooks "*gatekeeper*,*.policy.example.com" --max-webhook-timeout 5`,
+	Run: func(cmd *cobra.Command, args []string) {
+		auditor, err := webhooks.New(webhooksConfig)
+		if err != nil {
+			log.WithError(err).Fatal("failed to create webhooks auditor")
+		}
+		runAudit(auditor)(cmd, args)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(webhooksCmd)
+	setWebhooksFlags(webhooksCmd)
+}
+
+func setWebhooksFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVar(&webhooksConfig.SecurityWebhooks, securityWebhooksFlagName, nil,
+		"List of webhook name patterns of the webhooks enforcing security policies (eg. *gatekeeper*)")
+	cmd.Flags().Int32Var(&webhooksConfig.MaxTimeoutSeconds, maxWebhookTimeoutFlagName, 0,
+		"Longest timeoutSeconds, in seconds, allowed for webhooks (default 10)")
+}
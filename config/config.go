@@ -1,14 +1,28 @@
 package config
 
 import (
+	"fmt"
 	"io"
 
+	"github.com/Shopify/kubeaudit/auditors/apparmor"
+	"github.com/Shopify/kubeaudit/auditors/availability"
 	"github.com/Shopify/kubeaudit/auditors/deprecatedapis"
+	"github.com/Shopify/kubeaudit/auditors/hostports"
 	"github.com/Shopify/kubeaudit/auditors/mounts"
+	"github.com/Shopify/kubeaudit/auditors/nonroot"
+	"github.com/Shopify/kubeaudit/auditors/probes"
+	"github.com/Shopify/kubeaudit/auditors/seccomp"
+	"github.com/Shopify/kubeaudit/auditors/selinux"
+	"github.com/Shopify/kubeaudit/auditors/serviceaccounts"
+	"github.com/Shopify/kubeaudit/auditors/sysctls"
+	"github.com/Shopify/kubeaudit/auditors/volumes"
+	"github.com/Shopify/kubeaudit/auditors/webhooks"
 
 	"github.com/Shopify/kubeaudit/auditors/capabilities"
 	"github.com/Shopify/kubeaudit/auditors/image"
 	"github.com/Shopify/kubeaudit/auditors/limits"
+	"github.com/Shopify/kubeaudit/pkg/k8s"
+	"github.com/Shopify/kubeaudit/pkg/override"
 	"gopkg.in/yaml.v3"
 )
 
@@ -24,12 +38,55 @@ func New(configData io.Reader) (KubeauditConfig, error) {
 		return KubeauditConfig{}, err
 	}
 
+	err = layerProfileConfigs(configBytes, &config)
+	if err != nil {
+		return KubeauditConfig{}, err
+	}
+
 	return config, nil
 }
 
+// layerProfileConfigs decodes the top-level auditor configs and then the auditor configs of each profile into a new
+// AuditorConfig, so only the values which are set in the profile replace the top-level values. Decoding into a copy of
+// the top-level configs instead would share, and merge into, their maps and slices
+func layerProfileConfigs(configBytes []byte, config *KubeauditConfig) error {
+	rawConfig := struct {
+		AuditorConfig yaml.Node `yaml:"auditors"`
+		Profiles      []struct {
+			AuditorConfig yaml.Node `yaml:"auditors"`
+		} `yaml:"profiles"`
+	}{}
+	if err := yaml.Unmarshal(configBytes, &rawConfig); err != nil {
+		return err
+	}
+
+	for i, rawProfile := range rawConfig.Profiles {
+		profileConfig := AuditorConfig{}
+		for _, node := range []yaml.Node{rawConfig.AuditorConfig, rawProfile.AuditorConfig} {
+			if node.IsZero() {
+				continue
+			}
+			if err := node.Decode(&profileConfig); err != nil {
+				return fmt.Errorf("error parsing auditor config for profile %q: %w", config.Profiles[i].Name, err)
+			}
+		}
+		config.Profiles[i].AuditorConfig = profileConfig
+	}
+
+	return nil
+}
+
 type KubeauditConfig struct {
 	EnabledAuditors map[string]bool `yaml:"enabledAuditors"`
 	AuditorConfig   AuditorConfig   `yaml:"auditors"`
+	// Profiles are checked in order and the first profile matching a resource supplies the auditor configs for it.
+	// Resources which don't match any profile use AuditorConfig
+	Profiles []Profile `yaml:"profiles"`
+	// Overrides configures how override labels and annotations are validated
+	Overrides override.Config `yaml:"overrides"`
+	// CustomResources maps custom resource kinds to the paths of their pod templates so they are audited like
+	// built-in workloads
+	CustomResources []k8s.CustomResourceConfig `yaml:"customResources"`
 }
 
 func (conf *KubeauditConfig) GetEnabledAuditors() map[string]bool {
@@ -46,10 +103,42 @@ func (conf *KubeauditConfig) GetAuditorConfigs() AuditorConfig {
 	return conf.AuditorConfig
 }
 
+func (conf *KubeauditConfig) GetProfiles() []Profile {
+	if conf == nil {
+		return nil
+	}
+	return conf.Profiles
+}
+
+func (conf *KubeauditConfig) GetCustomResources() []k8s.CustomResourceConfig {
+	if conf == nil {
+		return nil
+	}
+	return conf.CustomResources
+}
+
+func (conf *KubeauditConfig) GetOverrides() override.Config {
+	if conf == nil {
+		return override.Config{}
+	}
+	return conf.Overrides
+}
+
 type AuditorConfig struct {
-	Capabilities   capabilities.Config   `yaml:"capabilities"`
-	DeprecatedAPIs deprecatedapis.Config `yaml:"config"`
-	Image          image.Config          `yaml:"image"`
-	Limits         limits.Config         `yaml:"limits"`
-	Mounts         mounts.Config         `yaml:"mounts"`
+	AppArmor        apparmor.Config        `yaml:"apparmor"`
+	Availability    availability.Config    `yaml:"availability"`
+	Capabilities    capabilities.Config    `yaml:"capabilities"`
+	DeprecatedAPIs  deprecatedapis.Config  `yaml:"config"`
+	HostPorts       hostports.Config       `yaml:"hostports"`
+	Image           image.Config           `yaml:"image"`
+	Limits          limits.Config          `yaml:"limits"`
+	Mounts          mounts.Config          `yaml:"mounts"`
+	NonRoot         nonroot.Config         `yaml:"nonroot"`
+	Probes          probes.Config          `yaml:"probes"`
+	Seccomp         seccomp.Config         `yaml:"seccomp"`
+	SELinux         selinux.Config         `yaml:"selinux"`
+	ServiceAccounts serviceaccounts.Config `yaml:"serviceaccounts"`
+	Sysctls         sysctls.Config         `yaml:"sysctls"`
+	Volumes         volumes.Config         `yaml:"volumes"`
+	Webhooks        webhooks.Config        `yaml:"webhooks"`
 }
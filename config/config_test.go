@@ -2,10 +2,12 @@ package config_test
 
 import (
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/Shopify/kubeaudit/auditors/all"
 	"github.com/Shopify/kubeaudit/config"
+	"github.com/Shopify/kubeaudit/pkg/k8s"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -22,3 +24,104 @@ func TestConfig(t *testing.T) {
 
 	assert.Equal(t, len(all.AuditorNames), len(conf.GetEnabledAuditors()), "Config is missing auditors")
 }
+
+func TestProfileConfigLayering(t *testing.T) {
+	configData := `
+auditors:
+  limits:
+    cpu: "500m"
+    memory: "256Mi"
+  mounts:
+    denyPathsList: ["/proc"]
+profiles:
+  - name: system
+    match:
+      namespaces: ["kube-*"]
+    auditors:
+      limits:
+        cpu: "2"
+  - name: empty
+    match:
+      kinds: ["Pod"]
+`
+	conf, err := config.New(strings.NewReader(configData))
+	require.NoError(t, err)
+	require.Len(t, conf.GetProfiles(), 2)
+
+	system := conf.GetProfiles()[0]
+	assert.Equal(t, "system", system.Name)
+	assert.Equal(t, []string{"kube-*"}, system.Match.Namespaces)
+	assert.Equal(t, "2", system.AuditorConfig.Limits.CPU)
+	assert.Equal(t, "256Mi", system.AuditorConfig.Limits.Memory)
+	assert.Equal(t, []string{"/proc"}, system.AuditorConfig.Mounts.SensitivePaths)
+
+	empty := conf.GetProfiles()[1]
+	assert.Equal(t, conf.GetAuditorConfigs(), empty.AuditorConfig)
+}
+
+func TestProfileConfigLayeringDoesNotChangeTopLevelConfig(t *testing.T) {
+	configData := `
+auditors:
+  probes:
+    severities:
+      a: "warning"
+profiles:
+  - name: strict
+    match:
+      namespaces: ["prod-*"]
+    auditors:
+      probes:
+        severities:
+          b: "error"
+  - name: other
+    match:
+      kinds: ["Pod"]
+`
+	conf, err := config.New(strings.NewReader(configData))
+	require.NoError(t, err)
+	require.Len(t, conf.GetProfiles(), 2)
+
+	assert.Equal(t, map[string]string{"a": "warning"}, conf.GetAuditorConfigs().Probes.Severities)
+	assert.Equal(t, map[string]string{"a": "warning", "b": "error"}, conf.GetProfiles()[0].AuditorConfig.Probes.Severities)
+	assert.Equal(t, map[string]string{"a": "warning"}, conf.GetProfiles()[1].AuditorConfig.Probes.Severities)
+}
+
+func TestProfileMatch(t *testing.T) {
+	deployment := k8s.NewDeployment()
+	deployment.Namespace = "kube-system"
+	deployment.Labels = map[string]string{"tier": "system"}
+
+	cases := []struct {
+		description string
+		match       config.ProfileMatch
+		expected    bool
+	}{
+		{"empty match", config.ProfileMatch{}, true},
+		{"namespace", config.ProfileMatch{Namespaces: []string{"kube-system"}}, true},
+		{"namespace glob", config.ProfileMatch{Namespaces: []string{"default", "kube-*"}}, true},
+		{"other namespace", config.ProfileMatch{Namespaces: []string{"default"}}, false},
+		{"kind", config.ProfileMatch{Kinds: []string{"Deployment"}}, true},
+		{"other kind", config.ProfileMatch{Kinds: []string{"Pod"}}, false},
+		{"label selector", config.ProfileMatch{LabelSelector: "tier in (system, infra)"}, true},
+		{"other label selector", config.ProfileMatch{LabelSelector: "tier=app"}, false},
+		{"all criteria", config.ProfileMatch{Namespaces: []string{"kube-*"}, Kinds: []string{"Deployment"}, LabelSelector: "tier"}, true},
+		{"one criteria does not match", config.ProfileMatch{Namespaces: []string{"kube-*"}, Kinds: []string{"Pod"}}, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			matched, err := tc.match.Matches(deployment)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, matched)
+		})
+	}
+
+	namespace := k8s.NewNamespace()
+	namespace.Name = "kube-system"
+	matched, err := (&config.ProfileMatch{Namespaces: []string{"kube-system"}}).Matches(namespace)
+	require.NoError(t, err)
+	assert.True(t, matched)
+
+	_, err = (&config.ProfileMatch{LabelSelector: "tier in ("}).Matches(deployment)
+	assert.Error(t, err)
+}
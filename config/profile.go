@@ -0,0 +1,100 @@
+package config
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/Shopify/kubeaudit/pkg/k8s"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// Profile replaces the auditor configs for the resources it matches. When loaded from a config file, the profile's
+// auditor configs are layered on top of the top-level auditor configs, so a profile only needs to specify the
+// values which differ.
+type Profile struct {
+	Name          string        `yaml:"name"`
+	Match         ProfileMatch  `yaml:"match"`
+	AuditorConfig AuditorConfig `yaml:"auditors"`
+}
+
+// ProfileMatch selects the resources a profile applies to. Every criteria which is set must match for the profile
+// to apply.
+type ProfileMatch struct {
+	// Namespaces is a list of namespace names or glob patterns (eg. "kube-*")
+	Namespaces []string `yaml:"namespaces"`
+	// Kinds is a list of resource kinds (eg. "Deployment")
+	Kinds []string `yaml:"kinds"`
+	// LabelSelector is a Kubernetes label selector (eg. "tier=system,env in (prod, staging)") matched against the
+	// labels of the resource itself
+	LabelSelector string `yaml:"labelSelector"`
+}
+
+// Selector parses the label selector of the profile match
+func (match *ProfileMatch) Selector() (labels.Selector, error) {
+	selector, err := labels.Parse(match.LabelSelector)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing label selector %q: %w", match.LabelSelector, err)
+	}
+	return selector, nil
+}
+
+// Matches returns true if the resource matches all the criteria of the profile match
+func (match *ProfileMatch) Matches(resource k8s.Resource) (bool, error) {
+	if len(match.Kinds) > 0 && !contains(match.Kinds, resource.GetObjectKind().GroupVersionKind().Kind) {
+		return false, nil
+	}
+
+	objectMeta := k8s.GetObjectMeta(resource)
+
+	if len(match.Namespaces) > 0 {
+		namespace := ""
+		if objectMeta != nil {
+			namespace = objectMeta.GetNamespace()
+			if k8s.IsNamespaceV1(resource) {
+				namespace = objectMeta.GetName()
+			}
+		}
+		matched, err := matchesAnyPattern(match.Namespaces, namespace)
+		if err != nil || !matched {
+			return false, err
+		}
+	}
+
+	if match.LabelSelector != "" {
+		selector, err := match.Selector()
+		if err != nil {
+			return false, err
+		}
+		var resourceLabels map[string]string
+		if objectMeta != nil {
+			resourceLabels = objectMeta.GetLabels()
+		}
+		if !selector.Matches(labels.Set(resourceLabels)) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func matchesAnyPattern(patterns []string, value string) (bool, error) {
+	for _, pattern := range patterns {
+		matched, err := path.Match(pattern, value)
+		if err != nil {
+			return false, fmt.Errorf("error parsing pattern %q: %w", pattern, err)
+		}
+		if matched {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func contains(arr []string, val string) bool {
+	for _, arrVal := range arr {
+		if arrVal == val {
+			return true
+		}
+	}
+	return false
+}
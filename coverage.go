@@ -0,0 +1,38 @@
+package kubeaudit
+
+import "github.com/Shopify/kubeaudit/internal/k8sinternal"
+
+// SkippedResource is a resource, or all the resources of a kind or API group, which couldn't be audited
+type SkippedResource = k8sinternal.SkippedResource
+
+// SkipReason is the reason a resource or API group couldn't be audited
+type SkipReason = k8sinternal.SkipReason
+
+const (
+	// SkipReasonUnknownKind means the kind of the resource isn't known to kubeaudit. Custom resources can be audited by
+	// configuring the paths of their pod templates (see WithCustomResources)
+	SkipReasonUnknownKind = k8sinternal.SkipReasonUnknownKind
+	// SkipReasonDecodeError means the resource couldn't be decoded
+	SkipReasonDecodeError = k8sinternal.SkipReasonDecodeError
+	// SkipReasonForbidden means the resources couldn't be listed because access was denied
+	SkipReasonForbidden = k8sinternal.SkipReasonForbidden
+	// SkipReasonListFailed means the resources or API group couldn't be listed
+	SkipReasonListFailed = k8sinternal.SkipReasonListFailed
+)
+
+// NewReportWithCoverage returns a report with the resources and API groups which couldn't be audited
+func NewReportWithCoverage(results []Result, skipped []SkippedResource) *Report {
+	report := NewReport(results)
+	report.skipped = skipped
+	return report
+}
+
+// Coverage returns the resources and API groups which couldn't be audited
+func (r *Report) Coverage() []SkippedResource {
+	return r.skipped
+}
+
+// IsCoverageComplete returns true if every resource was audited
+func (r *Report) IsCoverageComplete() bool {
+	return len(r.skipped) == 0
+}
@@ -30,7 +30,7 @@ func fix(results []Result) ([]byte, error) {
 			continue
 		}
 
-		fixedresourceBytes, err := resourceToBytes(result.GetResource().Object(), result.GetResource().Bytes())
+		fixedresourceBytes, err := resourceToBytes(result.GetResource().Object(), result.GetResource().Bytes(), getRenamedKeys(result))
 		if err != nil {
 			return nil, err
 		}
@@ -40,7 +40,7 @@ func fix(results []Result) ([]byte, error) {
 
 	// Convert all the new resources to bytes
 	for _, newResource := range newResources {
-		fixedresourceBytes, err := resourceToBytes(newResource, nil)
+		fixedresourceBytes, err := resourceToBytes(newResource, nil, nil)
 		if err != nil {
 			return nil, err
 		}
@@ -52,7 +52,25 @@ func fix(results []Result) ([]byte, error) {
 	return fixedManifest, nil
 }
 
-func resourceToBytes(fixedResource k8s.Resource, origResourceBytes []byte) ([]byte, error) {
+// keyRenamingFix is implemented by pending fixes which rename map keys, such as labels. The renamed keys keep their
+// position and comments in the fixed manifest
+type keyRenamingFix interface {
+	RenamedKeys() map[string]string
+}
+
+func getRenamedKeys(result Result) map[string]string {
+	renamedKeys := map[string]string{}
+	for _, auditResult := range result.GetAuditResults() {
+		if fix, ok := auditResult.PendingFix.(keyRenamingFix); ok {
+			for oldKey, newKey := range fix.RenamedKeys() {
+				renamedKeys[oldKey] = newKey
+			}
+		}
+	}
+	return renamedKeys
+}
+
+func resourceToBytes(fixedResource k8s.Resource, origResourceBytes []byte, renamedKeys map[string]string) ([]byte, error) {
 	fixedresourceBytes, err := k8sinternal.EncodeResource(fixedResource)
 	if err != nil {
 		return nil, err
@@ -63,7 +81,7 @@ func resourceToBytes(fixedResource k8s.Resource, origResourceBytes []byte) ([]by
 		// Add  a leading newline
 		fixedresourceBytes = append([]byte{'\n'}, fixedresourceBytes...)
 	} else {
-		fixedresourceBytes, err = yaml.Merge(origResourceBytes, fixedresourceBytes)
+		fixedresourceBytes, err = yaml.MergeRenamed(origResourceBytes, fixedresourceBytes, renamedKeys)
 		if err != nil {
 			return nil, err
 		}
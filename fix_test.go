@@ -38,6 +38,9 @@ func TestFix(t *testing.T) {
 		}
 
 		for _, fixture := range fixtureFiles {
+			if fixture.IsDir() {
+				continue
+			}
 			t.Run(filepath.Join(fixturesDirPath, fixture.Name()), func(t *testing.T) {
 				_, report := test.FixSetupMultiple(t, fixturesDirPath, fixture.Name(), allAuditors)
 				for _, result := range report.Results() {
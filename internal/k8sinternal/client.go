@@ -3,7 +3,9 @@ package k8sinternal
 import (
 	"context"
 	"errors"
+	"fmt"
 	"os"
+	"sort"
 
 	"github.com/Shopify/kubeaudit/pkg/k8s"
 	log "github.com/sirupsen/logrus"
@@ -19,11 +21,8 @@ import (
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 
 	// add authentication support to the kubernetes code
-	_ "k8s.io/client-go/plugin/pkg/client/auth/azure"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/exec"
-	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/oidc"
-	_ "k8s.io/client-go/plugin/pkg/client/auth/openstack"
 )
 
 // ErrNoReadableKubeConfig represents any error that prevents the client from opening a kubeconfig file.
@@ -105,11 +104,14 @@ type ClientOptions struct {
 	Namespace string
 	// IncludeGenerated is a boolean option to include generated resources.
 	IncludeGenerated bool
+	// CustomResourceKinds are the custom resource kinds which are audited through their pod templates. Defaults to none.
+	CustomResourceKinds *k8s.CustomResourceKinds
 }
 
 type KubeClient interface {
-	// GetAllResources gets all supported resources from the cluster
-	GetAllResources(options ClientOptions) ([]k8s.Resource, error)
+	// GetAllResources gets all supported resources from the cluster, along with the resources and API groups which
+	// couldn't be listed or decoded
+	GetAllResources(options ClientOptions) ([]k8s.Resource, []SkippedResource, error)
 	// GetKubernetesVersion returns the kubernetes client version
 	GetKubernetesVersion() (*version.Info, error)
 	// ServerPreferredResources returns the supported resources with the version preferred by the server.
@@ -125,13 +127,14 @@ func NewKubeClient(dynamic dynamic.Interface, discovery discovery.DiscoveryInter
 	return &kubeClient{dynamicClient: dynamic, discoveryClient: discovery}
 }
 
-// GetAllResources gets all supported resources from the cluster
-func (kc kubeClient) GetAllResources(options ClientOptions) ([]k8s.Resource, error) {
+// GetAllResources gets all supported resources from the cluster, along with the resources and API groups which
+// couldn't be listed or decoded
+func (kc kubeClient) GetAllResources(options ClientOptions) ([]k8s.Resource, []SkippedResource, error) {
 	var resources []k8s.Resource
 
-	lists, err := kc.ServerPreferredResources()
+	lists, skipped, err := kc.serverPreferredResources()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	for _, list := range lists {
@@ -140,6 +143,7 @@ func (kc kubeClient) GetAllResources(options ClientOptions) ([]k8s.Resource, err
 		}
 		gv, err := schema.ParseGroupVersion(list.GroupVersion)
 		if err != nil {
+			skipped = append(skipped, SkippedResource{APIVersion: list.GroupVersion, Reason: SkipReasonListFailed, Message: err.Error()})
 			continue
 		}
 		for _, apiresource := range list.APIResources {
@@ -148,25 +152,51 @@ func (kc kubeClient) GetAllResources(options ClientOptions) ([]k8s.Resource, err
 			}
 			gvr := schema.GroupVersionResource{Group: gv.Group, Version: gv.Version, Resource: apiresource.Name}
 
+			var items []unstructured.Unstructured
 			// Namespace has to be included as a resource to audit if it is specified.
 			if apiresource.Name == "namespaces" && options.Namespace != "" {
 				unstructured, err := kc.dynamicClient.Resource(gvr).Get(context.Background(), options.Namespace, metav1.GetOptions{})
-				if err == nil {
-					r, err := unstructuredToObject(unstructured)
-					if err == nil {
-						resources = append(resources, r)
-					}
+				if err != nil {
+					skipped = append(skipped, SkippedResource{APIVersion: list.GroupVersion, Kind: apiresource.Kind, Name: options.Namespace, Reason: getListSkipReason(err), Message: err.Error()})
+					continue
 				}
+				items = append(items, *unstructured)
 			} else {
 				unstructuredList, err := kc.dynamicClient.Resource(gvr).Namespace(options.Namespace).List(context.Background(), metav1.ListOptions{})
-				if err == nil {
-					for _, unstructured := range unstructuredList.Items {
-						r, err := unstructuredToObject(&unstructured)
-						if err == nil {
-							resources = append(resources, r)
-						}
-					}
+				if err != nil {
+					skipped = append(skipped, SkippedResource{APIVersion: list.GroupVersion, Kind: apiresource.Kind, Reason: getListSkipReason(err), Message: err.Error()})
+					continue
 				}
+				items = unstructuredList.Items
+			}
+
+			unknownCount := 0
+			for i := range items {
+				r, err := unstructuredToObject(&items[i], options.CustomResourceKinds)
+				switch {
+				case err == nil:
+					resources = append(resources, r)
+				case getDecodeSkipReason(err) == SkipReasonUnknownKind:
+					// Every resource of an unknown kind is skipped for the same reason so they are reported together
+					unknownCount++
+				default:
+					skipped = append(skipped, SkippedResource{
+						APIVersion: list.GroupVersion,
+						Kind:       apiresource.Kind,
+						Namespace:  items[i].GetNamespace(),
+						Name:       items[i].GetName(),
+						Reason:     SkipReasonDecodeError,
+						Message:    err.Error(),
+					})
+				}
+			}
+			if unknownCount > 0 {
+				skipped = append(skipped, SkippedResource{
+					APIVersion: list.GroupVersion,
+					Kind:       apiresource.Kind,
+					Reason:     SkipReasonUnknownKind,
+					Message:    fmt.Sprintf("%d resources of an unknown kind", unknownCount),
+				})
 			}
 		}
 	}
@@ -174,11 +204,16 @@ func (kc kubeClient) GetAllResources(options ClientOptions) ([]k8s.Resource, err
 	if !options.IncludeGenerated {
 		resources = excludeGenerated(resources)
 	}
-	return resources, nil
+	return resources, skipped, nil
 }
 
-// unstructuredToObject unstructured to Go typed object conversions
-func unstructuredToObject(unstructured *unstructured.Unstructured) (k8s.Resource, error) {
+// unstructuredToObject unstructured to Go typed object conversions. Resources of the custom resource kinds are
+// converted to k8s.CustomResources
+func unstructuredToObject(unstructured *unstructured.Unstructured, customResourceKinds *k8s.CustomResourceKinds) (k8s.Resource, error) {
+	if customResource, ok, err := toCustomResource(unstructured, customResourceKinds); ok {
+		return customResource, err
+	}
+
 	obj, err := scheme.New(unstructured.GroupVersionKind())
 	if err == nil {
 		err = runtime.DefaultUnstructuredConverter.FromUnstructured(unstructured.UnstructuredContent(), obj)
@@ -212,11 +247,23 @@ func (kc kubeClient) GetKubernetesVersion() (*version.Info, error) {
 
 // ServerPreferredResources returns the supported resources with the version preferred by the server.
 func (kc kubeClient) ServerPreferredResources() ([]*metav1.APIResourceList, error) {
+	list, _, err := kc.serverPreferredResources()
+	return list, err
+}
+
+// serverPreferredResources returns the supported resources with the version preferred by the server, along with the
+// API groups which couldn't be discovered
+func (kc kubeClient) serverPreferredResources() ([]*metav1.APIResourceList, []SkippedResource, error) {
 	list, err := discovery.ServerPreferredResources(kc.discoveryClient)
 	// If a group is not served by the cluster the resources of this group will not be audited.
 	var e *discovery.ErrGroupDiscoveryFailed
 	if errors.As(err, &e) {
-		return list, nil
+		var skipped []SkippedResource
+		for gv, groupErr := range e.Groups {
+			skipped = append(skipped, SkippedResource{APIVersion: gv.String(), Reason: getListSkipReason(groupErr), Message: groupErr.Error()})
+		}
+		sort.Slice(skipped, func(i, j int) bool { return skipped[i].APIVersion < skipped[j].APIVersion })
+		return list, skipped, nil
 	}
-	return list, err
+	return list, nil, err
 }
@@ -102,15 +102,44 @@ func TestGetAllResources(t *testing.T) {
 	}
 
 	client := newFakeKubeClient(resources...)
-	k8sresources, err := client.GetAllResources(k8sinternal.ClientOptions{})
+	k8sresources, skipped, err := client.GetAllResources(k8sinternal.ClientOptions{})
 	require.NoError(t, err)
 	assert.Len(t, k8sresources, len(resourceTemplates)*len(namespaces))
+	assert.Empty(t, skipped)
 
-	k8sresources, err = client.GetAllResources(k8sinternal.ClientOptions{Namespace: namespaces[0]})
+	k8sresources, _, err = client.GetAllResources(k8sinternal.ClientOptions{Namespace: namespaces[0]})
 	require.NoError(t, err)
 	assert.Len(t, k8sresources, len(resourceTemplates))
 }
 
+func TestGetAllResourcesCoverage(t *testing.T) {
+	widget := &unstructured.Unstructured{}
+	widget.SetAPIVersion("example.com/v1")
+	widget.SetKind("Widget")
+	widget.SetName("widget")
+	widget.SetNamespace("foo")
+
+	client := newFakeKubeClient(k8s.NewDeployment(), &k8s.CustomResource{Unstructured: widget})
+	k8sresources, skipped, err := client.GetAllResources(k8sinternal.ClientOptions{})
+	require.NoError(t, err)
+	assert.Len(t, k8sresources, 1)
+	require.Len(t, skipped, 1)
+	assert.Equal(t, "example.com/v1", skipped[0].APIVersion)
+	assert.Equal(t, "Widget", skipped[0].Kind)
+	assert.Equal(t, k8sinternal.SkipReasonUnknownKind, skipped[0].Reason)
+
+	// Resources of custom resource kinds are decoded
+	customResourceKinds, err := k8s.NewCustomResourceKinds([]k8s.CustomResourceConfig{
+		{APIVersion: "example.com/v1", Kind: "Widget", PodTemplatePaths: []string{".spec.template"}},
+	})
+	require.NoError(t, err)
+
+	k8sresources, skipped, err = client.GetAllResources(k8sinternal.ClientOptions{CustomResourceKinds: customResourceKinds})
+	require.NoError(t, err)
+	assert.Len(t, k8sresources, 2)
+	assert.Empty(t, skipped)
+}
+
 func setNamespace(resource k8s.Resource, namespace string) {
 	if _, ok := resource.(*k8s.NamespaceV1); ok {
 		k8s.GetObjectMeta(resource).SetName(namespace)
@@ -148,21 +177,21 @@ func TestIncludeGenerated(t *testing.T) {
 	require.NoError(t, err)
 
 	// Test IncludeGenerated = false
-	resources, err := client.GetAllResources(
+	resources, _, err := client.GetAllResources(
 		k8sinternal.ClientOptions{Namespace: namespace, IncludeGenerated: false},
 	)
 	require.NoError(t, err)
 	assert.False(t, hasPod(resources), "Expected no pods for IncludeGenerated=false")
 
 	// Test IncludeGenerated unspecified defaults to false
-	resources, err = client.GetAllResources(
+	resources, _, err = client.GetAllResources(
 		k8sinternal.ClientOptions{Namespace: namespace},
 	)
 	require.NoError(t, err)
 	assert.False(t, hasPod(resources), "Expected no pods if IncludeGenerated is unspecified (ie. default to false)")
 
 	// Test IncludeGenerated = true
-	resources, err = client.GetAllResources(
+	resources, _, err = client.GetAllResources(
 		k8sinternal.ClientOptions{Namespace: namespace, IncludeGenerated: true},
 	)
 	require.NoError(t, err)
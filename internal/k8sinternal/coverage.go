@@ -0,0 +1,89 @@
+package k8sinternal
+
+import (
+	"path"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+)
+
+// SkipReason is the reason a resource or API group couldn't be audited
+type SkipReason string
+
+const (
+	// SkipReasonUnknownKind means the kind of the resource isn't known to kubeaudit
+	SkipReasonUnknownKind SkipReason = "UnknownKind"
+	// SkipReasonDecodeError means the resource couldn't be decoded
+	SkipReasonDecodeError SkipReason = "DecodeError"
+	// SkipReasonForbidden means the resources couldn't be listed because access was denied
+	SkipReasonForbidden SkipReason = "Forbidden"
+	// SkipReasonListFailed means the resources or API group couldn't be listed
+	SkipReasonListFailed SkipReason = "ListFailed"
+)
+
+// SkippedResource is a resource, or all the resources of a kind or API group, which couldn't be audited
+type SkippedResource struct {
+	// APIVersion is the API group version of the skipped resources
+	APIVersion string
+	// Kind is the kind of the skipped resources. It is empty if a whole API group version was skipped
+	Kind string
+	// Namespace and Name identify a single skipped resource. They are empty if all the resources of the kind or API
+	// group version were skipped
+	Namespace string
+	Name      string
+	Reason    SkipReason
+	Message   string
+}
+
+// String returns the API group version, kind and namespaced name of the skipped resources, if set
+func (skipped SkippedResource) String() string {
+	resource := skipped.APIVersion
+	if skipped.Kind != "" {
+		resource += " " + skipped.Kind
+	}
+	if skipped.Name != "" {
+		resource += " " + path.Join(skipped.Namespace, skipped.Name)
+	}
+	return resource
+}
+
+// getDecodeSkipReason returns the reason for a resource which couldn't be decoded
+func getDecodeSkipReason(err error) SkipReason {
+	if runtime.IsNotRegisteredError(err) {
+		return SkipReasonUnknownKind
+	}
+	return SkipReasonDecodeError
+}
+
+// getListSkipReason returns the reason for resources which couldn't be listed
+func getListSkipReason(err error) SkipReason {
+	if apierrors.IsForbidden(err) || apierrors.IsUnauthorized(err) {
+		return SkipReasonForbidden
+	}
+	return SkipReasonListFailed
+}
+
+// NewManifestSkippedResource returns the skipped resource for a manifest document which couldn't be decoded. It returns
+// false if the document is empty
+func NewManifestSkippedResource(b []byte, err error) (SkippedResource, bool) {
+	obj := map[string]interface{}{}
+	if yamlErr := yaml.Unmarshal(b, &obj); yamlErr != nil {
+		return SkippedResource{Reason: SkipReasonDecodeError, Message: yamlErr.Error()}, true
+	}
+	if len(obj) == 0 {
+		return SkippedResource{}, false
+	}
+
+	u := unstructured.Unstructured{Object: obj}
+	skipped := SkippedResource{
+		APIVersion: u.GetAPIVersion(),
+		Kind:       u.GetKind(),
+		Namespace:  u.GetNamespace(),
+		Name:       u.GetName(),
+		Reason:     getDecodeSkipReason(err),
+		Message:    err.Error(),
+	}
+	return skipped, true
+}
@@ -2,16 +2,55 @@ package k8sinternal
 
 import (
 	"github.com/Shopify/kubeaudit/pkg/k8s"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	k8sRuntime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/yaml"
 )
 
-func DecodeResource(b []byte) (k8s.Resource, error) {
+// DecodeResource decodes a resource. Resources of the custom resource kinds, if any, are decoded as k8s.CustomResources
+func DecodeResource(b []byte, customResourceKinds *k8s.CustomResourceKinds) (k8s.Resource, error) {
 	decoder := codecs.UniversalDeserializer()
-	return k8sRuntime.Decode(decoder, b)
+	resource, err := k8sRuntime.Decode(decoder, b)
+	if err != nil && k8sRuntime.IsNotRegisteredError(err) {
+		if customResource, ok, customErr := decodeCustomResource(b, customResourceKinds); ok {
+			return customResource, customErr
+		}
+	}
+	return resource, err
+}
+
+// decodeCustomResource decodes a resource of one of the custom resource kinds. It returns false if the resource isn't of
+// a custom resource kind
+func decodeCustomResource(b []byte, customResourceKinds *k8s.CustomResourceKinds) (k8s.Resource, bool, error) {
+	jsonBytes, err := yaml.YAMLToJSON(b)
+	if err != nil {
+		return nil, false, nil
+	}
+	obj := &unstructured.Unstructured{}
+	if err := obj.UnmarshalJSON(jsonBytes); err != nil {
+		return nil, false, nil
+	}
+	return toCustomResource(obj, customResourceKinds)
+}
+
+func toCustomResource(obj *unstructured.Unstructured, customResourceKinds *k8s.CustomResourceKinds) (k8s.Resource, bool, error) {
+	customResource, ok, err := customResourceKinds.NewCustomResource(obj)
+	if !ok || err != nil {
+		return nil, ok, err
+	}
+	return customResource, true, nil
 }
 
 func EncodeResource(resource k8s.Resource) ([]byte, error) {
+	if customResource, ok := resource.(*k8s.CustomResource); ok {
+		obj, err := customResource.ToUnstructured()
+		if err != nil {
+			return nil, err
+		}
+		return yaml.Marshal(obj.Object)
+	}
+
 	info, _ := k8sRuntime.SerializerInfoForMediaType(codecs.SupportedMediaTypes(), "application/yaml")
 	groupVersion := schema.GroupVersion{Group: resource.GetObjectKind().GroupVersionKind().Group, Version: resource.GetObjectKind().GroupVersionKind().Version}
 	encoder := codecs.EncoderForVersion(info.Serializer, groupVersion)
@@ -36,11 +36,53 @@ func TestEncodeDecode(t *testing.T) {
 	require.NoError(err)
 	assert.Equal(string(expectedManifest), string(encoded))
 
-	decoded, err := k8sinternal.DecodeResource(expectedManifest)
+	decoded, err := k8sinternal.DecodeResource(expectedManifest, nil)
 	require.NoError(err)
 	assert.Equal(deployment, decoded)
 }
 
+func TestEncodeDecodeCustomResource(t *testing.T) {
+	require := require.New(t)
+
+	manifest, err := os.ReadFile("fixtures/custom-resource.yml")
+	require.NoError(err)
+
+	_, err = k8sinternal.DecodeResource(manifest, nil)
+	require.Error(err)
+
+	customResourceKinds, err := k8s.NewCustomResourceKinds([]k8s.CustomResourceConfig{
+		{APIVersion: "example.com/v1", Kind: "Workflow", PodTemplatePaths: []string{".spec.template", "{.spec.steps[1].template}"}},
+	})
+	require.NoError(err)
+
+	decoded, err := k8sinternal.DecodeResource(manifest, customResourceKinds)
+	require.NoError(err)
+	require.IsType(&k8s.CustomResource{}, decoded)
+	assert.Equal(t, "workflow", k8s.GetObjectMeta(decoded).GetName())
+	assert.Equal(t, map[string]string{"app": "deploy"}, k8s.GetLabels(decoded))
+
+	containers := k8s.GetContainers(decoded)
+	require.Len(containers, 1)
+	containers[0].Image = "scratch:latest"
+
+	encoded, err := k8sinternal.EncodeResource(decoded)
+	require.NoError(err)
+	assert.Contains(t, string(encoded), "- name: build\n")
+
+	redecoded, err := k8sinternal.DecodeResource(encoded, customResourceKinds)
+	require.NoError(err)
+	assert.Equal(t, "scratch:latest", k8s.GetContainers(redecoded)[0].Image)
+}
+
+func TestNewCustomResourceKindsInvalidPath(t *testing.T) {
+	for _, path := range []string{"spec.template", ".spec..template", ".spec.steps[a]"} {
+		_, err := k8s.NewCustomResourceKinds([]k8s.CustomResourceConfig{
+			{APIVersion: "example.com/v1", Kind: "Workflow", PodTemplatePaths: []string{path}},
+		})
+		assert.Error(t, err, path)
+	}
+}
+
 func TestGetContainers(t *testing.T) {
 	for _, resource := range getAllResources(t) {
 		containers := k8s.GetContainers(resource)
@@ -125,7 +167,7 @@ func getResourcesFromManifest(t *testing.T, manifest string) (resources []k8s.Re
 	bufSlice := bytes.Split(data, []byte("---"))
 
 	for _, b := range bufSlice {
-		resource, err := k8sinternal.DecodeResource(b)
+		resource, err := k8sinternal.DecodeResource(b, nil)
 		if err != nil {
 			continue
 		}
@@ -10,6 +10,8 @@ import (
 	serializer "k8s.io/apimachinery/pkg/runtime/serializer"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	kubescheme "k8s.io/client-go/kubernetes/scheme"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 )
 
 var scheme = kubescheme.Scheme
@@ -18,6 +20,8 @@ var localSchemeBuilder = runtime.SchemeBuilder{
 	certmanagerv1alpha2.AddToScheme,
 	apiextensionsv1.AddToScheme,
 	apiextensionsv1beta1.AddToScheme,
+	gatewayv1alpha2.AddToScheme,
+	gatewayv1beta1.AddToScheme,
 }
 
 // AddToScheme adds localScheme to Scheme
@@ -3,9 +3,14 @@ package sarif
 import (
 	"github.com/Shopify/kubeaudit/auditors/apparmor"
 	"github.com/Shopify/kubeaudit/auditors/asat"
+	"github.com/Shopify/kubeaudit/auditors/availability"
 	"github.com/Shopify/kubeaudit/auditors/capabilities"
 	"github.com/Shopify/kubeaudit/auditors/deprecatedapis"
+	"github.com/Shopify/kubeaudit/auditors/exposure"
+	"github.com/Shopify/kubeaudit/auditors/gateway"
 	"github.com/Shopify/kubeaudit/auditors/hostns"
+	"github.com/Shopify/kubeaudit/auditors/hostports"
+	"github.com/Shopify/kubeaudit/auditors/hostprocess"
 	"github.com/Shopify/kubeaudit/auditors/image"
 	"github.com/Shopify/kubeaudit/auditors/limits"
 	"github.com/Shopify/kubeaudit/auditors/mounts"
@@ -13,23 +18,42 @@ import (
 	"github.com/Shopify/kubeaudit/auditors/nonroot"
 	"github.com/Shopify/kubeaudit/auditors/privesc"
 	"github.com/Shopify/kubeaudit/auditors/privileged"
+	"github.com/Shopify/kubeaudit/auditors/probes"
+	"github.com/Shopify/kubeaudit/auditors/procmount"
 	"github.com/Shopify/kubeaudit/auditors/rootfs"
 	"github.com/Shopify/kubeaudit/auditors/seccomp"
+	"github.com/Shopify/kubeaudit/auditors/selinux"
+	"github.com/Shopify/kubeaudit/auditors/serviceaccounts"
+	"github.com/Shopify/kubeaudit/auditors/sysctls"
+	"github.com/Shopify/kubeaudit/auditors/volumes"
+	"github.com/Shopify/kubeaudit/auditors/webhooks"
 )
 
 var allAuditors = map[string]string{
-	apparmor.Name:       "Finds containers that do not have AppArmor enabled",
-	asat.Name:           "Finds containers where the deprecated SA field is used or with a mounted default SA",
-	capabilities.Name:   "Finds containers that do not drop the recommended capabilities or add new ones",
-	deprecatedapis.Name: "Finds any resource defined with a deprecated API version",
-	hostns.Name:         "Finds containers that have HostPID, HostIPC or HostNetwork enabled",
-	image.Name:          "Finds containers which do not use the desired version of an image (via the tag) or use an image without a tag",
-	limits.Name:         "Finds containers which exceed the specified CPU and memory limits or do not specify any",
-	mounts.Name:         "Finds containers that have sensitive host paths mounted",
-	netpols.Name:        "Finds namespaces that do not have a default-deny network policy",
-	nonroot.Name:        "Finds containers allowed to run as root",
-	privesc.Name:        "Finds containers that allow privilege escalation",
-	privileged.Name:     "Finds containers running as privileged",
-	rootfs.Name:         "Finds containers which do not have a read-only filesystem",
-	seccomp.Name:        "Finds containers running without seccomp",
+	apparmor.Name:        "Finds containers that do not have AppArmor enabled",
+	asat.Name:            "Finds containers where the deprecated SA field is used or with a mounted default SA",
+	availability.Name:    "Finds single-replica workloads, workloads without PodDisruptionBudgets or topology spread, and PodDisruptionBudgets which block disruptions or select no pods",
+	capabilities.Name:    "Finds containers that do not drop the recommended capabilities or add new ones",
+	deprecatedapis.Name:  "Finds any resource defined with a deprecated API version",
+	exposure.Name:        "Finds workloads exposed outside of the cluster without a network policy, LoadBalancers without source ranges and Ingresses without TLS",
+	gateway.Name:         "Finds Ingresses and Gateways without TLS or with wildcard hostnames, Gateways accepting routes from every namespace and references to missing Secrets or Services",
+	hostns.Name:          "Finds containers that have HostPID, HostIPC or HostNetwork enabled",
+	hostports.Name:       "Finds containers which bind ports to the host",
+	hostprocess.Name:     "Finds Windows containers running as HostProcess containers",
+	image.Name:           "Finds containers which do not use the desired version of an image (via the tag) or use an image without a tag",
+	limits.Name:          "Finds containers which exceed the specified CPU and memory limits and requests or do not specify any",
+	mounts.Name:          "Finds containers that have sensitive host paths mounted",
+	netpols.Name:         "Finds namespaces that do not have a default-deny network policy and workloads reachable from every namespace or IP address",
+	nonroot.Name:         "Finds containers allowed to run as root or with the root group, and images which run as root",
+	privesc.Name:         "Finds containers that allow privilege escalation",
+	privileged.Name:      "Finds containers running as privileged",
+	probes.Name:          "Finds long-running containers without liveness or readiness probes, and misconfigured probes",
+	procmount.Name:       "Finds containers with an unmasked /proc mount",
+	rootfs.Name:          "Finds containers which do not have a read-only filesystem",
+	seccomp.Name:         "Finds containers running without seccomp",
+	selinux.Name:         "Finds containers with custom SELinux users, roles or types",
+	serviceaccounts.Name: "Finds legacy service account token Secrets, long-lived or unbound projected tokens and references to missing ServiceAccounts and Secrets",
+	sysctls.Name:         "Finds pods which set unsafe sysctls",
+	volumes.Name:         "Finds pods using volume types which are not allowed, unbounded emptyDir volumes and subPath mounts of hostPath volumes",
+	webhooks.Name:        "Finds security webhooks which fail open or exclude namespaces, and webhooks with long timeouts or matching every resource",
 }
@@ -93,6 +93,8 @@ func Create(kubeauditReport *kubeaudit.Report) (*sarif.Report, error) {
 		run.AddResult(result)
 	}
 
+	addSkippedResources(run, kubeauditReport.Coverage())
+
 	var reportBytes bytes.Buffer
 
 	err = report.Write(&reportBytes)
@@ -102,3 +104,21 @@ func Create(kubeauditReport *kubeaudit.Report) (*sarif.Report, error) {
 
 	return report, nil
 }
+
+// addSkippedResources adds the resources which couldn't be audited to the run as tool execution notifications, so
+// consumers of the SARIF output know the results are incomplete
+func addSkippedResources(run *sarif.Run, skippedResources []kubeaudit.SkippedResource) {
+	if len(skippedResources) == 0 {
+		return
+	}
+
+	invocation := run.AddInvocation(true)
+	for _, skipped := range skippedResources {
+		// The descriptor identifies the reason the resources were skipped
+		notification := sarif.NewNotification().
+			WithLevel("warning").
+			WithDescriptor(sarif.NewReportingDescriptorReference().WithId(string(skipped.Reason))).
+			WithTextMessage(fmt.Sprintf("%s could not be audited: %s", skipped.String(), skipped.Message))
+		invocation.AddTToolExecutionNotification(notification)
+	}
+}
@@ -133,3 +133,28 @@ func TestCreateWithNoResults(t *testing.T) {
 	// verify that the rules are only added as per report findings
 	assert.Len(t, sarifReport.Runs[0].Tool.Driver.Rules, 0)
 }
+
+func TestCreateWithSkippedResources(t *testing.T) {
+	kubeAuditReport := kubeaudit.NewReportWithCoverage(nil, []kubeaudit.SkippedResource{
+		{APIVersion: "example.com/v1", Kind: "Widget", Reason: kubeaudit.SkipReasonUnknownKind, Message: "2 resources of an unknown kind"},
+		{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "foo", Name: "bar", Reason: kubeaudit.SkipReasonDecodeError, Message: "invalid replicas"},
+	})
+
+	sarifReport, err := Create(kubeAuditReport)
+	require.NoError(t, err)
+	assert.Empty(t, sarifReport.Runs[0].Results)
+
+	require.Len(t, sarifReport.Runs[0].Invocations, 1)
+	invocation := sarifReport.Runs[0].Invocations[0]
+	assert.True(t, *invocation.ExecutionSuccessful)
+	require.Len(t, invocation.ToolExecutionNotifications, 2)
+
+	notification := invocation.ToolExecutionNotifications[0]
+	assert.Equal(t, "warning", notification.Level)
+	assert.Equal(t, "UnknownKind", *notification.Descriptor.Id)
+	assert.Equal(t, "example.com/v1 Widget could not be audited: 2 resources of an unknown kind", *notification.Message.Text)
+
+	notification = invocation.ToolExecutionNotifications[1]
+	assert.Equal(t, "DecodeError", *notification.Descriptor.Id)
+	assert.Equal(t, "apps/v1 Deployment foo/bar could not be audited: invalid replicas", *notification.Message.Text)
+}
@@ -29,7 +29,7 @@ func AuditLocal(t *testing.T, fixtureDir, fixture string, auditable kubeaudit.Au
 	return AuditMultiple(t, fixtureDir, fixture, []kubeaudit.Auditable{auditable}, expectedErrors, namespace, LOCAL_MODE)
 }
 
-func AuditMultiple(t *testing.T, fixtureDir, fixture string, auditables []kubeaudit.Auditable, expectedErrors []string, namespace string, mode string) *kubeaudit.Report {
+func AuditMultiple(t *testing.T, fixtureDir, fixture string, auditables []kubeaudit.Auditable, expectedErrors []string, namespace string, mode string, opts ...kubeaudit.Option) *kubeaudit.Report {
 	if mode == LOCAL_MODE && !UseKind() {
 		return nil
 	}
@@ -39,7 +39,7 @@ func AuditMultiple(t *testing.T, fixtureDir, fixture string, auditables []kubeau
 		expected[err] = true
 	}
 
-	report := GetReport(t, fixtureDir, fixture, auditables, namespace, mode)
+	report := GetReport(t, fixtureDir, fixture, auditables, namespace, mode, opts...)
 	require.NotNil(t, report)
 
 	errors := make(map[string]bool)
@@ -58,10 +58,10 @@ func FixSetup(t *testing.T, fixtureDir, fixture string, auditable kubeaudit.Audi
 }
 
 // FixSetup runs Fix() on a given manifest and returns the resulting resources
-func FixSetupMultiple(t *testing.T, fixtureDir, fixture string, auditables []kubeaudit.Auditable) (fixedResources []k8s.Resource, report *kubeaudit.Report) {
+func FixSetupMultiple(t *testing.T, fixtureDir, fixture string, auditables []kubeaudit.Auditable, opts ...kubeaudit.Option) (fixedResources []k8s.Resource, report *kubeaudit.Report) {
 	require := require.New(t)
 
-	report = GetReport(t, fixtureDir, fixture, auditables, "", MANIFEST_MODE)
+	report = GetReport(t, fixtureDir, fixture, auditables, "", MANIFEST_MODE, opts...)
 	require.NotNil(report)
 
 	// This increases code coverage by calling the Plan() method on each PendingFix object. Plan() returns a human
@@ -75,7 +75,7 @@ func FixSetupMultiple(t *testing.T, fixtureDir, fixture string, auditables []kub
 	err := report.Fix(fixedManifest)
 	require.Nil(err)
 
-	auditor, err := kubeaudit.New(auditables)
+	auditor, err := kubeaudit.New(auditables, opts...)
 	require.Nil(err)
 
 	report, err = auditor.AuditManifest("", fixedManifest)
@@ -94,11 +94,11 @@ func FixSetupMultiple(t *testing.T, fixtureDir, fixture string, auditables []kub
 	return fixedResources, report
 }
 
-func GetReport(t *testing.T, fixtureDir, fixture string, auditables []kubeaudit.Auditable, namespace string, mode string) *kubeaudit.Report {
+func GetReport(t *testing.T, fixtureDir, fixture string, auditables []kubeaudit.Auditable, namespace string, mode string, opts ...kubeaudit.Option) *kubeaudit.Report {
 	require := require.New(t)
 
 	fixture = filepath.Join(fixtureDir, fixture)
-	auditor, err := kubeaudit.New(auditables)
+	auditor, err := kubeaudit.New(auditables, opts...)
 	require.NoError(err)
 
 	var report *kubeaudit.Report
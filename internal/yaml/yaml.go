@@ -18,6 +18,12 @@ const (
 // Merge merges the original YAML with the fixed YAML such that the resulting YAML is autofixed but with the
 // same order and comments as the original.
 func Merge(origData, fixedData []byte) ([]byte, error) {
+	return MergeRenamed(origData, fixedData, nil)
+}
+
+// MergeRenamed is like Merge but treats the map keys in renamedKeys (old key to new key) as renamed by the fix, so
+// they keep the position, comments and value style of the original key instead of being dropped and re-added.
+func MergeRenamed(origData, fixedData []byte, renamedKeys map[string]string) ([]byte, error) {
 	origYaml, err := unmarshal(origData)
 	if err != nil {
 		return nil, err
@@ -31,7 +37,7 @@ func Merge(origData, fixedData []byte) ([]byte, error) {
 	// Create a new document node that contains the merged maps for the original and fixed yaml
 	mergedYaml := shallowCopyNode(origYaml)
 	mergedYaml.Content = []*goyaml.Node{
-		mergeMaps(origYaml.Content[0], fixedYaml.Content[0]),
+		mergeMaps(origYaml.Content[0], fixedYaml.Content[0], renamedKeys),
 	}
 
 	return marshal(mergedYaml)
@@ -69,7 +75,8 @@ func marshal(node *goyaml.Node) ([]byte, error) {
 // Key-value pairs which exist in fixed but not orig are included.
 // If keys exist in both orig and fixed then the key-value pair from fixed is used unless both values are complex
 // (maps or sequences), in which case they are merged recursively.
-func mergeMaps(orig, fixed *goyaml.Node) *goyaml.Node {
+// Keys in orig which were renamed to a key in fixed (see renamedKeys) are renamed in place.
+func mergeMaps(orig, fixed *goyaml.Node, renamedKeys map[string]string) *goyaml.Node {
 	merged := shallowCopyNode(orig)
 	origContent := orig.Content
 	fixedContent := fixed.Content
@@ -77,9 +84,11 @@ func mergeMaps(orig, fixed *goyaml.Node) *goyaml.Node {
 	// Drop items from original if they are not in fixed
 	for i := 0; i < len(origContent); i += 2 {
 		origKey := origContent[i]
+		origVal := origContent[i+1]
 		if isKeyInMap(origKey, fixed) {
-			origVal := origContent[i+1]
 			merged.Content = append(merged.Content, origKey, origVal)
+		} else if renamedKey := getRenamedKey(origKey, orig, fixed, renamedKeys); renamedKey != nil {
+			merged.Content = append(merged.Content, renamedKey, origVal)
 		}
 	}
 
@@ -104,9 +113,9 @@ func mergeMaps(orig, fixed *goyaml.Node) *goyaml.Node {
 			case goyaml.ScalarNode:
 				merged.Content[mergedValIndex].Value = fixedVal.Value
 			case goyaml.MappingNode:
-				merged.Content[mergedValIndex] = mergeMaps(mergedVal, fixedVal)
+				merged.Content[mergedValIndex] = mergeMaps(mergedVal, fixedVal, renamedKeys)
 			case goyaml.SequenceNode:
-				merged.Content[mergedValIndex] = mergeSequences(fixedKey.Value, mergedVal, fixedVal)
+				merged.Content[mergedValIndex] = mergeSequences(fixedKey.Value, mergedVal, fixedVal, renamedKeys)
 			default:
 				log.Error("Unexpected yaml node kind", fixedVal.Kind)
 			}
@@ -116,12 +125,33 @@ func mergeMaps(orig, fixed *goyaml.Node) *goyaml.Node {
 	return merged
 }
 
+// getRenamedKey returns a copy of origKey with its renamed value if it was renamed to a key in fixed, or nil if it
+// wasn't renamed or the new key was already in orig
+func getRenamedKey(origKey, orig, fixed *goyaml.Node, renamedKeys map[string]string) *goyaml.Node {
+	if origKey.Kind != goyaml.ScalarNode {
+		return nil
+	}
+
+	newName, ok := renamedKeys[origKey.Value]
+	if !ok {
+		return nil
+	}
+
+	renamedKey := shallowCopyNode(origKey)
+	renamedKey.Value = newName
+	if !isKeyInMap(renamedKey, fixed) || isKeyInMap(renamedKey, orig) {
+		return nil
+	}
+
+	return renamedKey
+}
+
 // mergeSequences recursively merges orig and fixed.
 // Items which exist in orig but not fixed are excluded.
 // Items which exist in fixed but not orig are included.
 // If items exist in both orig and fixed then the item from fixed is used unless both items are complex
 // (maps or sequences), in which case they are merged recursively.
-func mergeSequences(sequenceKey string, orig, fixed *goyaml.Node) *goyaml.Node {
+func mergeSequences(sequenceKey string, orig, fixed *goyaml.Node, renamedKeys map[string]string) *goyaml.Node {
 	merged := shallowCopyNode(orig)
 	origContent := orig.Content
 	fixedContent := fixed.Content
@@ -145,9 +175,9 @@ func mergeSequences(sequenceKey string, orig, fixed *goyaml.Node) *goyaml.Node {
 			case fixedItem.Kind != mergedItem.Kind:
 				merged.Content[mergedItemIndex] = fixedItem
 			case fixedItem.Kind == goyaml.MappingNode:
-				merged.Content[mergedItemIndex] = mergeMaps(mergedItem, fixedItem)
+				merged.Content[mergedItemIndex] = mergeMaps(mergedItem, fixedItem, renamedKeys)
 			case fixedItem.Kind == goyaml.SequenceNode:
-				merged.Content[mergedItemIndex] = mergeSequences(sequenceKey, mergedItem, fixedItem)
+				merged.Content[mergedItemIndex] = mergeSequences(sequenceKey, mergedItem, fixedItem, renamedKeys)
 			}
 		}
 	}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	yaml "gopkg.in/yaml.v3"
 )
 
@@ -352,13 +353,43 @@ func TestMergeMaps(t *testing.T) {
 
 	for _, test := range cases {
 		t.Run(test.testName, func(t *testing.T) {
-			merged := mergeMaps(test.orig, test.fixed)
+			merged := mergeMaps(test.orig, test.fixed, nil)
 			assert.True(t, deepEqual(test.merged, merged))
 			assert.Equal(t, test.merged, merged)
 		})
 	}
 }
 
+func TestMergeRenamed(t *testing.T) {
+	orig := `labels:
+  # Comment
+  old: "value" # Line comment
+  other: v
+`
+	fixed := `labels:
+  other: v
+  new: value
+`
+
+	merged, err := MergeRenamed([]byte(orig), []byte(fixed), map[string]string{"old": "new"})
+	require.NoError(t, err)
+	assert.Equal(t, `labels:
+  # Comment
+  new: "value" # Line comment
+  other: v
+`, string(merged))
+
+	// Without the renamed keys the old key is dropped and the new key is added
+	merged, err = Merge([]byte(orig), []byte(fixed))
+	require.NoError(t, err)
+	assert.Equal(t, "labels:\n  other: v\n  new: value\n", string(merged))
+
+	// The old key is dropped if the new key was already in the original
+	merged, err = MergeRenamed([]byte("a: b\nnew: value\nold: value\n"), []byte("a: b\nnew: value\n"), map[string]string{"old": "new"})
+	require.NoError(t, err)
+	assert.Equal(t, "a: b\nnew: value\n", string(merged))
+}
+
 func TestFindItemInSequence(t *testing.T) {
 	assert := assert.New(t)
 
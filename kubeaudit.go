@@ -115,7 +115,8 @@ import (
 
 // Kubeaudit provides functions to audit and fix Kubernetes manifests
 type Kubeaudit struct {
-	auditors []Auditable
+	auditors            []Auditable
+	customResourceKinds *k8s.CustomResourceKinds
 }
 
 type AuditOptions = k8sinternal.ClientOptions
@@ -144,7 +145,7 @@ func (a *Kubeaudit) AuditManifest(manifestPath string, manifest io.Reader) (*Rep
 		return nil, err
 	}
 
-	resources, err := getResourcesFromManifest(manifestBytes)
+	resources, skipped, err := getResourcesFromManifest(manifestBytes, a.customResourceKinds)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get resources from manifest: %w", err)
 	}
@@ -167,6 +168,7 @@ func (a *Kubeaudit) AuditManifest(manifestPath string, manifest io.Reader) (*Rep
 	}
 
 	report := NewReport(results)
+	report.skipped = skipped
 
 	return report, nil
 }
@@ -182,7 +184,10 @@ func (a *Kubeaudit) AuditCluster(options AuditOptions) (*Report, error) {
 		return nil, err
 	}
 
-	resources, err := getResourcesFromClient(client, options)
+	if options.CustomResourceKinds == nil {
+		options.CustomResourceKinds = a.customResourceKinds
+	}
+	resources, skipped, err := getResourcesFromClient(client, options)
 	if err != nil {
 		return nil, err
 	}
@@ -192,6 +197,7 @@ func (a *Kubeaudit) AuditCluster(options AuditOptions) (*Report, error) {
 	}
 
 	report := NewReport(results)
+	report.skipped = skipped
 
 	return report, nil
 }
@@ -205,7 +211,10 @@ func (a *Kubeaudit) AuditLocal(configpath string, context string, options AuditO
 		return nil, err
 	}
 
-	resources, err := getResourcesFromClient(client, options)
+	if options.CustomResourceKinds == nil {
+		options.CustomResourceKinds = a.customResourceKinds
+	}
+	resources, skipped, err := getResourcesFromClient(client, options)
 	if err != nil {
 		return nil, err
 	}
@@ -215,6 +224,7 @@ func (a *Kubeaudit) AuditLocal(configpath string, context string, options AuditO
 	}
 
 	report := NewReport(results)
+	report.skipped = skipped
 
 	return report, nil
 }
@@ -222,10 +232,11 @@ func (a *Kubeaudit) AuditLocal(configpath string, context string, options AuditO
 // Report contains the results after auditing
 type Report struct {
 	results []Result
+	skipped []SkippedResource
 }
 
 func NewReport(results []Result) *Report {
-	return &Report{results}
+	return &Report{results: results}
 }
 
 // RawResults returns all of the results for each Kubernetes resource, including ones that had no audit results.
@@ -1,10 +1,13 @@
 package kubeaudit_test
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/Shopify/kubeaudit"
 	"github.com/Shopify/kubeaudit/auditors/all"
+	"github.com/Shopify/kubeaudit/auditors/limits"
+	"github.com/Shopify/kubeaudit/auditors/privileged"
 	"github.com/Shopify/kubeaudit/config"
 	"github.com/Shopify/kubeaudit/internal/k8sinternal"
 	"github.com/Shopify/kubeaudit/internal/test"
@@ -82,3 +85,52 @@ func TestUnknownResource(t *testing.T) {
 		})
 	}
 }
+
+func TestCoverage(t *testing.T) {
+	allAuditors, err := all.Auditors(config.KubeauditConfig{})
+	require.NoError(t, err)
+
+	report := test.GetReport(t, "internal/test/fixtures", "coverage.yml", allAuditors, "", test.MANIFEST_MODE)
+	require.False(t, report.IsCoverageComplete())
+
+	skipped := report.Coverage()
+	require.Len(t, skipped, 2)
+	assert.Equal(t, "Widget", skipped[0].Kind)
+	assert.Equal(t, "coverage", skipped[0].Namespace)
+	assert.Equal(t, "widget", skipped[0].Name)
+	assert.Equal(t, kubeaudit.SkipReasonUnknownKind, skipped[0].Reason)
+	assert.Equal(t, "Deployment", skipped[1].Kind)
+	assert.Equal(t, kubeaudit.SkipReasonDecodeError, skipped[1].Reason)
+
+	out := bytes.NewBuffer(nil)
+	report.PrintResults(kubeaudit.WithWriter(out), kubeaudit.WithColor(false))
+	assert.Contains(t, out.String(), "-- [skipped] example.com/v1 Widget coverage/widget\n   Reason: UnknownKind\n")
+}
+
+func TestEphemeralContainers(t *testing.T) {
+	allAuditors, err := all.Auditors(config.KubeauditConfig{})
+	require.NoError(t, err)
+
+	report := test.GetReport(t, "internal/test/fixtures", "ephemeral_containers.yml", allAuditors, "", test.MANIFEST_MODE)
+	require.Len(t, report.RawResults(), 1)
+
+	containerTypes := map[string]string{}
+	ephemeralRules := map[string]bool{}
+	for _, auditResult := range report.RawResults()[0].GetAuditResults() {
+		containerName, ok := auditResult.Metadata[kubeaudit.ContainerMetadataKey]
+		if !ok {
+			continue
+		}
+		containerTypes[containerName] = auditResult.Metadata[kubeaudit.ContainerTypeMetadataKey]
+		if containerName == "debugger" {
+			ephemeralRules[auditResult.Rule] = true
+			// Ephemeral containers can't be changed so they are not fixed
+			assert.Nil(t, auditResult.PendingFix, auditResult.Rule)
+		}
+	}
+
+	assert.Equal(t, map[string]string{"app": "regular", "init": "init", "debugger": "ephemeral"}, containerTypes)
+	assert.True(t, ephemeralRules[privileged.PrivilegedTrue])
+	// Ephemeral containers can't set resources
+	assert.False(t, ephemeralRules[limits.LimitsNotSet])
+}
@@ -1,6 +1,7 @@
 package kubeaudit
 
 import (
+	"github.com/Shopify/kubeaudit/pkg/k8s"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -15,6 +16,18 @@ func WithLogger(formatter log.Formatter) Option {
 	}
 }
 
+// WithCustomResources specifies the custom resource kinds to audit through their pod templates
+func WithCustomResources(configs []k8s.CustomResourceConfig) Option {
+	return func(a *Kubeaudit) error {
+		customResourceKinds, err := k8s.NewCustomResourceKinds(configs)
+		if err != nil {
+			return err
+		}
+		a.customResourceKinds = customResourceKinds
+		return nil
+	}
+}
+
 func (a *Kubeaudit) parseOptions(opts []Option) error {
 	for _, opt := range opts {
 		if err := opt(a); err != nil {
@@ -0,0 +1,41 @@
+package imageconfig
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Cache looks up images in an image config cache file. The file maps image references to their OCI image config, in
+// YAML or JSON:
+//
+//	docker.io/library/nginx:1.25:
+//	  config:
+//	    User: "101"
+type Cache struct {
+	configs map[string]*Config
+}
+
+// NewCache reads the image config cache file
+func NewCache(file string) (*Cache, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("error reading image config cache: %w", err)
+	}
+
+	imageConfigs := map[string]ociImageConfig{}
+	if err := yaml.Unmarshal(data, &imageConfigs); err != nil {
+		return nil, fmt.Errorf("error parsing image config cache: %w", err)
+	}
+
+	cache := &Cache{configs: map[string]*Config{}}
+	for ref, imageConfig := range imageConfigs {
+		cache.configs[NormalizeReference(ref)] = imageConfig.toConfig()
+	}
+	return cache, nil
+}
+
+func (cache *Cache) Get(image string) (*Config, error) {
+	return cache.configs[NormalizeReference(image)], nil
+}
@@ -0,0 +1,84 @@
+// Package imageconfig reads the config of container images from local sources, such as an OCI image layout or an
+// image config cache file, so auditors can inspect images without pulling them from a registry
+package imageconfig
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Config is the part of an OCI image config which is inspected
+type Config struct {
+	// User is the USER of the image. It can be a username or a UID, optionally followed by a group (eg. "1000:1000")
+	User string
+}
+
+// UID returns the numeric UID of the image user. It returns false if the user is a username, since the UID it maps to
+// is only known inside the image. The image runs as root (UID 0) if it has no user
+func (config *Config) UID() (int64, bool) {
+	user, _, _ := strings.Cut(config.User, ":")
+	if user == "" {
+		return 0, true
+	}
+
+	uid, err := strconv.ParseInt(user, 10, 64)
+	if err != nil || uid < 0 {
+		return 0, false
+	}
+	return uid, true
+}
+
+// Source looks up the config of images
+type Source interface {
+	// Get returns the config of the image. It returns nil if the source doesn't have the image
+	Get(image string) (*Config, error)
+}
+
+// Sources looks up images in each of its sources in order
+type Sources []Source
+
+func (sources Sources) Get(image string) (*Config, error) {
+	for _, source := range sources {
+		config, err := source.Get(image)
+		if err != nil || config != nil {
+			return config, err
+		}
+	}
+	return nil, nil
+}
+
+// ociImageConfig is the OCI image config format, used by the config blobs of OCI image layouts and by image config
+// cache files
+type ociImageConfig struct {
+	Config struct {
+		User string `json:"User" yaml:"User"`
+	} `json:"config" yaml:"config"`
+}
+
+func (config *ociImageConfig) toConfig() *Config {
+	return &Config{User: config.Config.User}
+}
+
+// NormalizeReference expands an image reference to its fully qualified form, so "nginx" and
+// "docker.io/library/nginx:latest" refer to the same image
+func NormalizeReference(image string) string {
+	name, digest, hasDigest := strings.Cut(image, "@")
+
+	// The first component is a registry if it looks like a hostname
+	if domain, _, ok := strings.Cut(name, "/"); !ok || (!strings.ContainsAny(domain, ".:") && domain != "localhost") {
+		name = "docker.io/" + name
+	}
+	if strings.HasPrefix(name, "docker.io/") && strings.Count(name, "/") == 1 {
+		name = "docker.io/library/" + strings.TrimPrefix(name, "docker.io/")
+	}
+
+	if hasDigest {
+		return name + "@" + digest
+	}
+
+	// The tag is after the last colon, unless the colon belongs to the registry port
+	if lastColon := strings.LastIndex(name, ":"); lastColon < strings.LastIndex(name, "/") {
+		name += ":latest"
+	}
+	return name
+}
@@ -0,0 +1,117 @@
+package imageconfig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeReference(t *testing.T) {
+	cases := []struct {
+		image    string
+		expected string
+	}{
+		{"nginx", "docker.io/library/nginx:latest"},
+		{"nginx:1.25", "docker.io/library/nginx:1.25"},
+		{"library/nginx", "docker.io/library/nginx:latest"},
+		{"docker.io/nginx", "docker.io/library/nginx:latest"},
+		{"myorg/app:v1", "docker.io/myorg/app:v1"},
+		{"registry.example.com/app", "registry.example.com/app:latest"},
+		{"localhost:5000/app", "localhost:5000/app:latest"},
+		{"localhost/app:v1", "localhost/app:v1"},
+		{"nginx@sha256:abc", "docker.io/library/nginx@sha256:abc"},
+	}
+
+	for _, tc := range cases {
+		assert.Equal(t, tc.expected, NormalizeReference(tc.image), tc.image)
+	}
+}
+
+func TestConfigUID(t *testing.T) {
+	cases := []struct {
+		user        string
+		expectedUID int64
+		expectedOk  bool
+	}{
+		{"", 0, true},
+		{"0", 0, true},
+		{"1000", 1000, true},
+		{"1000:1000", 1000, true},
+		{"root", 0, false},
+		{"app:app", 0, false},
+	}
+
+	for _, tc := range cases {
+		uid, ok := (&Config{User: tc.user}).UID()
+		assert.Equal(t, tc.expectedOk, ok, tc.user)
+		assert.Equal(t, tc.expectedUID, uid, tc.user)
+	}
+}
+
+func TestSources(t *testing.T) {
+	layout, err := NewOCILayout("fixtures/oci-layout", "")
+	require.NoError(t, err)
+	cache, err := NewCache("fixtures/image-config-cache.yml")
+	require.NoError(t, err)
+	sources := Sources{layout, cache}
+
+	cases := []struct {
+		image        string
+		expectedUser *string
+	}{
+		{"app:root", newString("")},
+		{"registry.example.com/app:nonroot", newString("1000:1000")},
+		// The amd64 image is picked from multi-platform images
+		{"app:named", newString("app")},
+		{"nginx:latest", newString("101")},
+		{"registry.example.com/app@sha256:4c8b3a1f0e9d2b7c6a5f4e3d2c1b0a9f8e7d6c5b4a3f2e1d0c9b8a7f6e5d4c3b", newString("root")},
+		{"app:missing", nil},
+	}
+
+	for _, tc := range cases {
+		config, err := sources.Get(tc.image)
+		require.NoError(t, err)
+		if tc.expectedUser == nil {
+			assert.Nil(t, config, tc.image)
+		} else if assert.NotNil(t, config, tc.image) {
+			assert.Equal(t, *tc.expectedUser, config.User, tc.image)
+		}
+	}
+
+	_, err = NewOCILayout("fixtures/missing", "")
+	assert.NotNil(t, err)
+	_, err = NewCache("fixtures/oci-layout/oci-layout")
+	assert.NotNil(t, err)
+}
+
+func TestOCILayoutPlatform(t *testing.T) {
+	cases := []struct {
+		platform     string
+		expectedUser string
+	}{
+		{"", "app"},
+		{"linux/amd64", "app"},
+		{"linux/arm64", "arm"},
+		// The first image is picked if none matches the platform
+		{"windows/amd64", "arm"},
+	}
+
+	for _, tc := range cases {
+		layout, err := NewOCILayout("fixtures/oci-layout", tc.platform)
+		require.NoError(t, err)
+		config, err := layout.Get("app:named")
+		require.NoError(t, err)
+		require.NotNil(t, config, tc.platform)
+		assert.Equal(t, tc.expectedUser, config.User, tc.platform)
+	}
+
+	for _, platform := range []string{"linux", "linux/", "/amd64", "linux/arm64/v8/extra"} {
+		_, err := NewOCILayout("fixtures/oci-layout", platform)
+		assert.NotNil(t, err, platform)
+	}
+}
+
+func newString(s string) *string {
+	return &s
+}
@@ -0,0 +1,183 @@
+package imageconfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+const (
+	// containerdImageNameAnnotation holds the full image reference in OCI layouts exported by containerd
+	containerdImageNameAnnotation = "io.containerd.image.name"
+	// ociRefNameAnnotation holds the reference of an image in an OCI layout
+	ociRefNameAnnotation = "org.opencontainers.image.ref.name"
+)
+
+// DefaultPlatform is the platform picked from multi-platform images if none is given
+const DefaultPlatform = "linux/amd64"
+
+type ociDescriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Annotations map[string]string `json:"annotations"`
+	Platform    *ociPlatform      `json:"platform"`
+}
+
+type ociPlatform struct {
+	OS           string `json:"os"`
+	Architecture string `json:"architecture"`
+	Variant      string `json:"variant"`
+}
+
+// parsePlatform parses a platform of the form "os/architecture" or "os/architecture/variant" (eg. "linux/arm64/v8")
+func parsePlatform(platform string) (ociPlatform, error) {
+	parts := strings.Split(platform, "/")
+	if len(parts) < 2 || len(parts) > 3 || parts[0] == "" || parts[1] == "" {
+		return ociPlatform{}, fmt.Errorf("invalid platform %q, it should have the form os/architecture[/variant]", platform)
+	}
+
+	parsed := ociPlatform{OS: parts[0], Architecture: parts[1]}
+	if len(parts) == 3 {
+		parsed.Variant = parts[2]
+	}
+	return parsed, nil
+}
+
+// matches returns true if the platform of a manifest is the given platform. The variant is only compared if it is
+// given
+func (platform *ociPlatform) matches(other ociPlatform) bool {
+	return platform != nil && platform.OS == other.OS && platform.Architecture == other.Architecture &&
+		(other.Variant == "" || platform.Variant == other.Variant)
+}
+
+// ociManifest holds the fields of both image indexes and image manifests, which are told apart by which fields are
+// set
+type ociManifest struct {
+	Manifests []ociDescriptor `json:"manifests"`
+	Config    *ociDescriptor  `json:"config"`
+}
+
+// OCILayout looks up images in an OCI image layout directory (see
+// https://github.com/opencontainers/image-spec/blob/main/image-layout.md). Images are matched by the
+// io.containerd.image.name or org.opencontainers.image.ref.name annotation of their entry in index.json, which should
+// be a full image reference (eg. "docker.io/library/nginx:1.25")
+type OCILayout struct {
+	dir      string
+	platform ociPlatform
+	refs     map[string]ociDescriptor
+
+	mu      sync.Mutex
+	configs map[string]*Config
+}
+
+// NewOCILayout reads the index of the OCI image layout in dir. Image configs are only read when they are looked up. The
+// image of the platform (eg. "linux/arm64") is picked from multi-platform images, falling back to the first image. It
+// defaults to DefaultPlatform
+func NewOCILayout(dir string, platform string) (*OCILayout, error) {
+	if platform == "" {
+		platform = DefaultPlatform
+	}
+	parsedPlatform, err := parsePlatform(platform)
+	if err != nil {
+		return nil, err
+	}
+
+	index := ociManifest{}
+	if err := readJSON(filepath.Join(dir, "index.json"), &index); err != nil {
+		return nil, fmt.Errorf("error reading OCI image layout index: %w", err)
+	}
+
+	layout := &OCILayout{
+		dir:      dir,
+		platform: parsedPlatform,
+		refs:     map[string]ociDescriptor{},
+		configs:  map[string]*Config{},
+	}
+	for _, descriptor := range index.Manifests {
+		ref := descriptor.Annotations[containerdImageNameAnnotation]
+		if ref == "" {
+			ref = descriptor.Annotations[ociRefNameAnnotation]
+		}
+		if ref != "" {
+			layout.refs[NormalizeReference(ref)] = descriptor
+		}
+	}
+
+	return layout, nil
+}
+
+func (layout *OCILayout) Get(image string) (*Config, error) {
+	ref := NormalizeReference(image)
+	descriptor, ok := layout.refs[ref]
+	if !ok {
+		return nil, nil
+	}
+
+	layout.mu.Lock()
+	defer layout.mu.Unlock()
+
+	if config, ok := layout.configs[ref]; ok {
+		return config, nil
+	}
+
+	config, err := layout.readConfig(descriptor)
+	if err != nil {
+		return nil, fmt.Errorf("error reading config of image %s from OCI image layout: %w", image, err)
+	}
+	layout.configs[ref] = config
+	return config, nil
+}
+
+// readConfig follows the descriptor through image indexes to an image manifest and reads its config
+func (layout *OCILayout) readConfig(descriptor ociDescriptor) (*Config, error) {
+	// Image indexes can be nested, but not infinitely
+	for depth := 0; depth < 8; depth++ {
+		manifest := ociManifest{}
+		if err := layout.readBlob(descriptor.Digest, &manifest); err != nil {
+			return nil, err
+		}
+
+		if manifest.Config != nil {
+			imageConfig := ociImageConfig{}
+			if err := layout.readBlob(manifest.Config.Digest, &imageConfig); err != nil {
+				return nil, err
+			}
+			return imageConfig.toConfig(), nil
+		}
+
+		if len(manifest.Manifests) == 0 {
+			return nil, fmt.Errorf("blob %s is neither an image index nor an image manifest", descriptor.Digest)
+		}
+		descriptor = pickManifest(manifest.Manifests, layout.platform)
+	}
+
+	return nil, fmt.Errorf("image indexes are nested too deeply")
+}
+
+func (layout *OCILayout) readBlob(digest string, v interface{}) error {
+	algorithm, encoded, ok := strings.Cut(digest, ":")
+	if !ok || !filepath.IsLocal(algorithm) || !filepath.IsLocal(encoded) {
+		return fmt.Errorf("invalid digest %q", digest)
+	}
+	return readJSON(filepath.Join(layout.dir, "blobs", algorithm, encoded), v)
+}
+
+func pickManifest(manifests []ociDescriptor, platform ociPlatform) ociDescriptor {
+	for _, manifest := range manifests {
+		if manifest.Platform.matches(platform) {
+			return manifest
+		}
+	}
+	return manifests[0]
+}
+
+func readJSON(path string, v interface{}) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
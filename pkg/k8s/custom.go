@@ -0,0 +1,339 @@
+package k8s
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8sRuntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// CustomResourceConfig maps a kind which isn't built into Kubernetes, such as a CRD, to the paths of its pod templates.
+// Paths are JSONPath field expressions such as "{.spec.template}" or ".spec.jobTargetRef.template" and may index
+// lists (eg. ".spec.templates[0]"). Every path found in a resource is audited and the others are ignored, so a single
+// mapping can cover several pod templates of a kind as well as several versions of a kind
+type CustomResourceConfig struct {
+	APIVersion       string   `yaml:"apiVersion"`
+	Kind             string   `yaml:"kind"`
+	PodTemplatePaths []string `yaml:"podTemplatePaths"`
+}
+
+type customResourceKind struct {
+	paths [][]string
+}
+
+// CustomResourceKinds are the custom resource kinds which are decoded as CustomResources and audited through their pod
+// templates. A nil CustomResourceKinds has no kinds
+type CustomResourceKinds struct {
+	kinds map[schema.GroupVersionKind]customResourceKind
+}
+
+// NewCustomResourceKinds parses the custom resource configs
+func NewCustomResourceKinds(configs []CustomResourceConfig) (*CustomResourceKinds, error) {
+	kinds := map[schema.GroupVersionKind]customResourceKind{}
+	for _, conf := range configs {
+		gv, err := schema.ParseGroupVersion(conf.APIVersion)
+		if err != nil {
+			return nil, fmt.Errorf("invalid apiVersion for custom resource %s: %w", conf.Kind, err)
+		}
+		if conf.Kind == "" || len(conf.PodTemplatePaths) == 0 {
+			return nil, fmt.Errorf("custom resource %s %s should have a kind and at least one pod template path", conf.APIVersion, conf.Kind)
+		}
+
+		kind := customResourceKind{}
+		for _, path := range conf.PodTemplatePaths {
+			fields, err := parsePodTemplatePath(path)
+			if err != nil {
+				return nil, fmt.Errorf("invalid pod template path for custom resource %s: %w", conf.Kind, err)
+			}
+			kind.paths = append(kind.paths, fields)
+		}
+		kinds[gv.WithKind(conf.Kind)] = kind
+	}
+
+	return &CustomResourceKinds{kinds: kinds}, nil
+}
+
+// IsCustomResourceKind returns true if the kind is configured as a custom resource
+func (k *CustomResourceKinds) IsCustomResourceKind(gvk schema.GroupVersionKind) bool {
+	_, ok := k.get(gvk)
+	return ok
+}
+
+func (k *CustomResourceKinds) get(gvk schema.GroupVersionKind) (customResourceKind, bool) {
+	if k == nil {
+		return customResourceKind{}, false
+	}
+	kind, ok := k.kinds[gvk]
+	return kind, ok
+}
+
+// parsePodTemplatePath splits a JSONPath field expression into its fields. List indices are kept as separate fields
+// (eg. ".spec.templates[0]" is split into "spec", "templates" and "0")
+func parsePodTemplatePath(path string) ([]string, error) {
+	trimmed := strings.TrimSpace(path)
+	trimmed = strings.TrimSuffix(strings.TrimPrefix(trimmed, "{"), "}")
+	trimmed = strings.TrimPrefix(trimmed, "$")
+	if !strings.HasPrefix(trimmed, ".") {
+		return nil, fmt.Errorf("%q should start with '.'", path)
+	}
+
+	var fields []string
+	for _, field := range strings.Split(trimmed[1:], ".") {
+		name, index, hasIndex := strings.Cut(field, "[")
+		if name == "" {
+			return nil, fmt.Errorf("%q has an empty field", path)
+		}
+		fields = append(fields, name)
+		for hasIndex {
+			var rest string
+			index, rest, _ = strings.Cut(index, "]")
+			if _, err := strconv.Atoi(index); err != nil {
+				return nil, fmt.Errorf("%q has an invalid list index %q", path, index)
+			}
+			fields = append(fields, index)
+			_, index, hasIndex = strings.Cut(rest, "[")
+		}
+	}
+	return fields, nil
+}
+
+// CustomResource is a resource of a custom resource kind (see CustomResourceKinds). Auditors and fixes work on the
+// decoded pod template, which is written back to the unstructured resource by ToUnstructured. A resource with several
+// pod templates is audited through one view per pod template (see PodTemplateViews)
+type CustomResource struct {
+	*unstructured.Unstructured
+
+	// PodTemplate is the pod template found at the first configured path, or nil if none of the paths were found
+	PodTemplate *PodTemplateSpecV1
+	path        []string
+
+	// podTemplates are all the pod templates found, including PodTemplate. They are shared with the views of the
+	// resource so fixes applied to a view are written back by ToUnstructured
+	podTemplates []*customPodTemplate
+}
+
+type customPodTemplate struct {
+	template *PodTemplateSpecV1
+	path     []string
+}
+
+// NewCustomResource decodes the pod templates of an unstructured resource of a custom resource kind. It returns false
+// if the kind isn't configured as a custom resource
+func (k *CustomResourceKinds) NewCustomResource(obj *unstructured.Unstructured) (*CustomResource, bool, error) {
+	kind, ok := k.get(obj.GroupVersionKind())
+	if !ok {
+		return nil, false, nil
+	}
+
+	resource := &CustomResource{Unstructured: obj}
+	for _, path := range kind.paths {
+		value, found := getPath(obj.Object, path)
+		if !found {
+			continue
+		}
+		content, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, true, fmt.Errorf("pod template of %s %s at %s should be an object", obj.GetKind(), obj.GetName(), strings.Join(path, "."))
+		}
+
+		podTemplate := &PodTemplateSpecV1{}
+		if err := k8sRuntime.DefaultUnstructuredConverter.FromUnstructured(content, podTemplate); err != nil {
+			return nil, true, fmt.Errorf("error decoding pod template of %s %s: %w", obj.GetKind(), obj.GetName(), err)
+		}
+		resource.podTemplates = append(resource.podTemplates, &customPodTemplate{template: podTemplate, path: path})
+	}
+
+	if len(resource.podTemplates) > 0 {
+		resource.PodTemplate = resource.podTemplates[0].template
+		resource.path = resource.podTemplates[0].path
+	}
+
+	return resource, true, nil
+}
+
+// PodTemplateViews returns a view of the resource for each of its pod templates, with PodTemplate set to that pod
+// template. The views share the unstructured resource and the pod templates with the resource
+func (r *CustomResource) PodTemplateViews() []*CustomResource {
+	if len(r.podTemplates) <= 1 {
+		return []*CustomResource{r}
+	}
+
+	views := make([]*CustomResource, 0, len(r.podTemplates))
+	for _, podTemplate := range r.podTemplates {
+		views = append(views, &CustomResource{
+			Unstructured: r.Unstructured,
+			PodTemplate:  podTemplate.template,
+			path:         podTemplate.path,
+			podTemplates: r.podTemplates,
+		})
+	}
+	return views
+}
+
+// PodTemplatePath returns the path of PodTemplate, eg. "spec.templates.0"
+func (r *CustomResource) PodTemplatePath() string {
+	return strings.Join(r.path, ".")
+}
+
+// GetObjectMeta implements metav1.ObjectMetaAccessor
+func (r *CustomResource) GetObjectMeta() metav1.Object {
+	return r.Unstructured
+}
+
+// DeepCopyObject implements runtime.Object
+func (r *CustomResource) DeepCopyObject() k8sRuntime.Object {
+	resource := &CustomResource{
+		Unstructured: r.Unstructured.DeepCopy(),
+		path:         append([]string{}, r.path...),
+	}
+	for _, podTemplate := range r.podTemplates {
+		podTemplateCopy := &customPodTemplate{template: podTemplate.template.DeepCopy(), path: podTemplate.path}
+		resource.podTemplates = append(resource.podTemplates, podTemplateCopy)
+		if podTemplate.template == r.PodTemplate {
+			resource.PodTemplate = podTemplateCopy.template
+		}
+	}
+	if resource.PodTemplate == nil && r.PodTemplate != nil {
+		resource.PodTemplate = r.PodTemplate.DeepCopy()
+	}
+	return resource
+}
+
+// ToUnstructured returns a copy of the unstructured resource with the pod templates written back to their paths.
+// Fields of the original pod templates which aren't part of PodTemplateSpec (such as fields of a pod spec wrapper type)
+// are kept
+func (r *CustomResource) ToUnstructured() (*unstructured.Unstructured, error) {
+	obj := r.Unstructured.DeepCopy()
+
+	podTemplates := r.podTemplates
+	if len(podTemplates) == 0 && r.PodTemplate != nil {
+		podTemplates = []*customPodTemplate{{template: r.PodTemplate, path: r.path}}
+	}
+	for _, podTemplate := range podTemplates {
+		if err := writePodTemplate(obj, podTemplate.template, podTemplate.path); err != nil {
+			return nil, err
+		}
+	}
+	return obj, nil
+}
+
+func writePodTemplate(obj *unstructured.Unstructured, podTemplate *PodTemplateSpecV1, path []string) error {
+	content, err := k8sRuntime.DefaultUnstructuredConverter.ToUnstructured(podTemplate)
+	if err != nil {
+		return err
+	}
+	if metadata, ok := content["metadata"].(map[string]interface{}); ok && metadata["creationTimestamp"] == nil {
+		delete(metadata, "creationTimestamp")
+		if len(metadata) == 0 {
+			delete(content, "metadata")
+		}
+	}
+
+	if original, found := getPath(obj.Object, path); found {
+		if original, ok := original.(map[string]interface{}); ok {
+			keepUnknownFields(content, original, reflect.TypeOf(PodTemplateSpecV1{}))
+		}
+	}
+
+	if !setPath(obj.Object, path, content) {
+		return fmt.Errorf("error writing pod template of %s %s to %s", obj.GetKind(), obj.GetName(), strings.Join(path, "."))
+	}
+	return nil
+}
+
+// keepUnknownFields copies the fields of original which aren't fields of the type into content, recursing into the
+// fields which are structs
+func keepUnknownFields(content, original map[string]interface{}, t reflect.Type) {
+	fields := getJSONFields(t)
+	for key, value := range original {
+		fieldType, known := fields[key]
+		if !known {
+			content[key] = value
+			continue
+		}
+		originalValue, ok := value.(map[string]interface{})
+		if !ok || fieldType.Kind() != reflect.Struct {
+			continue
+		}
+		if contentValue, ok := content[key].(map[string]interface{}); ok {
+			keepUnknownFields(contentValue, originalValue, fieldType)
+		}
+	}
+}
+
+// getJSONFields returns the types of the fields of a struct type by JSON name, including the fields of embedded structs
+func getJSONFields(t reflect.Type) map[string]reflect.Type {
+	fields := map[string]reflect.Type{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if field.Anonymous && name == "" {
+			for embeddedName, embeddedType := range getJSONFields(field.Type) {
+				fields[embeddedName] = embeddedType
+			}
+			continue
+		}
+		if name == "" || name == "-" {
+			continue
+		}
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		fields[name] = fieldType
+	}
+	return fields
+}
+
+func getPath(obj interface{}, path []string) (interface{}, bool) {
+	value := obj
+	for _, field := range path {
+		switch current := value.(type) {
+		case map[string]interface{}:
+			next, ok := current[field]
+			if !ok {
+				return nil, false
+			}
+			value = next
+		case []interface{}:
+			index, err := strconv.Atoi(field)
+			if err != nil || index < 0 || index >= len(current) {
+				return nil, false
+			}
+			value = current[index]
+		default:
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+func setPath(obj map[string]interface{}, path []string, value interface{}) bool {
+	if len(path) == 0 {
+		return false
+	}
+	parent, found := getPath(obj, path[:len(path)-1])
+	if !found {
+		return false
+	}
+
+	last := path[len(path)-1]
+	switch current := parent.(type) {
+	case map[string]interface{}:
+		current[last] = value
+		return true
+	case []interface{}:
+		index, err := strconv.Atoi(last)
+		if err != nil || index < 0 || index >= len(current) {
+			return false
+		}
+		current[index] = value
+		return true
+	}
+	return false
+}
@@ -1,6 +1,7 @@
 package k8s
 
 import (
+	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -15,6 +16,24 @@ func NewFalse() *bool {
 	return new(bool)
 }
 
+// ContainerType is the type of a container in a pod spec
+type ContainerType string
+
+const (
+	// ContainerTypeRegular is the type of the containers in the containers list of a pod spec
+	ContainerTypeRegular ContainerType = "regular"
+	// ContainerTypeInit is the type of the containers in the initContainers list of a pod spec which run to completion
+	// before the regular containers are started
+	ContainerTypeInit ContainerType = "init"
+	// ContainerTypeSidecar is the type of the containers in the initContainers list of a pod spec with a restartPolicy
+	// of Always, which keep running alongside the regular containers
+	ContainerTypeSidecar ContainerType = "sidecar"
+	// ContainerTypeEphemeral is the type of the containers in the ephemeralContainers list of a pod spec, which are
+	// added to running pods (eg. by kubectl debug) and can't be changed once they are added
+	ContainerTypeEphemeral ContainerType = "ephemeral"
+)
+
+// GetContainers returns the regular, init (including sidecar) and ephemeral containers of a resource
 func GetContainers(resource Resource) []*ContainerV1 {
 	podSpec := GetPodSpec(resource)
 	if podSpec == nil {
@@ -29,6 +48,9 @@ func GetContainers(resource Resource) []*ContainerV1 {
 	if len(podSpec.InitContainers) > 0 {
 		containers = append(containers, GetInitContainers(resource)...)
 	}
+	if len(podSpec.EphemeralContainers) > 0 {
+		containers = append(containers, GetEphemeralContainers(resource)...)
+	}
 	return containers
 }
 
@@ -45,6 +67,56 @@ func GetInitContainers(resource Resource) []*ContainerV1 {
 	return containers
 }
 
+// GetEphemeralContainers returns the ephemeral containers of a resource as containers. Ephemeral containers have the
+// same fields as containers so they can be audited like any other container
+func GetEphemeralContainers(resource Resource) []*ContainerV1 {
+	podSpec := GetPodSpec(resource)
+	if podSpec == nil {
+		return nil
+	}
+
+	containers := make([]*ContainerV1, len(podSpec.EphemeralContainers))
+	for i := range podSpec.EphemeralContainers {
+		containers[i] = (*ContainerV1)(&podSpec.EphemeralContainers[i].EphemeralContainerCommon)
+	}
+	return containers
+}
+
+// GetContainerType returns the type of the container with the given name. It returns false if the resource doesn't
+// have a container with this name
+func GetContainerType(resource Resource, containerName string) (ContainerType, bool) {
+	podSpec := GetPodSpec(resource)
+	if podSpec == nil {
+		return "", false
+	}
+
+	for _, container := range podSpec.Containers {
+		if container.Name == containerName {
+			return ContainerTypeRegular, true
+		}
+	}
+	for i := range podSpec.InitContainers {
+		if podSpec.InitContainers[i].Name == containerName {
+			if IsSidecarContainer(&podSpec.InitContainers[i]) {
+				return ContainerTypeSidecar, true
+			}
+			return ContainerTypeInit, true
+		}
+	}
+	for _, container := range podSpec.EphemeralContainers {
+		if container.Name == containerName {
+			return ContainerTypeEphemeral, true
+		}
+	}
+	return "", false
+}
+
+// IsSidecarContainer returns true if the container is a native sidecar container, which is an init container with a
+// restartPolicy of Always
+func IsSidecarContainer(container *ContainerV1) bool {
+	return container.RestartPolicy != nil && *container.RestartPolicy == apiv1.ContainerRestartPolicyAlways
+}
+
 // GetAnnotations returns the annotations at the pod level. If the resource does not have pods, then it returns
 // the least-nested annotations
 func GetAnnotations(resource Resource) map[string]string {
@@ -111,6 +183,8 @@ func GetPodTemplateSpec(resource Resource) *PodTemplateSpecV1 {
 	switch kubeType := resource.(type) {
 	case *CronJobV1Beta1:
 		return &kubeType.Spec.JobTemplate.Spec.Template
+	case *CustomResource:
+		return kubeType.PodTemplate
 	case *DaemonSetV1:
 		return &kubeType.Spec.Template
 	case *DeploymentV1:
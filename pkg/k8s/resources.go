@@ -97,6 +97,30 @@ func NewNetworkPolicy() *NetworkPolicyV1 {
 	}
 }
 
+// NewLimitRange creates a new LimitRange resource
+func NewLimitRange() *LimitRangeV1 {
+	return &LimitRangeV1{
+		TypeMeta: TypeMetaV1{
+			Kind:       "LimitRange",
+			APIVersion: "v1",
+		},
+		ObjectMeta: ObjectMetaV1{},
+		Spec:       LimitRangeSpecV1{},
+	}
+}
+
+// NewPodDisruptionBudget creates a new PodDisruptionBudget resource
+func NewPodDisruptionBudget() *PodDisruptionBudgetV1 {
+	return &PodDisruptionBudgetV1{
+		TypeMeta: TypeMetaV1{
+			Kind:       "PodDisruptionBudget",
+			APIVersion: "policy/v1",
+		},
+		ObjectMeta: ObjectMetaV1{},
+		Spec:       PodDisruptionBudgetSpecV1{},
+	}
+}
+
 // NewPodTemplate creates a new PodTemplate resource
 func NewPodTemplate() *PodTemplateV1 {
 	return &PodTemplateV1{
@@ -1,13 +1,19 @@
 package k8s
 
 import (
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	batchv1 "k8s.io/api/batch/v1"
 	batchv1beta1 "k8s.io/api/batch/v1beta1"
 	apiv1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8sRuntime "k8s.io/apimachinery/pkg/runtime"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 )
 
 // CapabilitiesV1 is a type alias for the v1 version of the k8s API.
@@ -16,6 +22,9 @@ type CapabilitiesV1 = apiv1.Capabilities
 // CapabilityV1 is a type alias for the v1 version of the k8s API.
 type CapabilityV1 = apiv1.Capability
 
+// ContainerPortV1 is a type alias for the v1 version of the k8s API.
+type ContainerPortV1 = apiv1.ContainerPort
+
 // ContainerV1 is a type alias for the v1 version of the k8s API.
 type ContainerV1 = apiv1.Container
 
@@ -37,6 +46,24 @@ type DeploymentSpecV1 = appsv1.DeploymentSpec
 // DeploymentV1 is a type alias for the v1 version of the k8s apps API.
 type DeploymentV1 = appsv1.Deployment
 
+// GatewayV1Beta1 is a type alias for the v1beta1 version of the Gateway API.
+type GatewayV1Beta1 = gatewayv1beta1.Gateway
+
+// HorizontalPodAutoscalerV1 is a type alias for the v1 version of the k8s autoscaling API.
+type HorizontalPodAutoscalerV1 = autoscalingv1.HorizontalPodAutoscaler
+
+// HorizontalPodAutoscalerV2 is a type alias for the v2 version of the k8s autoscaling API.
+type HorizontalPodAutoscalerV2 = autoscalingv2.HorizontalPodAutoscaler
+
+// HTTPRouteV1Alpha2 is a type alias for the v1alpha2 version of the Gateway API.
+type HTTPRouteV1Alpha2 = gatewayv1alpha2.HTTPRoute
+
+// HTTPRouteV1Beta1 is a type alias for the v1beta1 version of the Gateway API.
+type HTTPRouteV1Beta1 = gatewayv1beta1.HTTPRoute
+
+// IngressV1 is a type alias for the v1 version of the k8s networking API.
+type IngressV1 = networkingv1.Ingress
+
 // JobTemplateSpecV1Beta1 is a type alias for the v1beta1 version of the k8s batch API.
 type JobTemplateSpecV1Beta1 = batchv1beta1.JobTemplateSpec
 
@@ -46,9 +73,21 @@ type JobSpecV1 = batchv1.JobSpec
 // JobV1 is a type alias for the v1 version of the k8s batch API.
 type JobV1 = batchv1.Job
 
+// LimitRangeItemV1 is a type alias for the v1 version of the k8s API.
+type LimitRangeItemV1 = apiv1.LimitRangeItem
+
+// LimitRangeSpecV1 is a type alias for the v1 version of the k8s API.
+type LimitRangeSpecV1 = apiv1.LimitRangeSpec
+
+// LimitRangeV1 is a type alias for the v1 version of the k8s API.
+type LimitRangeV1 = apiv1.LimitRange
+
 // ListOptionsV1 is a type alias for the v1 version of the k8s meta API.
 type ListOptionsV1 = metav1.ListOptions
 
+// MutatingWebhookConfigurationV1 is a type alias for the v1 version of the k8s admissionregistration API.
+type MutatingWebhookConfigurationV1 = admissionregistrationv1.MutatingWebhookConfiguration
+
 // NamespaceV1 is a type alias for the v1 version of the k8s API.
 type NamespaceV1 = apiv1.Namespace
 
@@ -64,6 +103,15 @@ type NetworkPolicyV1 = networkingv1.NetworkPolicy
 // ObjectMetaV1 is a type alias for the v1 version of the k8s meta API.
 type ObjectMetaV1 = metav1.ObjectMeta
 
+// PodDisruptionBudgetSpecV1 is a type alias for the v1 version of the k8s policy API.
+type PodDisruptionBudgetSpecV1 = policyv1.PodDisruptionBudgetSpec
+
+// PodDisruptionBudgetV1 is a type alias for the v1 version of the k8s policy API.
+type PodDisruptionBudgetV1 = policyv1.PodDisruptionBudget
+
+// PodSecurityContextV1 is a type alias for the v1 version of the k8s API.
+type PodSecurityContextV1 = apiv1.PodSecurityContext
+
 // PodSpecV1 is a type alias for the v1 version of the k8s API.
 type PodSpecV1 = apiv1.PodSpec
 
@@ -85,9 +133,15 @@ type ReplicationControllerSpecV1 = apiv1.ReplicationControllerSpec
 // ReplicationControllerV1 is a type alias for the v1 version of the k8s API.
 type ReplicationControllerV1 = apiv1.ReplicationController
 
+// ResourceQuotaV1 is a type alias for the v1 version of the k8s API.
+type ResourceQuotaV1 = apiv1.ResourceQuota
+
 // Resource is a type alias for a runtime.Object
 type Resource k8sRuntime.Object
 
+// SecretV1 is a type alias for the v1 version of the k8s API.
+type SecretV1 = apiv1.Secret
+
 // SecurityContextV1 is a type alias for the v1 version of the k8s API.
 type SecurityContextV1 = apiv1.SecurityContext
 
@@ -106,8 +160,23 @@ type StatefulSetSpecV1 = appsv1.StatefulSetSpec
 // StatefulSetV1 is a type alias for the v1 version of the k8s apps API.
 type StatefulSetV1 = appsv1.StatefulSet
 
+// SysctlV1 is a type alias for the v1 version of the k8s API.
+type SysctlV1 = apiv1.Sysctl
+
+// TCPRouteV1Alpha2 is a type alias for the v1alpha2 version of the Gateway API.
+type TCPRouteV1Alpha2 = gatewayv1alpha2.TCPRoute
+
+// TLSRouteV1Alpha2 is a type alias for the v1alpha2 version of the Gateway API.
+type TLSRouteV1Alpha2 = gatewayv1alpha2.TLSRoute
+
 // TypeMetaV1 is a type alias for the v1 version of the k8s meta API.
 type TypeMetaV1 = metav1.TypeMeta
 
+// UDPRouteV1Alpha2 is a type alias for the v1alpha2 version of the Gateway API.
+type UDPRouteV1Alpha2 = gatewayv1alpha2.UDPRoute
+
 // UnsupportedType is a type alias for v1 version of the k8s apps API, this is meant for testing
 type UnsupportedType = apiv1.Binding
+
+// ValidatingWebhookConfigurationV1 is a type alias for the v1 version of the k8s admissionregistration API.
+type ValidatingWebhookConfigurationV1 = admissionregistrationv1.ValidatingWebhookConfiguration
@@ -0,0 +1,132 @@
+package override
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ExpiryDateFormat is the format of the expires field of an override annotation
+const ExpiryDateFormat = "2006-01-02"
+
+// now is used to check override expiry dates and can be replaced in tests
+var now = time.Now
+
+// Override is an override label and/or annotation found on a resource. Override annotations use the same key as the
+// override label and have either a plain string value, which is used as the reason, or a YAML value with the following
+// optional fields:
+//
+//	reason: Needs to load kernel modules
+//	owner: team-platform
+//	ticket: SEC-1234
+//	expires: 2025-06-30
+type Override struct {
+	// Key is the label or annotation key
+	Key     string
+	Reason  string `yaml:"reason"`
+	Owner   string `yaml:"owner"`
+	Ticket  string `yaml:"ticket"`
+	Expires string `yaml:"expires"`
+
+	// err is set if the override annotation could not be parsed
+	err error
+}
+
+func newOverride(key string, labels, annotations map[string]string) *Override {
+	label, hasLabel := labels[key]
+	annotation, hasAnnotation := annotations[key]
+	if !hasLabel && !hasAnnotation {
+		return nil
+	}
+
+	override := &Override{}
+	if hasAnnotation {
+		if err := parseAnnotation(annotation, override); err != nil {
+			override.err = fmt.Errorf("the override annotation %s could not be parsed: %w", key, err)
+		}
+	}
+
+	override.Key = key
+	if (hasLabel && isReason(label)) || override.Reason == "" {
+		override.Reason = label
+	}
+
+	return override
+}
+
+// parseAnnotation parses an override annotation value into the override. A scalar value, such as a reason copied from
+// an override label, is used as the reason
+func parseAnnotation(annotation string, override *Override) error {
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(annotation), &node); err != nil {
+		return err
+	}
+
+	if len(node.Content) == 0 {
+		return nil
+	}
+
+	value := node.Content[0]
+	switch value.Kind {
+	case yaml.ScalarNode:
+		override.Reason = value.Value
+		return nil
+	case yaml.MappingNode:
+		return value.Decode(override)
+	default:
+		return errors.New("expected a string or a mapping")
+	}
+}
+
+// Ignored returns the reason the override should not be applied, or an empty string if it is valid. Requirements
+// which depend on the config, such as requiring a justification, are checked by Config.Auditor
+func (o *Override) Ignored() string {
+	if o.err != nil {
+		return o.err.Error()
+	}
+
+	if o.Expires != "" {
+		expires, err := time.Parse(ExpiryDateFormat, o.Expires)
+		if err != nil {
+			return fmt.Sprintf("the expiry date %q is not in the format YYYY-MM-DD", o.Expires)
+		}
+		// The override is valid until the end of the day it expires
+		if !now().Before(expires.AddDate(0, 0, 1)) {
+			return fmt.Sprintf("the override expired on %s", o.Expires)
+		}
+	}
+
+	return ""
+}
+
+// isJustified returns true if the override has a reason or a ticket reference
+func (o *Override) isJustified() bool {
+	return isReason(o.Reason) || o.Ticket != ""
+}
+
+// Metadata returns the audit result metadata describing the override
+func (o *Override) Metadata() map[string]string {
+	metadata := map[string]string{}
+	if isReason(o.Reason) {
+		metadata["OverrideReason"] = o.Reason
+	}
+	if o.Owner != "" {
+		metadata["OverrideOwner"] = o.Owner
+	}
+	if o.Ticket != "" {
+		metadata["OverrideTicket"] = o.Ticket
+	}
+	if o.Expires != "" {
+		metadata["OverrideExpires"] = o.Expires
+	}
+	return metadata
+}
+
+// isReason returns true if the label value describes why the auditor was overridden, as opposed to just enabling
+// the override
+func isReason(value string) bool {
+	return value != "" && strings.ToLower(value) != "true"
+}
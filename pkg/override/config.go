@@ -0,0 +1,78 @@
+package override
+
+import (
+	"strings"
+
+	"github.com/Shopify/kubeaudit"
+	"github.com/Shopify/kubeaudit/pkg/k8s"
+)
+
+// Config configures how override labels and annotations are validated
+type Config struct {
+	// RequireJustification ignores overrides which don't have a reason or a ticket reference
+	RequireJustification bool `yaml:"requireJustification"`
+}
+
+// Auditor wraps an auditor so the overrides of its audit results which don't meet the requirements of the config are
+// ignored
+func (conf Config) Auditor(auditor kubeaudit.Auditable) kubeaudit.Auditable {
+	if !conf.RequireJustification {
+		return auditor
+	}
+	return &configAuditor{auditor: auditor, config: conf}
+}
+
+// ignored returns the reason the overrides of the audit result should be ignored, or an empty string if they meet the
+// requirements of the config
+func (conf Config) ignored(auditResult *kubeaudit.AuditResult, resource k8s.Resource) string {
+	labels := k8s.GetLabels(resource)
+	annotations := k8s.GetAnnotations(resource)
+
+	// Some auditors combine several overrides in one audit result
+	for _, key := range strings.Split(auditResult.Metadata[OverrideKeyMetadataKey], ", ") {
+		override := newOverride(key, labels, annotations)
+		if override == nil {
+			continue
+		}
+		if ignored := conf.check(override); ignored != "" {
+			return ignored
+		}
+	}
+
+	return ""
+}
+
+// check returns the reason the override should be ignored because it doesn't meet the requirements of the config, or
+// an empty string if it does
+func (conf Config) check(override *Override) string {
+	if conf.RequireJustification && !override.isJustified() {
+		return "the override has no reason or ticket reference"
+	}
+	return ""
+}
+
+// configAuditor implements Auditable. It ignores the overrides of the audit results of the wrapped auditor which don't
+// meet the requirements of the config
+type configAuditor struct {
+	auditor kubeaudit.Auditable
+	config  Config
+}
+
+func (a *configAuditor) Audit(resource k8s.Resource, resources []k8s.Resource) ([]*kubeaudit.AuditResult, error) {
+	auditResults, err := a.auditor.Audit(resource, resources)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, auditResult := range auditResults {
+		if auditResult == nil || auditResult.OverriddenResult == nil {
+			continue
+		}
+		if ignored := a.config.ignored(auditResult, resource); ignored != "" {
+			auditResults[i] = ignoreOverride(auditResult.OverriddenResult, auditResult.Metadata[OverrideKeyMetadataKey], ignored)
+			auditResults[i].Metadata[OverrideKeyMetadataKey] = auditResult.Metadata[OverrideKeyMetadataKey]
+		}
+	}
+
+	return auditResults, nil
+}
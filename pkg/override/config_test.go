@@ -0,0 +1,57 @@
+package override_test
+
+import (
+	"testing"
+
+	"github.com/Shopify/kubeaudit/auditors/privileged"
+	"github.com/Shopify/kubeaudit/internal/test"
+	"github.com/Shopify/kubeaudit/pkg/override"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigAuditor(t *testing.T) {
+	cases := []struct {
+		testName       string
+		config         override.Config
+		expectedErrors []string
+	}{
+		{"Justification not required", override.Config{}, []string{
+			override.GetOverriddenResultName(privileged.PrivilegedTrue),
+		}},
+		{"Justification required", override.Config{RequireJustification: true}, []string{
+			privileged.PrivilegedTrue,
+			override.GetOverriddenResultName(privileged.PrivilegedTrue),
+		}},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.testName, func(t *testing.T) {
+			t.Parallel()
+			report := test.AuditManifest(t, fixtureDir, "justification.yml", tc.config.Auditor(privileged.New()), tc.expectedErrors)
+
+			for _, result := range report.Results() {
+				for _, auditResult := range result.GetAuditResults() {
+					if auditResult.Rule == privileged.PrivilegedTrue {
+						assert.Equal(t, "the override has no reason or ticket reference", auditResult.Metadata["OverrideIgnored"])
+						assert.NotNil(t, auditResult.PendingFix)
+						assert.Equal(t, "unjustified", auditResult.Metadata["Container"])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestConfigAuditorDoesNotShareConfig(t *testing.T) {
+	strict := override.Config{RequireJustification: true}.Auditor(privileged.New())
+	lenient := override.Config{}.Auditor(privileged.New())
+
+	test.AuditManifest(t, fixtureDir, "justification.yml", strict, []string{
+		privileged.PrivilegedTrue,
+		override.GetOverriddenResultName(privileged.PrivilegedTrue),
+	})
+	test.AuditManifest(t, fixtureDir, "justification.yml", lenient, []string{
+		override.GetOverriddenResultName(privileged.PrivilegedTrue),
+	})
+}
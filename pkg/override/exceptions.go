@@ -0,0 +1,268 @@
+package override
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Shopify/kubeaudit"
+	"github.com/Shopify/kubeaudit/pkg/k8s"
+	"gopkg.in/yaml.v3"
+)
+
+// ExceptionMetadataKey is the audit result metadata key describing the exception which overrode the audit result
+const ExceptionMetadataKey = "Exception"
+
+// Exception overrides the audit results matching all of its set fields, as an alternative to override labels for
+// resources which can't be labelled. Namespace, Name and Container can be glob patterns (eg. "kube-*"). ContainerType
+// is one of the container types (see k8s.ContainerType) so policy can differ for init, sidecar, regular and ephemeral
+// containers
+type Exception struct {
+	Auditor       string `yaml:"auditor"`
+	Rule          string `yaml:"rule"`
+	Namespace     string `yaml:"namespace"`
+	Kind          string `yaml:"kind"`
+	Name          string `yaml:"name"`
+	Container     string `yaml:"container"`
+	ContainerType string `yaml:"containerType"`
+	Reason        string `yaml:"reason"`
+	Owner         string `yaml:"owner"`
+	Ticket        string `yaml:"ticket"`
+	Expires       string `yaml:"expires"`
+}
+
+// Exceptions is a list of exceptions loaded from an exceptions file. It keeps track of which exceptions matched an
+// audit result so exceptions which are no longer needed can be reported
+type Exceptions struct {
+	exceptions []Exception
+	config     Config
+
+	mu      sync.Mutex
+	matched []bool
+}
+
+// NewExceptions parses an exceptions file with the following format:
+//
+//	exceptions:
+//	  - auditor: privileged
+//	    rule: PrivilegedTrue
+//	    namespace: kube-system
+//	    kind: DaemonSet
+//	    name: cilium-*
+//	    container: agent
+//	    containerType: regular
+//	    reason: Needs to manage the host network
+//	    expires: 2025-06-30
+//
+// Exceptions which don't meet the requirements of the override config, such as requiring a justification, are ignored
+// like override labels and annotations are
+func NewExceptions(exceptionsData io.Reader, conf Config) (*Exceptions, error) {
+	var file struct {
+		Exceptions []Exception `yaml:"exceptions"`
+	}
+
+	if err := yaml.NewDecoder(exceptionsData).Decode(&file); err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	for i, exception := range file.Exceptions {
+		if err := exception.validate(); err != nil {
+			return nil, fmt.Errorf("invalid exception %d (%s): %w", i+1, exception, err)
+		}
+	}
+
+	return &Exceptions{
+		exceptions: file.Exceptions,
+		config:     conf,
+		matched:    make([]bool, len(file.Exceptions)),
+	}, nil
+}
+
+// Auditor wraps an auditor so the exceptions are applied to its audit results
+func (e *Exceptions) Auditor(auditor kubeaudit.Auditable) kubeaudit.Auditable {
+	return &exceptionAuditor{auditor: auditor, exceptions: e}
+}
+
+// Unmatched returns the exceptions which haven't matched any audit results
+func (e *Exceptions) Unmatched() []Exception {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var unmatched []Exception
+	for i, exception := range e.exceptions {
+		if !e.matched[i] {
+			unmatched = append(unmatched, exception)
+		}
+	}
+	return unmatched
+}
+
+// apply overrides the audit result using the first matching exception. Audit results which were already overridden
+// or are informational are left as is
+func (e *Exceptions) apply(auditResult *kubeaudit.AuditResult, resource k8s.Resource) (*kubeaudit.AuditResult, error) {
+	if auditResult.Severity < kubeaudit.Warn || auditResult.Rule == kubeaudit.RedundantAuditorOverride {
+		return auditResult, nil
+	}
+
+	for i, exception := range e.exceptions {
+		matched, err := exception.Matches(auditResult, resource)
+		if err != nil {
+			return nil, err
+		}
+		if !matched {
+			continue
+		}
+
+		e.mu.Lock()
+		e.matched[i] = true
+		e.mu.Unlock()
+
+		override := exception.override()
+		if ignored := e.config.check(override); ignored != "" {
+			if auditResult.Metadata == nil {
+				auditResult.Metadata = make(kubeaudit.Metadata)
+			}
+			auditResult = ignoreOverride(auditResult, override.Key, ignored)
+		} else {
+			auditResult = applyOverride(auditResult, override)
+		}
+		auditResult.Metadata[ExceptionMetadataKey] = exception.String()
+		return auditResult, nil
+	}
+
+	return auditResult, nil
+}
+
+// Matches returns true if the audit result for the resource matches all the fields set in the exception
+func (exception Exception) Matches(auditResult *kubeaudit.AuditResult, resource k8s.Resource) (bool, error) {
+	if exception.Auditor != "" && exception.Auditor != auditResult.Auditor {
+		return false, nil
+	}
+
+	if exception.Rule != "" && exception.Rule != auditResult.Rule {
+		return false, nil
+	}
+
+	if exception.Kind != "" && exception.Kind != resource.GetObjectKind().GroupVersionKind().Kind {
+		return false, nil
+	}
+
+	if exception.ContainerType != "" {
+		// The container type metadata is only added once all auditors ran, so it is looked up in the resource
+		containerType, ok := k8s.GetContainerType(resource, auditResult.Metadata[kubeaudit.ContainerMetadataKey])
+		if !ok || string(containerType) != exception.ContainerType {
+			return false, nil
+		}
+	}
+
+	var namespace, name string
+	if objectMeta := k8s.GetObjectMeta(resource); objectMeta != nil {
+		namespace = objectMeta.GetNamespace()
+		name = objectMeta.GetName()
+		if k8s.IsNamespaceV1(resource) {
+			namespace = name
+		}
+	}
+
+	for _, field := range []struct {
+		pattern string
+		value   string
+	}{
+		{exception.Namespace, namespace},
+		{exception.Name, name},
+		{exception.Container, auditResult.Metadata[kubeaudit.ContainerMetadataKey]},
+	} {
+		if field.pattern == "" {
+			continue
+		}
+		matched, err := path.Match(field.pattern, field.value)
+		if err != nil || !matched {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// String describes the exception by its matchers
+func (exception Exception) String() string {
+	var matchers []string
+	for _, matcher := range []struct {
+		field string
+		value string
+	}{
+		{"auditor", exception.Auditor},
+		{"rule", exception.Rule},
+		{"namespace", exception.Namespace},
+		{"kind", exception.Kind},
+		{"name", exception.Name},
+		{"container", exception.Container},
+		{"containerType", exception.ContainerType},
+	} {
+		if matcher.value != "" {
+			matchers = append(matchers, matcher.field+"="+matcher.value)
+		}
+	}
+	return strings.Join(matchers, ",")
+}
+
+func (exception Exception) validate() error {
+	if exception.Auditor == "" && exception.Rule == "" {
+		return fmt.Errorf("an auditor or a rule is required")
+	}
+
+	for _, pattern := range []string{exception.Namespace, exception.Name, exception.Container} {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("error parsing pattern %q: %w", pattern, err)
+		}
+	}
+
+	switch k8s.ContainerType(exception.ContainerType) {
+	case "", k8s.ContainerTypeRegular, k8s.ContainerTypeInit, k8s.ContainerTypeSidecar, k8s.ContainerTypeEphemeral:
+	default:
+		return fmt.Errorf("the container type %q should be one of %q, %q, %q or %q", exception.ContainerType,
+			k8s.ContainerTypeRegular, k8s.ContainerTypeInit, k8s.ContainerTypeSidecar, k8s.ContainerTypeEphemeral)
+	}
+
+	if exception.Expires != "" {
+		if _, err := time.Parse(ExpiryDateFormat, exception.Expires); err != nil {
+			return fmt.Errorf("the expiry date %q is not in the format YYYY-MM-DD", exception.Expires)
+		}
+	}
+
+	return nil
+}
+
+func (exception Exception) override() *Override {
+	return &Override{
+		Key:     "exception " + exception.String(),
+		Reason:  exception.Reason,
+		Owner:   exception.Owner,
+		Ticket:  exception.Ticket,
+		Expires: exception.Expires,
+	}
+}
+
+// exceptionAuditor implements Auditable. It applies exceptions to the audit results of the wrapped auditor
+type exceptionAuditor struct {
+	auditor    kubeaudit.Auditable
+	exceptions *Exceptions
+}
+
+func (a *exceptionAuditor) Audit(resource k8s.Resource, resources []k8s.Resource) ([]*kubeaudit.AuditResult, error) {
+	auditResults, err := a.auditor.Audit(resource, resources)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, auditResult := range auditResults {
+		if auditResults[i], err = a.exceptions.apply(auditResult, resource); err != nil {
+			return nil, err
+		}
+	}
+
+	return auditResults, nil
+}
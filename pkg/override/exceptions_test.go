@@ -0,0 +1,155 @@
+package override_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Shopify/kubeaudit"
+	"github.com/Shopify/kubeaudit/auditors/privileged"
+	"github.com/Shopify/kubeaudit/internal/test"
+	"github.com/Shopify/kubeaudit/pkg/override"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const fixtureDir = "fixtures"
+
+func TestExceptions(t *testing.T) {
+	cases := []struct {
+		testName          string
+		exceptions        string
+		config            override.Config
+		expectedErrors    []string
+		expectedUnmatched []string
+	}{
+		{
+			testName:       "No exceptions",
+			exceptions:     "",
+			expectedErrors: []string{privileged.PrivilegedTrue},
+		},
+		{
+			testName: "Namespace and container glob",
+			exceptions: `
+exceptions:
+  - rule: PrivilegedTrue
+    namespace: kube-*
+    container: "*"
+    reason: Needs host access
+`,
+			expectedErrors: []string{privileged.PrivilegedTrue, override.GetOverriddenResultName(privileged.PrivilegedTrue)},
+		},
+		{
+			testName: "Every resource",
+			exceptions: `
+exceptions:
+  - auditor: privileged
+    reason: Trusted
+  - auditor: privileged
+    kind: Pod
+`,
+			expectedErrors:    []string{override.GetOverriddenResultName(privileged.PrivilegedTrue)},
+			expectedUnmatched: []string{"auditor=privileged,kind=Pod"},
+		},
+		{
+			testName: "Expired",
+			exceptions: `
+exceptions:
+  - auditor: privileged
+    reason: Trusted
+    expires: 2020-01-01
+`,
+			expectedErrors: []string{privileged.PrivilegedTrue},
+		},
+		{
+			testName: "Justification required",
+			exceptions: `
+exceptions:
+  - auditor: privileged
+    owner: team-platform
+`,
+			config:         override.Config{RequireJustification: true},
+			expectedErrors: []string{privileged.PrivilegedTrue},
+		},
+		{
+			testName: "Justification required with ticket",
+			exceptions: `
+exceptions:
+  - auditor: privileged
+    ticket: SEC-1234
+`,
+			config:         override.Config{RequireJustification: true},
+			expectedErrors: []string{override.GetOverriddenResultName(privileged.PrivilegedTrue)},
+		},
+		{
+			testName: "Container type",
+			exceptions: `
+exceptions:
+  - rule: PrivilegedTrue
+    containerType: sidecar
+  - rule: PrivilegedTrue
+    containerType: ephemeral
+`,
+			expectedErrors:    []string{privileged.PrivilegedTrue, override.GetOverriddenResultName(privileged.PrivilegedTrue)},
+			expectedUnmatched: []string{"rule=PrivilegedTrue,containerType=ephemeral"},
+		},
+		{
+			testName: "Unmatched",
+			exceptions: `
+exceptions:
+  - rule: PrivilegedTrue
+    name: cilium-*
+    container: operator
+`,
+			expectedErrors:    []string{privileged.PrivilegedTrue},
+			expectedUnmatched: []string{"rule=PrivilegedTrue,name=cilium-*,container=operator"},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.testName, func(t *testing.T) {
+			t.Parallel()
+			exceptions, err := override.NewExceptions(strings.NewReader(tc.exceptions), tc.config)
+			require.NoError(t, err)
+
+			report := test.AuditManifest(t, fixtureDir, "exceptions.yml", exceptions.Auditor(privileged.New()), tc.expectedErrors)
+
+			var unmatched []string
+			for _, exception := range exceptions.Unmatched() {
+				unmatched = append(unmatched, exception.String())
+			}
+			assert.Equal(t, tc.expectedUnmatched, unmatched)
+
+			for _, result := range report.Results() {
+				for _, auditResult := range result.GetAuditResults() {
+					if auditResult.Severity == kubeaudit.Info {
+						assert.Nil(t, auditResult.PendingFix)
+						assert.NotEmpty(t, auditResult.Metadata[override.ExceptionMetadataKey])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestNewExceptionsInvalid(t *testing.T) {
+	cases := []struct {
+		testName   string
+		exceptions string
+	}{
+		{"No auditor or rule", "exceptions: [{namespace: default}]"},
+		{"Invalid pattern", "exceptions: [{auditor: privileged, name: '[cilium'}]"},
+		{"Invalid container type", "exceptions: [{auditor: privileged, containerType: main}]"},
+		{"Invalid expiry date", "exceptions: [{auditor: privileged, expires: tomorrow}]"},
+		{"Invalid yaml", "exceptions: {auditor: privileged}"},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.testName, func(t *testing.T) {
+			t.Parallel()
+			_, err := override.NewExceptions(strings.NewReader(tc.exceptions), override.Config{})
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,200 @@
+package override
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Shopify/kubeaudit"
+	"github.com/Shopify/kubeaudit/pkg/k8s"
+)
+
+const (
+	// ActiveOverride occurs when an override label or annotation overrides at least one audit result
+	ActiveOverride = "ActiveOverride"
+	// IgnoredOverride occurs when an override label or annotation is ignored because it expired, can't be parsed or
+	// lacks a required justification
+	IgnoredOverride = "IgnoredOverride"
+	// UnknownOverride occurs when an override label or annotation isn't used by any of the enabled auditors
+	UnknownOverride = "UnknownOverride"
+)
+
+// ReplacementLabelMetadataKey is the inventory metadata key holding the kubeaudit.io replacement for a deprecated
+// override label
+const ReplacementLabelMetadataKey = "ReplacementLabel"
+
+// Inventory lists every override label and annotation on the audited resources. Each override is reported as an
+// audit result of the auditor which uses it, with one of the following rules:
+//
+//	ActiveOverride: the override is still needed (info)
+//	RedundantAuditorOverride: the auditor found no security issues so the override can be removed (warning)
+//	IgnoredOverride: the override is expired, invalid or lacks a required justification (warning)
+//	UnknownOverride: none of the enabled auditors use the override (warning)
+func Inventory(report *kubeaudit.Report) *kubeaudit.Report {
+	var results []kubeaudit.Result
+
+	for _, result := range report.RawResults() {
+		resource := result.GetResource().Object()
+		if resource == nil {
+			continue
+		}
+
+		var auditResults []*kubeaudit.AuditResult
+		for _, key := range getOverrideKeys(resource) {
+			auditResult := newInventoryResult(key, resource, result.GetAuditResults())
+			auditResults = append(auditResults, auditResult)
+		}
+
+		results = append(results, &kubeaudit.WorkloadResult{
+			Resource:     result.GetResource(),
+			AuditResults: auditResults,
+		})
+	}
+
+	return kubeaudit.NewReport(results)
+}
+
+// getOverrideKeys returns the sorted override label and annotation keys of the resource
+func getOverrideKeys(resource k8s.Resource) []string {
+	keys := map[string]bool{}
+
+	for key := range k8s.GetLabels(resource) {
+		if _, _, ok := ParseOverrideKey(key); ok {
+			keys[key] = true
+		}
+	}
+
+	for key := range k8s.GetAnnotations(resource) {
+		if _, _, ok := ParseOverrideKey(key); ok && !IsDeprecatedOverrideKey(key) {
+			keys[key] = true
+		}
+	}
+
+	sortedKeys := make([]string, 0, len(keys))
+	for key := range keys {
+		sortedKeys = append(sortedKeys, key)
+	}
+	sort.Strings(sortedKeys)
+
+	return sortedKeys
+}
+
+func newInventoryResult(key string, resource k8s.Resource, auditResults []*kubeaudit.AuditResult) *kubeaudit.AuditResult {
+	containerName, overrideLabel, _ := ParseOverrideKey(key)
+
+	annotations := k8s.GetAnnotations(resource)
+	if IsDeprecatedOverrideKey(key) {
+		annotations = nil
+	}
+	override := newOverride(key, k8s.GetLabels(resource), annotations)
+
+	inventoryResult := &kubeaudit.AuditResult{
+		Rule:     UnknownOverride,
+		Severity: kubeaudit.Warn,
+		Message:  fmt.Sprintf("Override %s is not used by any of the enabled auditors.", key),
+		Metadata: override.Metadata(),
+	}
+	if len(auditResults) > 0 {
+		inventoryResult.FilePath = auditResults[0].FilePath
+	}
+
+	// An override can apply to several audit results (eg. a pod override applies to every container). It is needed
+	// if any of them were overridden
+	priority := 0
+	for _, auditResult := range auditResults {
+		if !hasOverrideKey(auditResult, key) {
+			continue
+		}
+
+		var rule, message string
+		var rulePriority int
+		switch {
+		case auditResult.Metadata["OverrideIgnored"] != "":
+			rule, rulePriority = IgnoredOverride, 2
+			message = fmt.Sprintf("Override %s for auditor %s is ignored because %s.", key, auditResult.Auditor, auditResult.Metadata["OverrideIgnored"])
+		case auditResult.Rule == kubeaudit.RedundantAuditorOverride:
+			rule, rulePriority = kubeaudit.RedundantAuditorOverride, 1
+			message = fmt.Sprintf("Override %s for auditor %s is no longer needed and should be removed.", key, auditResult.Auditor)
+		default:
+			rule, rulePriority = ActiveOverride, 3
+			message = fmt.Sprintf("Override %s disables auditor %s.", key, auditResult.Auditor)
+		}
+
+		if rulePriority > priority {
+			priority = rulePriority
+			inventoryResult.Auditor = auditResult.Auditor
+			inventoryResult.Rule = rule
+			inventoryResult.Message = message
+			inventoryResult.FilePath = auditResult.FilePath
+		}
+	}
+
+	if inventoryResult.Rule == ActiveOverride {
+		inventoryResult.Severity = kubeaudit.Info
+	}
+
+	inventoryResult.Metadata[OverrideLabelMetadataKey] = key
+	inventoryResult.Metadata["OverrideIdentifier"] = overrideLabel
+	inventoryResult.Metadata["Needed"] = fmt.Sprint(inventoryResult.Rule == ActiveOverride)
+	if containerName != "" {
+		inventoryResult.Metadata["Container"] = containerName
+	}
+	if replacement := GetReplacementOverrideKey(key); replacement != key {
+		inventoryResult.Metadata[ReplacementLabelMetadataKey] = replacement
+	}
+
+	return inventoryResult
+}
+
+func hasOverrideKey(auditResult *kubeaudit.AuditResult, key string) bool {
+	for _, resultKey := range strings.Split(auditResult.Metadata[OverrideKeyMetadataKey], ", ") {
+		if resultKey == key {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseOverrideKey returns the container name (empty for pod and namespace overrides) and the auditor override label
+// of an override label or annotation key. ok is false if the key isn't an override key
+func ParseOverrideKey(key string) (containerName, overrideLabel string, ok bool) {
+	for _, prefix := range []string{ContainerOverrideLabelPrefix, DeprecatedContainerOverrideLabelPrefix} {
+		if strings.HasPrefix(key, prefix) {
+			// Container names can't contain dots so the first dot separates the container name from the label
+			containerName, overrideLabel, ok = strings.Cut(strings.TrimPrefix(key, prefix), ".")
+			return containerName, overrideLabel, ok && containerName != "" && overrideLabel != ""
+		}
+	}
+
+	for _, prefix := range []string{OverrideLabelPrefix, DeprecatedPodOverrideLabelPrefix, DeprecatedNamespaceOverrideLabelPrefix} {
+		if strings.HasPrefix(key, prefix) {
+			overrideLabel = strings.TrimPrefix(key, prefix)
+			return "", overrideLabel, overrideLabel != ""
+		}
+	}
+
+	return "", "", false
+}
+
+// IsDeprecatedOverrideKey returns true if the key uses one of the deprecated kubernetes.io override prefixes
+func IsDeprecatedOverrideKey(key string) bool {
+	for _, prefix := range []string{DeprecatedContainerOverrideLabelPrefix, DeprecatedPodOverrideLabelPrefix, DeprecatedNamespaceOverrideLabelPrefix} {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetReplacementOverrideKey returns the kubeaudit.io equivalent of a deprecated override key. Other keys are
+// returned as is
+func GetReplacementOverrideKey(key string) string {
+	containerName, overrideLabel, ok := ParseOverrideKey(key)
+	if !ok || !IsDeprecatedOverrideKey(key) {
+		return key
+	}
+	if containerName != "" {
+		return GetContainerOverrideLabel(containerName, overrideLabel)
+	}
+	return GetOverrideLabel(overrideLabel)
+}
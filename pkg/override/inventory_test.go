@@ -0,0 +1,95 @@
+package override_test
+
+import (
+	"testing"
+
+	"github.com/Shopify/kubeaudit"
+	"github.com/Shopify/kubeaudit/auditors/hostns"
+	"github.com/Shopify/kubeaudit/auditors/netpols"
+	"github.com/Shopify/kubeaudit/auditors/privileged"
+	"github.com/Shopify/kubeaudit/internal/test"
+	"github.com/Shopify/kubeaudit/pkg/override"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInventory(t *testing.T) {
+	auditables := []kubeaudit.Auditable{privileged.New(), hostns.New()}
+	report := test.GetReport(t, fixtureDir, "inventory.yml", auditables, "", test.MANIFEST_MODE)
+	require.NotNil(t, report)
+
+	results := override.Inventory(report).Results()
+	require.Len(t, results, 1)
+
+	inventory := map[string]*kubeaudit.AuditResult{}
+	for _, auditResult := range results[0].GetAuditResults() {
+		inventory[auditResult.Metadata[override.OverrideLabelMetadataKey]] = auditResult
+	}
+	require.Len(t, inventory, 4)
+
+	active := inventory["container.kubeaudit.io/app.allow-privileged"]
+	assert.Equal(t, override.ActiveOverride, active.Rule)
+	assert.Equal(t, kubeaudit.Info, active.Severity)
+	assert.Equal(t, privileged.Name, active.Auditor)
+	assert.Equal(t, "app", active.Metadata["Container"])
+	assert.Equal(t, "NeedsDevices", active.Metadata["OverrideReason"])
+	assert.Equal(t, "true", active.Metadata["Needed"])
+
+	redundant := inventory["container.audit.kubernetes.io/sidecar.allow-privileged"]
+	assert.Equal(t, kubeaudit.RedundantAuditorOverride, redundant.Rule)
+	assert.Equal(t, privileged.Name, redundant.Auditor)
+	assert.Equal(t, "false", redundant.Metadata["Needed"])
+	assert.Equal(t, "container.kubeaudit.io/sidecar.allow-privileged", redundant.Metadata[override.ReplacementLabelMetadataKey])
+
+	ignored := inventory["kubeaudit.io/allow-namespace-host-network"]
+	assert.Equal(t, override.IgnoredOverride, ignored.Rule)
+	assert.Equal(t, hostns.Name, ignored.Auditor)
+	assert.Equal(t, "team-network", ignored.Metadata["OverrideOwner"])
+	assert.Equal(t, "2020-01-01", ignored.Metadata["OverrideExpires"])
+
+	unknown := inventory["kubeaudit.io/allow-something-else"]
+	assert.Equal(t, override.UnknownOverride, unknown.Rule)
+	assert.Equal(t, kubeaudit.Warn, unknown.Severity)
+	assert.Empty(t, unknown.Auditor)
+}
+
+func TestInventoryIgnoredNetworkPolicyOverrides(t *testing.T) {
+	report := test.GetReport(t, fixtureDir, "inventory-netpols.yml", []kubeaudit.Auditable{netpols.New()}, "", test.MANIFEST_MODE)
+	require.NotNil(t, report)
+
+	results := override.Inventory(report).Results()
+	require.Len(t, results, 1)
+
+	auditResults := results[0].GetAuditResults()
+	require.Len(t, auditResults, 2)
+	for _, auditResult := range auditResults {
+		assert.Equal(t, override.IgnoredOverride, auditResult.Rule, auditResult.Metadata[override.OverrideLabelMetadataKey])
+		assert.Equal(t, netpols.Name, auditResult.Auditor)
+	}
+}
+
+func TestParseOverrideKey(t *testing.T) {
+	cases := []struct {
+		key                   string
+		expectedContainerName string
+		expectedOverrideLabel string
+		expectedOk            bool
+	}{
+		{"container.kubeaudit.io/app.allow-privileged", "app", "allow-privileged", true},
+		{"container.audit.kubernetes.io/app.allow-privileged", "app", "allow-privileged", true},
+		{"kubeaudit.io/allow-privileged", "", "allow-privileged", true},
+		{"audit.kubernetes.io/pod.allow-privileged", "", "allow-privileged", true},
+		{"audit.kubernetes.io/namespace.allow-privileged", "", "allow-privileged", true},
+		{"container.kubeaudit.io/allow-privileged", "", "", false},
+		{"app.kubernetes.io/name", "", "", false},
+	}
+
+	for _, tc := range cases {
+		containerName, overrideLabel, ok := override.ParseOverrideKey(tc.key)
+		assert.Equal(t, tc.expectedOk, ok, tc.key)
+		if tc.expectedOk {
+			assert.Equal(t, tc.expectedContainerName, containerName, tc.key)
+			assert.Equal(t, tc.expectedOverrideLabel, overrideLabel, tc.key)
+		}
+	}
+}
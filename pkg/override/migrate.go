@@ -0,0 +1,100 @@
+package override
+
+import (
+	"fmt"
+
+	"github.com/Shopify/kubeaudit"
+	"github.com/Shopify/kubeaudit/pkg/k8s"
+)
+
+// MigrationName is the auditor name of the audit results produced by the override label migration
+const MigrationName = "overrides"
+
+const (
+	// DeprecatedOverrideLabel occurs when a resource uses a deprecated kubernetes.io override label
+	DeprecatedOverrideLabel = "DeprecatedOverrideLabel"
+	// DeprecatedOverrideLabelConflict occurs when a resource has both a deprecated kubernetes.io override label and
+	// its kubeaudit.io replacement with a different value, so the label can't be migrated automatically
+	DeprecatedOverrideLabelConflict = "DeprecatedOverrideLabelConflict"
+)
+
+// Migration implements Auditable. It finds deprecated kubernetes.io override labels and fixes them by renaming them
+// to their kubeaudit.io equivalents
+type Migration struct{}
+
+func NewMigration() *Migration {
+	return &Migration{}
+}
+
+// Audit checks the resource for deprecated override labels
+func (m *Migration) Audit(resource k8s.Resource, _ []k8s.Resource) ([]*kubeaudit.AuditResult, error) {
+	var auditResults []*kubeaudit.AuditResult
+
+	labels := k8s.GetLabels(resource)
+	for _, key := range getOverrideKeys(resource) {
+		if !IsDeprecatedOverrideKey(key) {
+			continue
+		}
+
+		replacement := GetReplacementOverrideKey(key)
+		metadata := kubeaudit.Metadata{
+			OverrideLabelMetadataKey:    key,
+			ReplacementLabelMetadataKey: replacement,
+		}
+
+		if value, ok := labels[replacement]; ok && value != labels[key] {
+			auditResults = append(auditResults, &kubeaudit.AuditResult{
+				Auditor:  MigrationName,
+				Rule:     DeprecatedOverrideLabelConflict,
+				Severity: kubeaudit.Warn,
+				Message:  fmt.Sprintf("Deprecated override label %s can't be migrated because %s is already set with a different value. One of them should be removed.", key, replacement),
+				Metadata: metadata,
+			})
+			continue
+		}
+
+		auditResults = append(auditResults, &kubeaudit.AuditResult{
+			Auditor:  MigrationName,
+			Rule:     DeprecatedOverrideLabel,
+			Severity: kubeaudit.Warn,
+			Message:  fmt.Sprintf("Override label %s is deprecated. It should be replaced with %s.", key, replacement),
+			PendingFix: &fixByRenamingLabel{
+				key:         key,
+				replacement: replacement,
+			},
+			Metadata: metadata,
+		})
+	}
+
+	return auditResults, nil
+}
+
+type fixByRenamingLabel struct {
+	key         string
+	replacement string
+}
+
+func (f *fixByRenamingLabel) Plan() string {
+	return fmt.Sprintf("Rename override label %s to %s", f.key, f.replacement)
+}
+
+// RenamedKeys lets the fixed manifest keep the position and comments of the renamed label
+func (f *fixByRenamingLabel) RenamedKeys() map[string]string {
+	return map[string]string{f.key: f.replacement}
+}
+
+func (f *fixByRenamingLabel) Apply(resource k8s.Resource) []k8s.Resource {
+	objectMeta := k8s.GetPodObjectMeta(resource)
+	if objectMeta == nil {
+		return nil
+	}
+
+	labels := objectMeta.GetLabels()
+	if _, ok := labels[f.replacement]; !ok {
+		labels[f.replacement] = labels[f.key]
+	}
+	delete(labels, f.key)
+	objectMeta.SetLabels(labels)
+
+	return nil
+}
@@ -0,0 +1,42 @@
+package override_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/Shopify/kubeaudit/internal/test"
+	"github.com/Shopify/kubeaudit/pkg/k8s"
+	"github.com/Shopify/kubeaudit/pkg/override"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigration(t *testing.T) {
+	test.AuditManifest(t, fixtureDir, "migrate.yml", override.NewMigration(), []string{
+		override.DeprecatedOverrideLabel,
+		override.DeprecatedOverrideLabelConflict,
+	})
+}
+
+func TestMigrationFix(t *testing.T) {
+	resources, report := test.FixSetup(t, fixtureDir, "migrate.yml", override.NewMigration())
+	require.Len(t, resources, 2)
+
+	assert.Equal(t, map[string]string{
+		"kubeaudit.io/allow-non-default-deny-egress-network-policy": "PlatformManaged",
+	}, k8s.GetLabels(resources[0]))
+
+	assert.Equal(t, map[string]string{
+		"name": "migrate",
+		"container.kubeaudit.io/app.allow-privileged": "NeedsDevices",
+		"kubeaudit.io/allow-namespace-host-network":   "true",
+		"audit.kubernetes.io/pod.allow-run-as-root":   "Legacy",
+		"kubeaudit.io/allow-run-as-root":              "NewReason",
+	}, k8s.GetLabels(resources[1]))
+
+	// Renamed labels keep their position, comments and quoting
+	fixedManifest := bytes.NewBuffer(nil)
+	require.NoError(t, report.Fix(fixedManifest))
+	assert.Contains(t, fixedManifest.String(), "# Default deny policies are managed by the platform team\n    kubeaudit.io/allow-non-default-deny-egress-network-policy: \"PlatformManaged\"\n")
+	assert.Contains(t, fixedManifest.String(), "# The app needs access to devices\n        container.kubeaudit.io/app.allow-privileged: \"NeedsDevices\"\n")
+}
@@ -1,7 +1,7 @@
 package override
 
 import (
-	"strings"
+	"fmt"
 
 	"github.com/Shopify/kubeaudit"
 	"github.com/Shopify/kubeaudit/pkg/k8s"
@@ -24,6 +24,14 @@ const (
 
 	// OverrideLabelPrefix is used to disable an auditor for either a pod or namespace
 	OverrideLabelPrefix = "kubeaudit.io/"
+
+	// OverrideLabelMetadataKey is the audit result metadata key holding the override label (eg. allow-privileged) of a
+	// redundant override
+	OverrideLabelMetadataKey = "OverrideLabel"
+
+	// OverrideKeyMetadataKey is the audit result metadata key holding the override label or annotation key which
+	// overrode the audit result, or which is redundant
+	OverrideKeyMetadataKey = "OverrideKey"
 )
 
 // GetOverriddenResultName takes an audit result name and modifies it to indicate that the security issue was
@@ -41,42 +49,90 @@ func NewRedundantOverrideResult(auditorName, containerName, overrideReason, over
 		Severity: kubeaudit.Warn,
 		Message:  "Auditor is disabled via label but there were no security issues found by the auditor. The label should be removed.",
 		Metadata: kubeaudit.Metadata{
-			"Container":     containerName,
-			"OverrideLabel": overrideLabel,
+			"Container":              containerName,
+			OverrideLabelMetadataKey: overrideLabel,
 		},
 	}
 }
 
-// ApplyOverride checks if hasOverride is true. If it is, it changes the severity of the audit result from error to
-// info, adds the override reason to the metadata and removes the pending fix
+// ApplyOverride checks if the resource has an override label or annotation for the auditor. If it does, it changes
+// the severity of the audit result from error to info, adds the override reason to the metadata and removes the
+// pending fix. Overrides which are expired or unparseable are ignored and the audit result keeps its original severity
 func ApplyOverride(auditResult *kubeaudit.AuditResult, auditorName, containerName string, resource k8s.Resource, overrideLabel string) *kubeaudit.AuditResult {
-	hasOverride, overrideReason := GetContainerOverrideReason(containerName, resource, overrideLabel)
+	override := GetContainerOverride(containerName, resource, overrideLabel)
 
-	if !hasOverride {
+	if override == nil {
 		return auditResult
 	}
 
 	if auditResult == nil {
-		return NewRedundantOverrideResult(auditorName, containerName, overrideReason, overrideLabel)
+		redundantResult := NewRedundantOverrideResult(auditorName, containerName, override.Reason, overrideLabel)
+		redundantResult.Metadata[OverrideKeyMetadataKey] = override.Key
+		return redundantResult
+	}
+
+	auditResult = applyOverride(auditResult, override)
+	auditResult.Metadata[OverrideKeyMetadataKey] = override.Key
+	return auditResult
+}
+
+// ApplyOverrides applies the override label to each audit result and returns the ones which aren't nil. Nil audit
+// results are rules which passed, so the override label is checked for redundancy for them, as it is if there are no
+// audit results at all
+func ApplyOverrides(auditResults []*kubeaudit.AuditResult, auditorName, containerName string, resource k8s.Resource, overrideLabel string) []*kubeaudit.AuditResult {
+	if len(auditResults) == 0 {
+		auditResults = []*kubeaudit.AuditResult{nil}
+	}
+
+	var overriddenResults []*kubeaudit.AuditResult
+	for _, auditResult := range auditResults {
+		auditResult = ApplyOverride(auditResult, auditorName, containerName, resource, overrideLabel)
+		if auditResult != nil {
+			overriddenResults = append(overriddenResults, auditResult)
+		}
+	}
+	return overriddenResults
+}
+
+// applyOverride overrides the audit result unless the override should be ignored, in which case the reason it was
+// ignored is added to the audit result
+func applyOverride(auditResult *kubeaudit.AuditResult, override *Override) *kubeaudit.AuditResult {
+	if auditResult.Metadata == nil {
+		auditResult.Metadata = make(kubeaudit.Metadata)
 	}
 
+	if ignored := override.Ignored(); ignored != "" {
+		return ignoreOverride(auditResult, override.Key, ignored)
+	}
+
+	overriddenResult := *auditResult
+	overriddenResult.Metadata = make(kubeaudit.Metadata, len(auditResult.Metadata))
+	for key, value := range auditResult.Metadata {
+		overriddenResult.Metadata[key] = value
+	}
+	auditResult.OverriddenResult = &overriddenResult
+
 	auditResult.Rule = GetOverriddenResultName(auditResult.Rule)
 	auditResult.PendingFix = nil
 	auditResult.Severity = kubeaudit.Info
 	auditResult.Message = "Audit result overridden: " + auditResult.Message
 
-	if overrideReason != "" && strings.ToLower(overrideReason) != "true" {
-		if auditResult.Metadata == nil {
-			auditResult.Metadata = make(kubeaudit.Metadata)
-		}
-		auditResult.Metadata["OverrideReason"] = overrideReason
+	for key, value := range override.Metadata() {
+		auditResult.Metadata[key] = value
 	}
 
 	return auditResult
 }
 
-// GetContainerOverrideReason returns true if the resource has a pod-level label disabling a given auditor and the
-// value of the label which is meant to represent the reason for overriding the auditor
+// ignoreOverride adds the reason the override was ignored to the audit result
+func ignoreOverride(auditResult *kubeaudit.AuditResult, overrideKey, ignored string) *kubeaudit.AuditResult {
+	auditResult.Message = fmt.Sprintf("%s Override %s was ignored because %s.", auditResult.Message, overrideKey, ignored)
+	auditResult.Metadata["OverrideIgnored"] = ignored
+	return auditResult
+}
+
+// GetContainerOverrideReason returns true if the resource has a valid pod-level label or annotation disabling a given
+// auditor and the value of the label which is meant to represent the reason for overriding the auditor
 //
 // Container override labels disable the auditor for that specific container and have the following format:
 //
@@ -84,23 +140,11 @@ func ApplyOverride(auditResult *kubeaudit.AuditResult, auditorName, containerNam
 //
 // If there is no container override label, it calls GetResourceOverrideReason()
 func GetContainerOverrideReason(containerName string, resource k8s.Resource, overrideLabel string) (hasOverride bool, reason string) {
-	labels := k8s.GetLabels(resource)
-
-	if containerName != "" {
-		if reason, hasOverride = labels[GetDeprecatedContainerOverrideLabel(containerName, overrideLabel)]; hasOverride {
-			return
-		}
-
-		if reason, hasOverride = labels[GetContainerOverrideLabel(containerName, overrideLabel)]; hasOverride {
-			return
-		}
-	}
-
-	return GetResourceOverrideReason(resource, overrideLabel)
+	return getOverrideReason(GetContainerOverride(containerName, resource, overrideLabel))
 }
 
-// GetResourceOverrideReason returns true if the resource has a label disabling a given auditor and the value of the
-// label which is meant to represent the reason for overriding the auditor
+// GetResourceOverrideReason returns true if the resource has a valid label or annotation disabling a given auditor
+// and the value of the label which is meant to represent the reason for overriding the auditor
 //
 // Pod override labels disable the auditor for the pod and all containers within the pod and have the following format:
 //
@@ -110,20 +154,59 @@ func GetContainerOverrideReason(containerName string, resource k8s.Resource, ove
 //
 // kubeaudit.io/[auditor override label]
 func GetResourceOverrideReason(resource k8s.Resource, auditorOverrideLabel string) (hasOverride bool, reason string) {
+	return getOverrideReason(GetResourceOverride(resource, auditorOverrideLabel))
+}
+
+func getOverrideReason(override *Override) (hasOverride bool, reason string) {
+	if override == nil || override.Ignored() != "" {
+		return false, ""
+	}
+	return true, override.Reason
+}
+
+// GetContainerOverride returns the container override label and/or annotation disabling a given auditor for a
+// container. If there is none, it calls GetResourceOverride(). Overrides are returned even if they should be ignored,
+// see Override.Ignored()
+func GetContainerOverride(containerName string, resource k8s.Resource, overrideLabel string) *Override {
+	labels := k8s.GetLabels(resource)
+	annotations := k8s.GetAnnotations(resource)
+
+	if containerName != "" {
+		if override := newOverride(GetDeprecatedContainerOverrideLabel(containerName, overrideLabel), labels, nil); override != nil {
+			return override
+		}
+
+		if override := newOverride(GetContainerOverrideLabel(containerName, overrideLabel), labels, annotations); override != nil {
+			return override
+		}
+	}
+
+	return GetResourceOverride(resource, overrideLabel)
+}
+
+// GetResourceOverride returns the pod or namespace override label and/or annotation disabling a given auditor.
+// Overrides are returned even if they should be ignored, see Override.Ignored()
+func GetResourceOverride(resource k8s.Resource, auditorOverrideLabel string) *Override {
+	labels := k8s.GetLabels(resource)
+	annotations := k8s.GetAnnotations(resource)
+
+	if override := newOverride(GetOverrideLabel(auditorOverrideLabel), labels, annotations); override != nil {
+		return override
+	}
+
+	// Deprecated labels are not supported as annotations
 	labelFuncs := []func(overrideLabel string) string{
-		GetOverrideLabel,
 		GetDeprecatedPodOverrideLabel,
 		GetDeprecatedNamespaceOverrideLabel,
 	}
 
-	labels := k8s.GetLabels(resource)
 	for _, getLabel := range labelFuncs {
-		if reason, hasOverride = labels[getLabel(auditorOverrideLabel)]; hasOverride {
-			return
+		if override := newOverride(getLabel(auditorOverrideLabel), labels, nil); override != nil {
+			return override
 		}
 	}
 
-	return false, ""
+	return nil
 }
 
 // TODO: remove deprecated getters
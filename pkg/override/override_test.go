@@ -0,0 +1,203 @@
+package override
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Shopify/kubeaudit"
+	"github.com/Shopify/kubeaudit/pkg/k8s"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testOverrideLabel = "allow-test"
+	testRule          = "TestRule"
+)
+
+func TestApplyOverride(t *testing.T) {
+	now = func() time.Time { return time.Date(2022, 6, 30, 12, 0, 0, 0, time.UTC) }
+	defer func() { now = time.Now }()
+
+	cases := []struct {
+		testName         string
+		labels           map[string]string
+		annotations      map[string]string
+		expectedRule     string
+		expectedMetadata map[string]string
+	}{
+		{
+			testName:     "No override",
+			expectedRule: testRule,
+		},
+		{
+			testName:         "Label",
+			labels:           map[string]string{GetOverrideLabel(testOverrideLabel): "SomeReason"},
+			expectedRule:     GetOverriddenResultName(testRule),
+			expectedMetadata: map[string]string{"OverrideReason": "SomeReason"},
+		},
+		{
+			testName:     "Label without reason",
+			labels:       map[string]string{GetOverrideLabel(testOverrideLabel): "true"},
+			expectedRule: GetOverriddenResultName(testRule),
+		},
+		{
+			testName: "Container annotation",
+			annotations: map[string]string{
+				GetContainerOverrideLabel("container", testOverrideLabel): "{reason: Some reason, owner: team-a, ticket: SEC-1, expires: 2022-06-30}",
+			},
+			expectedRule: GetOverriddenResultName(testRule),
+			expectedMetadata: map[string]string{
+				"OverrideReason":  "Some reason",
+				"OverrideOwner":   "team-a",
+				"OverrideTicket":  "SEC-1",
+				"OverrideExpires": "2022-06-30",
+			},
+		},
+		{
+			testName:         "Label reason takes precedence over annotation reason",
+			labels:           map[string]string{GetOverrideLabel(testOverrideLabel): "LabelReason"},
+			annotations:      map[string]string{GetOverrideLabel(testOverrideLabel): "{reason: Annotation reason}"},
+			expectedRule:     GetOverriddenResultName(testRule),
+			expectedMetadata: map[string]string{"OverrideReason": "LabelReason"},
+		},
+		{
+			testName:         "Expired",
+			labels:           map[string]string{GetOverrideLabel(testOverrideLabel): "SomeReason"},
+			annotations:      map[string]string{GetOverrideLabel(testOverrideLabel): "{expires: 2022-06-29}"},
+			expectedRule:     testRule,
+			expectedMetadata: map[string]string{"OverrideIgnored": "the override expired on 2022-06-29"},
+		},
+		{
+			testName:         "Invalid expiry date",
+			annotations:      map[string]string{GetOverrideLabel(testOverrideLabel): "{reason: Some reason, expires: 30/06/2022}"},
+			expectedRule:     testRule,
+			expectedMetadata: map[string]string{"OverrideIgnored": "the expiry date \"30/06/2022\" is not in the format YYYY-MM-DD"},
+		},
+		{
+			testName:         "Plain string annotation",
+			annotations:      map[string]string{GetOverrideLabel(testOverrideLabel): "needed by CNI"},
+			expectedRule:     GetOverriddenResultName(testRule),
+			expectedMetadata: map[string]string{"OverrideReason": "needed by CNI"},
+		},
+		{
+			testName:     "Plain string annotation without reason",
+			annotations:  map[string]string{GetOverrideLabel(testOverrideLabel): "true"},
+			expectedRule: GetOverriddenResultName(testRule),
+		},
+		{
+			testName:         "Unparseable annotation",
+			annotations:      map[string]string{GetOverrideLabel(testOverrideLabel): "{reason: [Some reason}"},
+			expectedRule:     testRule,
+			expectedMetadata: map[string]string{"OverrideIgnored": "could not be parsed"},
+		},
+		{
+			testName:         "Malformed annotation",
+			annotations:      map[string]string{GetOverrideLabel(testOverrideLabel): "{reason: {text: Some reason}}"},
+			expectedRule:     testRule,
+			expectedMetadata: map[string]string{"OverrideIgnored": "could not be parsed"},
+		},
+		{
+			testName:         "Annotation list",
+			annotations:      map[string]string{GetOverrideLabel(testOverrideLabel): "[Some reason]"},
+			expectedRule:     testRule,
+			expectedMetadata: map[string]string{"OverrideIgnored": "could not be parsed"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(t *testing.T) {
+			resource := k8s.NewDeployment()
+			resource.Spec.Template.Labels = tc.labels
+			resource.Spec.Template.Annotations = tc.annotations
+
+			auditResult := &kubeaudit.AuditResult{
+				Rule:     testRule,
+				Severity: kubeaudit.Error,
+				Message:  "Test message.",
+			}
+			auditResult = ApplyOverride(auditResult, "test", "container", resource, testOverrideLabel)
+			require.NotNil(t, auditResult)
+			assert.Equal(t, tc.expectedRule, auditResult.Rule)
+
+			if tc.expectedRule == testRule {
+				assert.Equal(t, kubeaudit.Error, auditResult.Severity)
+				assert.Nil(t, auditResult.OverriddenResult)
+			} else {
+				assert.Equal(t, kubeaudit.Info, auditResult.Severity)
+				require.NotNil(t, auditResult.OverriddenResult)
+				assert.Equal(t, testRule, auditResult.OverriddenResult.Rule)
+				assert.Equal(t, kubeaudit.Error, auditResult.OverriddenResult.Severity)
+			}
+
+			for key, value := range tc.expectedMetadata {
+				assert.Contains(t, auditResult.Metadata[key], value)
+			}
+		})
+	}
+}
+
+func TestApplyOverrides(t *testing.T) {
+	newAuditResult := func() *kubeaudit.AuditResult {
+		return &kubeaudit.AuditResult{Rule: testRule, Severity: kubeaudit.Error, Metadata: kubeaudit.Metadata{}}
+	}
+
+	cases := []struct {
+		testName      string
+		labels        map[string]string
+		auditResults  []*kubeaudit.AuditResult
+		expectedRules []string
+	}{
+		{
+			testName: "No audit results",
+		},
+		{
+			testName:      "No audit results with override",
+			labels:        map[string]string{GetOverrideLabel(testOverrideLabel): "SomeReason"},
+			expectedRules: []string{kubeaudit.RedundantAuditorOverride},
+		},
+		{
+			testName:      "Nil audit result with override",
+			labels:        map[string]string{GetOverrideLabel(testOverrideLabel): "SomeReason"},
+			auditResults:  []*kubeaudit.AuditResult{nil},
+			expectedRules: []string{kubeaudit.RedundantAuditorOverride},
+		},
+		{
+			testName:      "Audit results",
+			auditResults:  []*kubeaudit.AuditResult{newAuditResult(), nil, newAuditResult()},
+			expectedRules: []string{testRule, testRule},
+		},
+		{
+			testName:      "Audit results with override",
+			labels:        map[string]string{GetOverrideLabel(testOverrideLabel): "SomeReason"},
+			auditResults:  []*kubeaudit.AuditResult{newAuditResult(), newAuditResult()},
+			expectedRules: []string{GetOverriddenResultName(testRule), GetOverriddenResultName(testRule)},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(t *testing.T) {
+			resource := k8s.NewDeployment()
+			resource.Spec.Template.Labels = tc.labels
+
+			var rules []string
+			for _, auditResult := range ApplyOverrides(tc.auditResults, "test", "", resource, testOverrideLabel) {
+				rules = append(rules, auditResult.Rule)
+			}
+			assert.Equal(t, tc.expectedRules, rules)
+		})
+	}
+}
+
+func TestGetResourceOverrideReason(t *testing.T) {
+	resource := k8s.NewNamespace()
+	resource.Labels = map[string]string{GetOverrideLabel(testOverrideLabel): "SomeReason"}
+
+	hasOverride, reason := GetResourceOverrideReason(resource, testOverrideLabel)
+	assert.True(t, hasOverride)
+	assert.Equal(t, "SomeReason", reason)
+
+	resource.Annotations = map[string]string{GetOverrideLabel(testOverrideLabel): "{expires: 2020-01-01}"}
+	hasOverride, _ = GetResourceOverrideReason(resource, testOverrideLabel)
+	assert.False(t, hasOverride)
+}
@@ -67,6 +67,7 @@ func NewPrinter(opts ...PrintOption) Printer {
 func (p *Printer) PrintReport(report *Report) {
 	if p.formatter == nil {
 		p.prettyPrintReport(report)
+		p.prettyPrintCoverage(report)
 	} else {
 		p.logReport(report)
 	}
@@ -122,6 +123,25 @@ func (p *Printer) prettyPrintReport(report *Report) {
 	}
 }
 
+// prettyPrintCoverage prints the resources which couldn't be audited, if any
+func (p *Printer) prettyPrintCoverage(report *Report) {
+	if report.IsCoverageComplete() {
+		return
+	}
+
+	p.printColor(color.YellowColor, "\n---------------- Coverage ----------------\n\n")
+	p.printColor(color.YellowColor, fmt.Sprintf("  %d resources or API groups could not be audited\n", len(report.Coverage())))
+	p.printColor(color.YellowColor, "\n------------------------------------------\n\n")
+
+	for _, skipped := range report.Coverage() {
+		p.print("-- ")
+		p.printColor(color.YellowColor, "[skipped] ")
+		p.print(skipped.String() + "\n")
+		p.print("   Reason: " + string(skipped.Reason) + "\n")
+		p.print("   Message: " + skipped.Message + "\n\n")
+	}
+}
+
 func (p *Printer) print(s string) {
 	fmt.Fprint(p.writer, s)
 }
@@ -147,6 +167,27 @@ func (p *Printer) logReport(report *Report) {
 			p.logAuditResult(workloadResult.GetResource().Object(), auditResult, resultLogger)
 		}
 	}
+
+	for _, skipped := range report.Coverage() {
+		p.logSkippedResource(skipped, resultLogger)
+	}
+}
+
+func (p *Printer) logSkippedResource(skipped SkippedResource, baseLogger *log.Logger) {
+	fields := log.Fields{
+		"SkipReason":         string(skipped.Reason),
+		"ResourceApiVersion": skipped.APIVersion,
+	}
+	if skipped.Kind != "" {
+		fields["ResourceKind"] = skipped.Kind
+	}
+	if skipped.Namespace != "" {
+		fields["ResourceNamespace"] = skipped.Namespace
+	}
+	if skipped.Name != "" {
+		fields["ResourceName"] = skipped.Name
+	}
+	baseLogger.WithFields(fields).Warn(skipped.Message)
 }
 
 func (p *Printer) logAuditResult(resource k8s.Resource, result *AuditResult, baseLogger *log.Logger) {
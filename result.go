@@ -44,6 +44,9 @@ type AuditResult struct {
 	PendingFix PendingFix    // PendingFix is the fix that will be applied to automatically fix the security issue
 	Metadata   Metadata      // Metadata includes additional context for an audit result
 	FilePath   string        // Manifest file path
+	// OverriddenResult is the audit result before it was overridden by an override label or annotation, so the
+	// override can be ignored later on (see override.Config). It is nil if the result wasn't overridden
+	OverriddenResult *AuditResult
 }
 
 func (result *AuditResult) Fix(resource k8s.Resource) (newResources []k8s.Resource) {
@@ -74,6 +77,17 @@ type PendingFix interface {
 // Metadata holds metadata for a potential security issue
 type Metadata = map[string]string
 
+const (
+	// ContainerMetadataKey is the metadata key for the name of the container an audit result is about
+	ContainerMetadataKey = "Container"
+	// ContainerTypeMetadataKey is the metadata key for the type of the container an audit result is about (see
+	// k8s.ContainerType). It is set for every audit result with a container name
+	ContainerTypeMetadataKey = "ContainerType"
+	// PodTemplatePathMetadataKey is the metadata key for the path of the pod template an audit result is about. It is
+	// set for the audit results of custom resources with several pod templates (see k8s.CustomResourceConfig)
+	PodTemplatePathMetadataKey = "PodTemplatePath"
+)
+
 // Implements Result
 type WorkloadResult struct {
 	Resource     KubeResource
@@ -9,47 +9,59 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
-func getResourcesFromClient(client k8sinternal.KubeClient, options k8sinternal.ClientOptions) ([]KubeResource, error) {
+func getResourcesFromClient(client k8sinternal.KubeClient, options k8sinternal.ClientOptions) ([]KubeResource, []SkippedResource, error) {
 	var resources []KubeResource
 
-	k8sresources, err := client.GetAllResources(options)
+	k8sresources, skipped, err := client.GetAllResources(options)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	for _, resource := range k8sresources {
 		resources = append(resources, &kubeResource{object: resource})
 	}
 
-	return resources, nil
+	return resources, skipped, nil
 }
 
-func getResourcesFromManifest(data []byte) ([]KubeResource, error) {
+// getResourcesFromManifest decodes the resources in the manifest. Documents which can't be decoded are kept as bytes so
+// they are preserved by autofix, and are returned as skipped resources
+func getResourcesFromManifest(data []byte, customResourceKinds *k8s.CustomResourceKinds) ([]KubeResource, []SkippedResource, error) {
 	var resources []KubeResource
+	var skipped []SkippedResource
 	bufSlice := bytes.Split(data, []byte("---"))
 
 	for _, b := range bufSlice {
-		obj, err := k8sinternal.DecodeResource(b)
+		obj, err := k8sinternal.DecodeResource(b, customResourceKinds)
 		if err == nil && obj != nil {
 			source := &kubeResource{
 				object: obj,
 				bytes:  b,
 			}
 			resources = append(resources, source)
-		} else if err := yaml.Unmarshal(data, &yaml.Node{}); err != nil {
-			return nil, fmt.Errorf("Invalid yaml: %w", err)
-		} else {
-			resources = append(resources, &kubeResource{bytes: b})
+			continue
+		}
+
+		if yamlErr := yaml.Unmarshal(data, &yaml.Node{}); yamlErr != nil {
+			return nil, nil, fmt.Errorf("Invalid yaml: %w", yamlErr)
+		}
+		resources = append(resources, &kubeResource{bytes: b})
+		if err != nil {
+			if skippedResource, ok := k8sinternal.NewManifestSkippedResource(b, err); ok {
+				skipped = append(skipped, skippedResource)
+			}
 		}
 	}
 
-	return resources, nil
+	return resources, skipped, nil
 }
 
 func auditResources(resources []KubeResource, auditable []Auditable) ([]Result, error) {
 	var results []Result
 
+	// The resources are unwrapped once so every auditor sees the same slice for the whole audit
+	unwrappedResources := unwrapResources(resources)
 	for _, resource := range resources {
-		result, err := auditResource(resource, resources, auditable)
+		result, err := auditResource(resource, unwrappedResources, auditable)
 		if err != nil {
 			return nil, err
 		}
@@ -59,7 +71,7 @@ func auditResources(resources []KubeResource, auditable []Auditable) ([]Result,
 	return results, nil
 }
 
-func auditResource(resource KubeResource, resources []KubeResource, auditables []Auditable) (Result, error) {
+func auditResource(resource KubeResource, resources []k8s.Resource, auditables []Auditable) (Result, error) {
 	result := &WorkloadResult{
 		Resource:     resource,
 		AuditResults: []*AuditResult{},
@@ -69,10 +81,19 @@ func auditResource(resource KubeResource, resources []KubeResource, auditables [
 		return result, nil
 	}
 
-	for _, auditable := range auditables {
-		auditResults, err := auditable.Audit(resource.Object(), unwrapResources(resources))
-		if err != nil {
-			return nil, err
+	for _, object := range getPodTemplateViews(resource.Object()) {
+		var auditResults []*AuditResult
+		for _, auditable := range auditables {
+			objectAuditResults, err := auditable.Audit(object, resources)
+			if err != nil {
+				return nil, err
+			}
+			auditResults = append(auditResults, objectAuditResults...)
+		}
+
+		setContainerTypes(auditResults, object)
+		if object != resource.Object() {
+			setPodTemplatePaths(auditResults, object.(*k8s.CustomResource))
 		}
 		result.AuditResults = append(result.AuditResults, auditResults...)
 	}
@@ -80,6 +101,77 @@ func auditResource(resource KubeResource, resources []KubeResource, auditables [
 	return result, nil
 }
 
+// getPodTemplateViews returns the resources to audit for a resource. Custom resources with several pod templates are
+// audited once per pod template, every other resource is audited as is
+func getPodTemplateViews(resource k8s.Resource) []k8s.Resource {
+	customResource, ok := resource.(*k8s.CustomResource)
+	if !ok {
+		return []k8s.Resource{resource}
+	}
+
+	views := customResource.PodTemplateViews()
+	if len(views) == 1 {
+		return []k8s.Resource{resource}
+	}
+
+	resources := make([]k8s.Resource, 0, len(views))
+	for _, view := range views {
+		resources = append(resources, view)
+	}
+	return resources
+}
+
+// setPodTemplatePaths adds the path of the pod template to the metadata of the audit results about a pod template view
+// of a custom resource. Their fixes are applied to the pod template of the view instead of the first pod template
+func setPodTemplatePaths(auditResults []*AuditResult, view *k8s.CustomResource) {
+	for _, auditResult := range auditResults {
+		if auditResult.Metadata == nil {
+			auditResult.Metadata = Metadata{}
+		}
+		auditResult.Metadata[PodTemplatePathMetadataKey] = view.PodTemplatePath()
+		if auditResult.PendingFix != nil {
+			auditResult.PendingFix = &podTemplateFix{PendingFix: auditResult.PendingFix, view: view}
+		}
+	}
+}
+
+// podTemplateFix applies a fix to a pod template view of a custom resource. The view shares its pod templates with the
+// resource so the fix is written back when the resource is encoded
+type podTemplateFix struct {
+	PendingFix
+	view *k8s.CustomResource
+}
+
+func (fix *podTemplateFix) Apply(_ k8s.Resource) []k8s.Resource {
+	return fix.PendingFix.Apply(fix.view)
+}
+
+func (fix *podTemplateFix) RenamedKeys() map[string]string {
+	if renamingFix, ok := fix.PendingFix.(keyRenamingFix); ok {
+		return renamingFix.RenamedKeys()
+	}
+	return nil
+}
+
+// setContainerTypes adds the container type to the metadata of the audit results about a container. Ephemeral containers
+// can't be changed once they are added to a pod so the audit results about them are not fixed
+func setContainerTypes(auditResults []*AuditResult, resource k8s.Resource) {
+	for _, auditResult := range auditResults {
+		containerName, ok := auditResult.Metadata[ContainerMetadataKey]
+		if !ok {
+			continue
+		}
+		containerType, ok := k8s.GetContainerType(resource, containerName)
+		if !ok {
+			continue
+		}
+		auditResult.Metadata[ContainerTypeMetadataKey] = string(containerType)
+		if containerType == k8s.ContainerTypeEphemeral {
+			auditResult.PendingFix = nil
+		}
+	}
+}
+
 func unwrapResources(resources []KubeResource) []k8s.Resource {
 	unwrappedResources := make([]k8s.Resource, 0, len(resources))
 	for _, resource := range resources {